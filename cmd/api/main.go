@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/davidrdsilva/blog-api/internal/application/workers"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/ai"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/database"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/fetcher"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/repository"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/storage"
@@ -58,10 +61,11 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize MinIO storage
-	minioStorage, err := storage.NewMinIOStorage(cfg, logger)
+	// Initialize object storage (MinIO, local filesystem or in-memory, per
+	// STORAGE_BACKEND)
+	objectStorage, err := storage.New(cfg, logger)
 	if err != nil {
-		logger.Error("Failed to initialize MinIO storage", logging.F("error", err.Error()))
+		logger.Error("Failed to initialize object storage", logging.F("error", err.Error()))
 		os.Exit(1)
 	}
 
@@ -71,6 +75,13 @@ func main() {
 	categoryRepo := repository.NewPostgresCategoryRepository(db)
 	tagRepo := repository.NewPostgresTagRepository(db)
 	characterRepo := repository.NewPostgresCharacterRepository(db)
+	arcRepo := repository.NewPostgresWhitenestArcRepository(db)
+	relationshipRepo := repository.NewPostgresCharacterRelationshipRepository(db)
+	progressRepo := repository.NewPostgresReaderProgressRepository(db)
+	exportRepo := repository.NewPostgresWhitenestExportRepository(db)
+	mediaRepo := repository.NewPostgresMediaRepository(db)
+	linkPreviewRepo := repository.NewPostgresLinkPreviewRepository(db)
+	linkCheckRepo := repository.NewPostgresLinkCheckRepository(db)
 
 	// Set up the AI comment generation pipeline:
 	// PostService -> jobCh -> CommentWorker -> AICommentService -> Gemini (Ollama fallback) -> DB
@@ -103,15 +114,76 @@ func main() {
 	viewWorker := workers.NewViewCounterWorker(viewCh, postRepo, logger)
 	viewWorker.Start(ctx)
 
+	// EPUB export pipeline: export.epub -> exportCh -> ExportWorker -> storage.
+	// Builds run one at a time; a full queue is reported to the client.
+	exportCh := make(chan jobs.BuildWhitenestExportJob, 20)
+
+	// Outbound fetches of user-supplied URLs (upload by URL) go through a
+	// client that refuses private/loopback destinations.
+	uploadFetcher := fetcher.New(fetcher.Options{
+		Timeout:      time.Duration(cfg.Upload.FetchTimeoutSeconds) * time.Second,
+		MaxRedirects: cfg.Upload.FetchMaxRedirects,
+		MaxBytes:     cfg.Upload.MaxUploadBytes(),
+	})
+	// Link previews use the same protection with their own limits. The body
+	// is capped by URLService, which truncates rather than fails.
+	previewFetcher := fetcher.New(fetcher.Options{
+		Timeout:      time.Duration(cfg.LinkPreview.FetchTimeoutSeconds) * time.Second,
+		MaxRedirects: cfg.LinkPreview.FetchMaxRedirects,
+	})
+	// The link checker follows longer redirect chains so it can report where
+	// a moved link ends up.
+	linkCheckFetcher := fetcher.New(fetcher.Options{
+		Timeout:      time.Duration(cfg.LinkCheck.TimeoutSeconds) * time.Second,
+		MaxRedirects: 10,
+	})
+
 	// Initialize services
-	postService := services.NewPostService(postRepo, categoryRepo, tagRepo, characterRepo, cfg, jobCh, viewCh, logger)
-	uploadService := services.NewUploadService(minioStorage)
-	urlService := services.NewURLService()
+	uploadService := services.NewUploadService(objectStorage, &cfg.Upload, mediaRepo, uploadFetcher, logger)
+	postService := services.NewPostService(postRepo, categoryRepo, tagRepo, characterRepo, cfg, objectStorage, uploadService, jobCh, viewCh, logger)
+	urlService := services.NewURLService(previewFetcher, linkPreviewRepo, cfg.LinkPreview, logger)
 	commentService := services.NewCommentService(commentRepo, postRepo, cfg)
 	categoryService := services.NewCategoryService(categoryRepo)
 	tagService := services.NewTagService(tagRepo)
-	whitenestService := services.NewWhitenestService(postRepo, viewCh, logger)
-	characterService := services.NewCharacterService(characterRepo)
+	whitenestService := services.NewWhitenestService(postRepo, arcRepo, characterRepo, objectStorage, viewCh, logger)
+	characterService := services.NewCharacterService(characterRepo, postRepo, relationshipRepo)
+	progressService := services.NewReaderProgressService(postRepo, progressRepo, logger)
+	exportService := services.NewWhitenestExportService(postRepo, arcRepo, characterRepo, exportRepo, characterService, objectStorage, cfg.Export, exportCh, logger)
+	exportService.FailInterrupted()
+	exportWorker := workers.NewExportWorker(exportCh, exportService, logger)
+	exportWorker.Start(ctx)
+	// Queued chapters go live from the release scheduler.
+	releaseWorker := workers.NewChapterReleaseWorker(
+		whitenestService,
+		time.Duration(cfg.Schedule.IntervalSeconds)*time.Second,
+		logger,
+	)
+	releaseWorker.Start(ctx)
+	mediaService := services.NewMediaService(mediaRepo, objectStorage, logger)
+	mediaGCService := services.NewMediaGCService(mediaRepo, objectStorage, cfg.MediaGC, logger)
+	linkCheckService := services.NewLinkCheckService(linkCheckRepo, linkCheckFetcher, cfg.LinkCheck, logger)
+
+	// Orphaned-media sweeps only run when enabled; the dry-run report endpoint
+	// works either way.
+	if cfg.MediaGC.Enabled {
+		mediaGCWorker := workers.NewMediaGCWorker(
+			mediaGCService,
+			time.Duration(cfg.MediaGC.IntervalMinutes)*time.Minute,
+			logger,
+		)
+		mediaGCWorker.Start(ctx)
+	}
+
+	// Likewise the scheduled link check; POST /api/links/check runs one on
+	// demand.
+	if cfg.LinkCheck.Enabled {
+		linkCheckWorker := workers.NewLinkCheckWorker(
+			linkCheckService,
+			time.Duration(cfg.LinkCheck.IntervalMinutes)*time.Minute,
+			logger,
+		)
+		linkCheckWorker.Start(ctx)
+	}
 
 	// Initialize handlers
 	postHandler := handlers.NewPostHandler(postService, logger)
@@ -120,8 +192,10 @@ func main() {
 	commentHandler := handlers.NewCommentHandler(commentService, logger)
 	categoryHandler := handlers.NewCategoryHandler(categoryService, logger)
 	tagHandler := handlers.NewTagHandler(tagService, logger)
-	whitenestHandler := handlers.NewWhitenestHandler(whitenestService, logger)
-	characterHandler := handlers.NewCharacterHandler(characterService, logger)
+	whitenestHandler := handlers.NewWhitenestHandler(whitenestService, progressService, exportService, logger)
+	characterHandler := handlers.NewCharacterHandler(characterService, progressService, logger)
+	mediaHandler := handlers.NewMediaHandler(mediaService, mediaGCService, logger)
+	linkHandler := handlers.NewLinkHandler(linkCheckService, logger)
 
 	// Setup router
 	r := router.SetupRouter(
@@ -133,10 +207,22 @@ func main() {
 		tagHandler,
 		whitenestHandler,
 		characterHandler,
+		mediaHandler,
+		linkHandler,
 		logger,
 		cfg.Server.CORSOrigins,
+		cfg.Reader,
+		cfg.Schedule.PreviewToken,
 	)
 
+	// The local backend has no server of its own; serve its public files
+	// from here.
+	if local, ok := objectStorage.(*storage.LocalStorage); ok {
+		for _, prefix := range storage.PublicPrefixes {
+			r.Static(path.Join(local.URLPath(), prefix), filepath.Join(local.Root(), prefix))
+		}
+	}
+
 	// Create HTTP server
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.Server.Port),
@@ -8,12 +8,80 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Database DatabaseConfig
-	MinIO    MinIOConfig
-	Server   ServerConfig
-	Upload   UploadConfig
-	Ollama   OllamaConfig
-	Gemini   GeminiConfig
+	Database    DatabaseConfig
+	MinIO       MinIOConfig
+	Storage     StorageConfig
+	Server      ServerConfig
+	Upload      UploadConfig
+	Ollama      OllamaConfig
+	Gemini      GeminiConfig
+	MediaGC     MediaGCConfig
+	LinkPreview LinkPreviewConfig
+	LinkCheck   LinkCheckConfig
+	Reader      ReaderConfig
+	Export      ExportConfig
+	Schedule    ScheduleConfig
+}
+
+// ScheduleConfig holds settings for the scheduler that releases queued
+// Whitenest chapters, checking for due ones every IntervalSeconds. Editors
+// preview queued chapters by sending PreviewToken; previews are disabled
+// while it is empty.
+type ScheduleConfig struct {
+	IntervalSeconds int
+	PreviewToken    string
+}
+
+// ExportConfig holds the book metadata written into Whitenest EPUB exports.
+// Language is a BCP 47 tag.
+type ExportConfig struct {
+	Title    string
+	Author   string
+	Language string
+}
+
+// ReaderConfig holds settings for the cookie that identifies anonymous
+// Whitenest readers. Cross-origin frontends need CookieSecure, which also
+// switches the cookie to SameSite=None.
+type ReaderConfig struct {
+	CookieName       string
+	CookieSecure     bool
+	CookieMaxAgeDays int
+}
+
+// LinkCheckConfig holds settings for the outbound link checker, which
+// requests every link in post content once per IntervalMinutes, up to
+// Concurrency at a time.
+type LinkCheckConfig struct {
+	Enabled         bool
+	IntervalMinutes int
+	TimeoutSeconds  int
+	Concurrency     int
+}
+
+// LinkPreviewConfig holds settings for GET /api/fetch-url. Pages are read up
+// to MaxBodyKB (the metadata lives in <head>); results are cached in Postgres
+// for CacheTTLMinutes, and a TTL of 0 disables the cache. With RehostImages
+// set, link blocks' preview images are copied into storage when a post is
+// saved. oEmbed players are only embedded when their https iframe is served
+// from one of EmbedHosts.
+type LinkPreviewConfig struct {
+	FetchTimeoutSeconds int
+	FetchMaxRedirects   int
+	MaxBodyKB           int
+	CacheTTLMinutes     int
+	RehostImages        bool
+	EmbedHosts          []string
+}
+
+// MediaGCConfig holds settings for the orphaned-media garbage collector.
+// Unreferenced uploads older than GracePeriodHours are moved to quarantine;
+// quarantined objects older than QuarantinePeriodHours are deleted.
+type MediaGCConfig struct {
+	Enabled               bool
+	IntervalMinutes       int
+	GracePeriodHours      int
+	QuarantinePeriodHours int
 }
 
 // OllamaConfig holds settings for the local Ollama LLM service
@@ -50,6 +118,15 @@ type MinIOConfig struct {
 	PublicURL string
 }
 
+// StorageConfig selects the object storage backend. LocalDir and PublicURL
+// only apply to the "local" and "memory" backends; MinIO builds its URLs from
+// MinIOConfig.PublicURL and the bucket.
+type StorageConfig struct {
+	Backend   string
+	LocalDir  string
+	PublicURL string
+}
+
 // ServerConfig holds HTTP server settings
 type ServerConfig struct {
 	Port        string
@@ -62,6 +139,19 @@ type UploadConfig struct {
 	MaxVideoFileSizeMB int
 	MaxImageDimension  int
 	AllowedMimeTypes   []string
+	// Limits for POST /api/upload/by-url, which fetches a remote file.
+	FetchTimeoutSeconds int
+	FetchMaxRedirects   int
+}
+
+// MaxUploadBytes is the largest file any upload may be: the video limit or
+// the image limit, whichever is bigger.
+func (c *UploadConfig) MaxUploadBytes() int64 {
+	maxMB := c.MaxFileSizeMB
+	if c.MaxVideoFileSizeMB > maxMB {
+		maxMB = c.MaxVideoFileSizeMB
+	}
+	return int64(maxMB) << 20
 }
 
 // Load reads configuration from environment variables
@@ -93,6 +183,94 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid GEMINI_TIMEOUT_SECONDS: %w", err)
 	}
 
+	fetchTimeout, err := strconv.Atoi(getEnv("UPLOAD_FETCH_TIMEOUT_SECONDS", "30"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid UPLOAD_FETCH_TIMEOUT_SECONDS: %w", err)
+	}
+
+	fetchRedirects, err := strconv.Atoi(getEnv("UPLOAD_FETCH_MAX_REDIRECTS", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid UPLOAD_FETCH_MAX_REDIRECTS: %w", err)
+	}
+
+	gcInterval, err := strconv.Atoi(getEnv("MEDIA_GC_INTERVAL_MINUTES", "60"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MEDIA_GC_INTERVAL_MINUTES: %w", err)
+	}
+	if gcInterval < 1 {
+		return nil, fmt.Errorf("invalid MEDIA_GC_INTERVAL_MINUTES: must be at least 1")
+	}
+
+	gcGrace, err := strconv.Atoi(getEnv("MEDIA_GC_GRACE_HOURS", "72"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MEDIA_GC_GRACE_HOURS: %w", err)
+	}
+
+	gcQuarantine, err := strconv.Atoi(getEnv("MEDIA_GC_QUARANTINE_HOURS", "168"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MEDIA_GC_QUARANTINE_HOURS: %w", err)
+	}
+
+	previewTimeout, err := strconv.Atoi(getEnv("LINK_PREVIEW_TIMEOUT_SECONDS", "10"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LINK_PREVIEW_TIMEOUT_SECONDS: %w", err)
+	}
+
+	previewRedirects, err := strconv.Atoi(getEnv("LINK_PREVIEW_MAX_REDIRECTS", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LINK_PREVIEW_MAX_REDIRECTS: %w", err)
+	}
+
+	previewMaxBody, err := strconv.Atoi(getEnv("LINK_PREVIEW_MAX_BODY_KB", "1024"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LINK_PREVIEW_MAX_BODY_KB: %w", err)
+	}
+	if previewMaxBody < 1 {
+		return nil, fmt.Errorf("invalid LINK_PREVIEW_MAX_BODY_KB: must be at least 1")
+	}
+
+	previewTTL, err := strconv.Atoi(getEnv("LINK_PREVIEW_CACHE_TTL_MINUTES", "1440"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LINK_PREVIEW_CACHE_TTL_MINUTES: %w", err)
+	}
+
+	linkCheckInterval, err := strconv.Atoi(getEnv("LINK_CHECK_INTERVAL_MINUTES", "1440"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LINK_CHECK_INTERVAL_MINUTES: %w", err)
+	}
+	if linkCheckInterval < 1 {
+		return nil, fmt.Errorf("invalid LINK_CHECK_INTERVAL_MINUTES: must be at least 1")
+	}
+
+	linkCheckTimeout, err := strconv.Atoi(getEnv("LINK_CHECK_TIMEOUT_SECONDS", "10"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LINK_CHECK_TIMEOUT_SECONDS: %w", err)
+	}
+
+	linkCheckConcurrency, err := strconv.Atoi(getEnv("LINK_CHECK_CONCURRENCY", "4"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LINK_CHECK_CONCURRENCY: %w", err)
+	}
+	if linkCheckConcurrency < 1 {
+		return nil, fmt.Errorf("invalid LINK_CHECK_CONCURRENCY: must be at least 1")
+	}
+
+	readerCookieMaxAge, err := strconv.Atoi(getEnv("READER_COOKIE_MAX_AGE_DAYS", "365"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid READER_COOKIE_MAX_AGE_DAYS: %w", err)
+	}
+	if readerCookieMaxAge < 1 {
+		return nil, fmt.Errorf("invalid READER_COOKIE_MAX_AGE_DAYS: must be at least 1")
+	}
+
+	releaseInterval, err := strconv.Atoi(getEnv("WHITENEST_RELEASE_INTERVAL_SECONDS", "60"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid WHITENEST_RELEASE_INTERVAL_SECONDS: %w", err)
+	}
+	if releaseInterval < 1 {
+		return nil, fmt.Errorf("invalid WHITENEST_RELEASE_INTERVAL_SECONDS: must be at least 1")
+	}
+
 	return &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -110,6 +288,11 @@ func Load() (*Config, error) {
 			UseSSL:    useSSL,
 			PublicURL: getEnv("MINIO_PUBLIC_URL", "http://localhost:9000"),
 		},
+		Storage: StorageConfig{
+			Backend:   getEnv("STORAGE_BACKEND", "minio"),
+			LocalDir:  getEnv("STORAGE_LOCAL_DIR", "./data/media"),
+			PublicURL: getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080/media-files"),
+		},
 		Server: ServerConfig{
 			Port:        getEnv("SERVER_PORT", "8080"),
 			CORSOrigins: parseCommaSeparated(getEnv("CORS_ORIGINS", "http://localhost:3000")),
@@ -122,6 +305,8 @@ func Load() (*Config, error) {
 				"image/jpeg", "image/png", "image/gif", "image/webp",
 				"video/mp4", "video/webm", "video/ogg", "video/quicktime",
 			},
+			FetchTimeoutSeconds: fetchTimeout,
+			FetchMaxRedirects:   fetchRedirects,
 		},
 		Ollama: OllamaConfig{
 			BaseURL:        getEnv("OLLAMA_BASE_URL", "http://localhost:11434"),
@@ -133,6 +318,41 @@ func Load() (*Config, error) {
 			Model:          getEnv("GEMINI_MODEL", "gemini-2.5-flash"),
 			TimeoutSeconds: geminiTimeout,
 		},
+		MediaGC: MediaGCConfig{
+			Enabled:               getEnv("MEDIA_GC_ENABLED", "false") == "true",
+			IntervalMinutes:       gcInterval,
+			GracePeriodHours:      gcGrace,
+			QuarantinePeriodHours: gcQuarantine,
+		},
+		LinkPreview: LinkPreviewConfig{
+			FetchTimeoutSeconds: previewTimeout,
+			FetchMaxRedirects:   previewRedirects,
+			MaxBodyKB:           previewMaxBody,
+			CacheTTLMinutes:     previewTTL,
+			RehostImages:        getEnv("LINK_PREVIEW_REHOST_IMAGES", "false") == "true",
+			EmbedHosts: parseCommaSeparated(getEnv("LINK_PREVIEW_EMBED_HOSTS",
+				"www.youtube.com,www.youtube-nocookie.com,player.vimeo.com,open.spotify.com,w.soundcloud.com,codepen.io")),
+		},
+		LinkCheck: LinkCheckConfig{
+			Enabled:         getEnv("LINK_CHECK_ENABLED", "false") == "true",
+			IntervalMinutes: linkCheckInterval,
+			TimeoutSeconds:  linkCheckTimeout,
+			Concurrency:     linkCheckConcurrency,
+		},
+		Reader: ReaderConfig{
+			CookieName:       getEnv("READER_COOKIE_NAME", "whitenest_reader"),
+			CookieSecure:     getEnv("READER_COOKIE_SECURE", "false") == "true",
+			CookieMaxAgeDays: readerCookieMaxAge,
+		},
+		Export: ExportConfig{
+			Title:    getEnv("WHITENEST_EXPORT_TITLE", "Whitenest"),
+			Author:   getEnv("WHITENEST_EXPORT_AUTHOR", ""),
+			Language: getEnv("WHITENEST_EXPORT_LANGUAGE", "en"),
+		},
+		Schedule: ScheduleConfig{
+			IntervalSeconds: releaseInterval,
+			PreviewToken:    getEnv("WHITENEST_PREVIEW_TOKEN", ""),
+		},
 	}, nil
 }
 
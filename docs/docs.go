@@ -54,21 +54,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/characters": {
+        "/character-relationships": {
             "get": {
-                "description": "Returns all characters, alphabetized by short name. Optionally\nfilter by ` + "`" + `?search=` + "`" + ` (case-insensitive on full and short name).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "characters"
                 ],
-                "summary": "List characters",
+                "summary": "List character relationships",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Substring search on short_name/full_name",
-                        "name": "search",
+                        "description": "Only relationships involving this character",
+                        "name": "character_id",
                         "in": "query"
                     }
                 ],
@@ -86,7 +85,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dtos.CharacterResponse"
+                                                "$ref": "#/definitions/dtos.RelationshipResponse"
                                             }
                                         }
                                     }
@@ -94,6 +93,12 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -103,6 +108,7 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "description": "` + "`" + `type` + "`" + ` is one of family, sibling, parent, partner, friend,\nally, rival, enemy, mentor, employer, other. A directed\nrelationship reads \"source is \u003ctype\u003e of target\".",
                 "consumes": [
                     "application/json"
                 ],
@@ -112,15 +118,15 @@ const docTemplate = `{
                 "tags": [
                     "characters"
                 ],
-                "summary": "Create a character",
+                "summary": "Create a character relationship",
                 "parameters": [
                     {
-                        "description": "Character payload",
-                        "name": "character",
+                        "description": "Relationship payload",
+                        "name": "relationship",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CreateCharacterRequest"
+                            "$ref": "#/definitions/dtos.CreateRelationshipRequest"
                         }
                     }
                 ],
@@ -136,7 +142,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.CharacterResponse"
+                                            "$ref": "#/definitions/dtos.RelationshipResponse"
                                         }
                                     }
                                 }
@@ -149,6 +155,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -158,7 +170,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/characters/{id}": {
+        "/character-relationships/{id}": {
             "get": {
                 "produces": [
                     "application/json"
@@ -166,11 +178,11 @@ const docTemplate = `{
                 "tags": [
                     "characters"
                 ],
-                "summary": "Get a character by ID",
+                "summary": "Get a character relationship",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Character UUID",
+                        "description": "Relationship UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -188,7 +200,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.CharacterResponse"
+                                            "$ref": "#/definitions/dtos.RelationshipResponse"
                                         }
                                     }
                                 }
@@ -225,22 +237,22 @@ const docTemplate = `{
                 "tags": [
                     "characters"
                 ],
-                "summary": "Update a character",
+                "summary": "Update a character relationship",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Character UUID",
+                        "description": "Relationship UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "description": "Patch payload",
-                        "name": "character",
+                        "name": "relationship",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UpdateCharacterRequest"
+                            "$ref": "#/definitions/dtos.UpdateRelationshipRequest"
                         }
                     }
                 ],
@@ -256,7 +268,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.CharacterResponse"
+                                            "$ref": "#/definitions/dtos.RelationshipResponse"
                                         }
                                     }
                                 }
@@ -275,6 +287,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -287,11 +305,11 @@ const docTemplate = `{
                 "tags": [
                     "characters"
                 ],
-                "summary": "Delete a character",
+                "summary": "Delete a character relationship",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Character UUID",
+                        "description": "Relationship UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -322,38 +340,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/comments": {
+        "/characters": {
             "get": {
+                "description": "Returns all characters, alphabetized by short name. Optionally\nfilter by ` + "`" + `?search=` + "`" + ` (case-insensitive on full and short name).\nEach character carries its chapter appearance count and its\nfirst and last chapter. With ` + "`" + `as_of_chapter` + "`" + `, only characters\nwho have appeared by then are listed, without later spoilers.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "characters"
                 ],
-                "summary": "List comments",
+                "summary": "List characters",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by post ID",
-                        "name": "postId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by author",
-                        "name": "author",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort field",
-                        "name": "sortBy",
+                        "description": "Substring search on short_name/full_name",
+                        "name": "search",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "asc or desc",
-                        "name": "sortOrder",
+                        "description": "Only characters introduced by this chapter (or progress), without later spoilers",
+                        "name": "as_of_chapter",
                         "in": "query"
                     }
                 ],
@@ -361,7 +368,22 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.CommentListResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.CharacterResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -386,17 +408,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "characters"
                 ],
-                "summary": "Create a comment",
+                "summary": "Create a character",
                 "parameters": [
                     {
-                        "description": "Comment payload",
-                        "name": "comment",
+                        "description": "Character payload",
+                        "name": "character",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CreateCommentRequest"
+                            "$ref": "#/definitions/dtos.CreateCharacterRequest"
                         }
                     }
                 ],
@@ -404,7 +426,19 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CharacterResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -413,8 +447,54 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/characters/graph": {
+            "get": {
+                "description": "Returns characters as nodes and relationships as links, ready\nfor a force-directed layout (link source/target are node IDs).\nWith ` + "`" + `as_of_chapter` + "`" + `, only characters who have appeared by then\nand relationships established by then are included.\n` + "`" + `as_of_chapter=progress` + "`" + ` uses the reader's own progress.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "Character relationship graph",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gate the graph to a reader at this chapter, or progress for the reader's own",
+                        "name": "as_of_chapter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CharacterGraphResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -428,26 +508,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/comments/{id}": {
-            "delete": {
+        "/characters/{id}": {
+            "get": {
+                "description": "With ` + "`" + `as_of_chapter` + "`" + `, description sections revealed later are\nleft out, skills/occupation/location are as of that chapter,\nand a character not yet introduced returns 404.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "comments"
+                    "characters"
                 ],
-                "summary": "Delete a comment",
+                "summary": "Get a character by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Comment UUID",
+                        "description": "Character UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gate the response to a reader at this chapter, or progress for the reader's own",
+                        "name": "as_of_chapter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CharacterResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -469,112 +571,149 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/fetch-url": {
-            "get": {
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "url"
+                    "characters"
                 ],
-                "summary": "Fetch URL metadata",
+                "summary": "Update a character",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "URL to fetch metadata from",
-                        "name": "url",
-                        "in": "query",
+                        "description": "Character UUID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Patch payload",
+                        "name": "character",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateCharacterRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EditorJsURLResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CharacterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EditorJsURLResponse"
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/posts": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Refuses with 409 CHARACTER_IN_USE, listing the affected\nchapters and posts, while the character is cast anywhere.\nWith ` + "`" + `force=true` + "`" + ` the character is removed from those casts.\nRelationships of the character are always deleted.",
                 "tags": [
-                    "posts"
+                    "characters"
                 ],
-                "summary": "List posts",
+                "summary": "Delete a character",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Character UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by author",
-                        "name": "author",
+                        "type": "boolean",
+                        "description": "Delete even if cast in posts",
+                        "name": "force",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
-                    {
-                        "type": "integer",
-                        "description": "Filter by category ID",
-                        "name": "category_id",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
                     },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "Filter by tag names (OR semantics; repeat the param or comma-join)",
-                        "name": "tags",
-                        "in": "query"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
                     },
-                    {
-                        "enum": [
-                            "date",
-                            "title",
-                            "createdAt",
-                            "updatedAt"
-                        ],
-                        "type": "string",
-                        "description": "Sort field",
-                        "name": "sortBy",
-                        "in": "query"
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/characters/{id}/appearances": {
+            "get": {
+                "description": "Returns the Whitenest chapters the character is cast in,\nordered by chapter number, with the first and last appearance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "List a character's chapter appearances",
+                "parameters": [
                     {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
                         "type": "string",
-                        "description": "asc or desc",
-                        "name": "sortOrder",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Character UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Items per page (default 6, max 50)",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Gate the response to a reader at this chapter, or progress for the reader's own",
+                        "name": "as_of_chapter",
                         "in": "query"
                     }
                 ],
@@ -582,7 +721,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PostListResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CharacterAppearancesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
@@ -592,8 +755,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/characters/{id}/merge": {
             "post": {
+                "description": "Moves every cast entry and relationship of ` + "`" + `duplicate_id` + "`" + ` to\nthe path character, then deletes the duplicate. Cast entries\nkeep their position; in chapters where both are cast the two\nentries become one at the earlier position. Relationships\nbetween the two, or already held by this character, are\ndropped. The duplicate's description, sections and portrait\nare not kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -601,25 +767,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "characters"
                 ],
-                "summary": "Create a post",
+                "summary": "Merge a duplicate character into this one",
                 "parameters": [
                     {
-                        "description": "Post payload",
-                        "name": "post",
+                        "type": "string",
+                        "description": "Canonical character UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Duplicate to merge",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CreatePostRequest"
+                            "$ref": "#/definitions/dtos.MergeCharacterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CharacterMergeResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -628,6 +813,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -637,20 +828,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/count/by-category": {
+        "/characters/{id}/progression": {
             "get": {
+                "description": "Returns the character's base state and their skills,\noccupation and location at every chapter they appear in, with\nper-chapter overrides applied in order.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "characters"
+                ],
+                "summary": "Character state across chapters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Character UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gate the response to a reader at this chapter, or progress for the reader's own",
+                        "name": "as_of_chapter",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Count posts grouped by category",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryCountListResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CharacterProgressionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
@@ -662,42 +893,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/drafts": {
+        "/comments": {
             "get": {
-                "description": "Returns posts whose category is flagged is_internal (e.g. Drafts).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "comments"
                 ],
-                "summary": "List draft posts (admin)",
+                "summary": "List comments",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Full-text search",
-                        "name": "search",
+                        "description": "Filter by post ID",
+                        "name": "postId",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "date",
-                            "title",
-                            "createdAt",
-                            "updatedAt"
-                        ],
                         "type": "string",
-                        "description": "Sort field",
+                        "description": "Filter by author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field: createdAt (default) or author",
                         "name": "sortBy",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
                         "type": "string",
-                        "description": "asc or desc",
+                        "description": "asc or desc (default)",
                         "name": "sortOrder",
                         "in": "query"
                     },
@@ -709,16 +935,34 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Items per page (default 6, max 50)",
+                        "description": "Comments per page (default 20, max 100)",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "meta.nextCursor or meta.prevCursor of another page; overrides page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to skip the total count (default true)",
+                        "name": "count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PostListResponse"
+                            "$ref": "#/definitions/dtos.CommentListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
@@ -728,54 +972,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/posts/most-viewed": {
-            "get": {
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "comments"
                 ],
-                "summary": "List the most viewed posts",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.PostListResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/posts/{id}": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "posts"
-                ],
-                "summary": "Get a post by ID",
+                "summary": "Create a comment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Post UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Comment payload",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateCommentRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/dtos.SuccessResponse"
                         }
@@ -799,34 +1021,21 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/comments/{id}": {
+            "delete": {
                 "tags": [
-                    "posts"
+                    "comments"
                 ],
-                "summary": "Update a post",
+                "summary": "Delete a comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post UUID",
+                        "description": "Comment UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Fields to update",
-                        "name": "post",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.UpdatePostRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -855,35 +1064,58 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/fetch-url": {
+            "get": {
+                "description": "Fetches the page server-side (private/loopback destinations are refused) and extracts its title, description and image. Results are cached for LINK_PREVIEW_CACHE_TTL_MINUTES.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "posts"
+                    "url"
                 ],
-                "summary": "Delete a post",
+                "summary": "Fetch URL metadata",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post UUID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "URL to fetch metadata from",
+                        "name": "url",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.EditorJsURLResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.EditorJsURLResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/links/broken": {
+            "get": {
+                "description": "Reports, per post, the links that failed the most recent\nlink check: request errors and 4xx/5xx statuses other than 429.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "links"
+                ],
+                "summary": "List posts with broken outbound links",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BrokenLinksReport"
                         }
                     },
                     "500": {
@@ -895,33 +1127,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/{id}/similar": {
-            "get": {
+        "/links/check": {
+            "post": {
+                "description": "Checks every outbound link in post content and replaces the\nstored results. Returns when the sweep finishes.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
-                ],
-                "summary": "List posts similar to the given post",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Post UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "links"
                 ],
+                "summary": "Run the link checker now",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PostListResponse"
+                            "$ref": "#/definitions/dtos.LinkCheckSummary"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -935,28 +1159,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/tags": {
+        "/media": {
             "get": {
+                "description": "Returns the media library newest first, with the same\npagination envelope as the post listing.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "media"
                 ],
-                "summary": "List tags",
+                "summary": "List uploaded media",
                 "parameters": [
                     {
+                        "enum": [
+                            "image",
+                            "video"
+                        ],
                         "type": "string",
-                        "description": "Case-insensitive name search",
+                        "description": "MIME top-level type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exact MIME type, e.g. image/png",
+                        "name": "mime_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by uploader",
+                        "name": "uploader",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Substring search on the object key",
                         "name": "search",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "asc or desc by upload time",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 24, max 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.TagListResponse"
+                            "$ref": "#/definitions/dtos.MediaListResponse"
                         }
                     },
                     "500": {
@@ -968,73 +1237,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/upload": {
-            "post": {
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/media/gc": {
+            "get": {
+                "description": "Runs a dry-run sweep and reports, per unreferenced object,\nwhether the collector would leave it pending, quarantine it,\nrestore it from quarantine or delete it. Nothing is changed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "upload"
-                ],
-                "summary": "Upload a file (image or video)",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Image or video file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "media"
                 ],
+                "summary": "Preview the orphaned-media garbage collector",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EditorJsUploadResponse"
+                            "$ref": "#/definitions/dtos.MediaGCReport"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EditorJsUploadResponse"
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/whitenest/chapters": {
+        "/media/stats": {
             "get": {
-                "description": "Returns every Whitenest chapter ordered by chapter number ASC\nwith the lightweight fields needed for list views (id, title,\nimage, tags, chapter number).",
+                "description": "Object count and stored bytes, plus how many uploads were\ndeduplicated against an existing object and the bytes that saved.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "whitenest"
+                    "media"
                 ],
-                "summary": "List all Whitenest chapters",
+                "summary": "Media storage statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dtos.WhitenestChapterSummary"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.MediaStatsResponse"
                         }
                     },
                     "500": {
@@ -1046,28 +1289,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/whitenest/chapters/order": {
-            "put": {
-                "description": "Accepts the full ordered list of (post_id, number) pairs and\nrewrites chapter numbers atomically. The submitted set must\ncover every existing chapter exactly once with contiguous\nnumbers 1..N. A mismatch (e.g. concurrent publish/unpublish)\nreturns 409 so the client can refresh and retry.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/media/{id}": {
+            "delete": {
+                "description": "Removes the object from storage and the media library. Refused\nwith 409 while any post cover image, Editor.js image or video\nblock, character portrait or arc cover references it; the\nresponse details list the referencing post, character and\narc IDs.",
                 "tags": [
-                    "whitenest"
+                    "media"
                 ],
-                "summary": "Reorder Whitenest chapters",
+                "summary": "Delete a media object",
                 "parameters": [
                     {
-                        "description": "Full chapter order",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ReorderChaptersRequest"
-                        }
+                        "type": "string",
+                        "description": "Media UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1080,6 +1315,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -1095,42 +1336,138 @@ const docTemplate = `{
                 }
             }
         },
-        "/whitenest/chapters/{number}": {
+        "/posts": {
             "get": {
-                "description": "Returns the chapter with the given serial number along with\nminimal references to the previous and next chapters, if any.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "whitenest"
+                    "posts"
                 ],
-                "summary": "Get a Whitenest chapter by number",
+                "summary": "List posts",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Chapter number (1-indexed)",
-                        "name": "number",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dtos.WhitenestChapterResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by category IDs (any of them; repeat the param or comma-join)",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by tag names (repeat the param or comma-join)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "any (default) or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posts dated on or after (YYYY-MM-DD or RFC 3339)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posts dated up to (YYYY-MM-DD inclusive, or RFC 3339 exclusive)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "category",
+                                "tag",
+                                "author",
+                                "year"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Facets to compute over the filtered set",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "date",
+                            "title",
+                            "createdAt",
+                            "updatedAt"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "asc or desc",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 6, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "meta.nextCursor or meta.prevCursor of another page; overrides page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to skip the total count (default true)",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PostListResponse"
                         }
                     },
                     "400": {
@@ -1139,11 +1476,79 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Create a post",
+                "parameters": [
+                    {
+                        "description": "Post payload",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreatePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/posts/count/by-category": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Count posts grouped by category",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CategoryCountListResponse"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -1153,441 +1558,2860 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dtos.CategoryCountListResponse": {
+        },
+        "/posts/drafts": {
+            "get": {
+                "description": "Returns posts whose category is flagged is_internal (e.g. Drafts).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "List draft posts (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full-text search",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by tag names (repeat the param or comma-join)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "any (default) or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posts dated on or after (YYYY-MM-DD or RFC 3339)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posts dated up to (YYYY-MM-DD inclusive, or RFC 3339 exclusive)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "category",
+                                "tag",
+                                "author",
+                                "year"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Facets to compute over the filtered set",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "date",
+                            "title",
+                            "createdAt",
+                            "updatedAt"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "asc or desc",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 6, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "meta.nextCursor or meta.prevCursor of another page; overrides page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to skip the total count (default true)",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PostListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/most-viewed": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "List the most viewed posts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PostListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}": {
+            "get": {
+                "description": "Queued Whitenest chapters are 404 except in a preview\n(` + "`" + `preview=true` + "`" + ` with the editor's X-Preview-Token), which\ndoesn't count as a view.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Get a post by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include queued chapters",
+                        "name": "preview",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Editor token, required with preview=true",
+                        "name": "X-Preview-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Update a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdatePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Delete a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/similar": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "List posts similar to the given post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PostListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive name search",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TagListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/upload": {
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upload"
+                ],
+                "summary": "Upload a file (image or video)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image or video file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name recorded as the uploader in the media library; must come before file",
+                        "name": "uploader",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative to the uploader form field",
+                        "name": "uploader",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EditorJsUploadResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EditorJsUploadResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/upload/by-url": {
+            "post": {
+                "description": "Fetches the URL server-side and stores the file as if it had\nbeen uploaded, so posts don't hotlink third-party hosts.\nPrivate, loopback and link-local destinations are refused,\nincluding via redirects.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upload"
+                ],
+                "summary": "Upload a file from a remote URL",
+                "parameters": [
+                    {
+                        "description": "Remote file URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EditorJsUploadByURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EditorJsUploadResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EditorJsUploadResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/upload/complete": {
+            "post": {
+                "description": "Verifies the uploaded object's size and sniffed type, moves it\nto its content-addressed key and records it in the media\nlibrary. Rejected objects are deleted. Answers like /upload.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upload"
+                ],
+                "summary": "Complete a direct-to-bucket upload",
+                "parameters": [
+                    {
+                        "description": "Key returned by /upload/presign",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CompleteUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EditorJsUploadResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EditorJsUploadResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/upload/presign": {
+            "post": {
+                "description": "Validates the declared video type and size and returns a signed\nmultipart form. POST the fields plus the file (field \"file\",\nlast) to the returned URL, then call /upload/complete with the\nkey. Images must use /upload so their metadata is stripped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upload"
+                ],
+                "summary": "Presign a direct-to-bucket video upload",
+                "parameters": [
+                    {
+                        "description": "Declared file",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PresignUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PresignUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/arcs": {
+            "get": {
+                "description": "Returns every arc in reading order with the chapter range it\ncurrently covers. Queued chapters only count with\n` + "`" + `preview=true` + "`" + ` and the editor's X-Preview-Token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "List Whitenest arcs",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include queued chapters",
+                        "name": "preview",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Editor token, required with preview=true",
+                        "name": "X-Preview-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.WhitenestArcResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The arc starts at start_chapter and runs until the next arc.\nstart_chapter may be one past the latest chapter to set up an\narc before its first chapter is published.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Create a Whitenest arc",
+                "parameters": [
+                    {
+                        "description": "Arc payload",
+                        "name": "arc",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateWhitenestArcRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.WhitenestArcResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/arcs/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Update a Whitenest arc",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Arc UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch payload",
+                        "name": "arc",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateWhitenestArcRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.WhitenestArcResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Chapters are kept; they join the preceding arc, or become\nungrouped if the deleted arc was the first.",
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Delete a Whitenest arc",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Arc UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/cast-matrix": {
+            "get": {
+                "description": "Returns every chapter as a column and, for each character cast\nin at least one chapter, the chapter numbers they appear in.\nRows are ordered by first appearance, then short name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "Characters × chapters presence matrix",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CastMatrixResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/chapters": {
+            "get": {
+                "description": "Returns every Whitenest chapter ordered by chapter number ASC\nwith the lightweight fields needed for list views (id, title,\nimage, tags, chapter number), grouped by arc. Chapters before\nthe first arc come first in a group whose arc is null.\nThe ETag header carries the chapter set's version, required by\nthe move endpoint and by whitenest_insert_at on posts.\nWhen the request identifies a reader, each chapter carries\na ` + "`" + `read` + "`" + ` flag. Queued chapters are left out unless\n` + "`" + `preview=true` + "`" + ` (with the editor's X-Preview-Token), where\nthey carry their ` + "`" + `release_at` + "`" + `; the version covers them\neither way.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "List all Whitenest chapters grouped by arc",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include queued chapters",
+                        "name": "preview",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Editor token, required with preview=true",
+                        "name": "X-Preview-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reader UUID, overrides the reader cookie",
+                        "name": "X-Reader-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.WhitenestArcGroup"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Chapter set version"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/chapters/order": {
+            "put": {
+                "description": "Accepts the full ordered list of (post_id, number) pairs and\nrewrites chapter numbers atomically. The submitted set must\ncover every existing chapter exactly once with contiguous\nnumbers 1..N, queued chapters included. A mismatch (e.g.\nconcurrent publish/unpublish) returns 409 so the client can\nrefresh and retry. Released chapters must all come before the\nqueued ones. Arcs keep their start chapter unless moved in the\noptional ` + "`" + `arcs` + "`" + ` list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Reorder Whitenest chapters",
+                "parameters": [
+                    {
+                        "description": "Full chapter order",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReorderChaptersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/chapters/{number}": {
+            "get": {
+                "description": "Returns the chapter with the given serial number along with\nminimal references to the previous and next chapters, if any.\nEach cast member carries their state as of this chapter.\nOpening a chapter records it in the reader's progress; a\nreader cookie is issued when the request carries neither the\ncookie nor an X-Reader-ID header.\nQueued chapters are 404 except with ` + "`" + `preview=true` + "`" + `, which\neditors use to proofread them and which needs their\nX-Preview-Token; a preview links queued neighbours and\nrecords neither a view nor progress.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Get a Whitenest chapter by number",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Chapter number (1-indexed)",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include queued chapters",
+                        "name": "preview",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Editor token, required with preview=true",
+                        "name": "X-Preview-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reader UUID, overrides the reader cookie",
+                        "name": "X-Reader-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.WhitenestChapterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/chapters/{number}/cast/{character_id}": {
+            "put": {
+                "description": "Replaces the character's per-chapter override of skills,\noccupation and location. Omitted fields carry over from\nearlier chapters; an empty body clears the override. Returns\nthe character's resulting state as of that chapter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Set a cast member's state in a chapter",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Chapter number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Character UUID",
+                        "name": "character_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "State override",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CharacterStateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CastMemberResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/chapters/{number}/move": {
+            "post": {
+                "description": "Moves the chapter to number ` + "`" + `to` + "`" + `, shifting the chapters in\nbetween by one. ` + "`" + `version` + "`" + ` is the ETag of the chapter list; if\nthe chapter set changed since, the move is rejected with 409.\nArc boundaries follow the other chapters, so the moved chapter\njoins the arc covering its new number. A released chapter\ncan't move after a queued one, nor a queued one before it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Move a Whitenest chapter",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Current chapter number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target position and chapter set version",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MoveChapterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.MoveChapterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/export.epub": {
+            "get": {
+                "description": "Exports the selected chapters as an EPUB 3 book with a table\nof contents, the first chapter's image as cover, embedded\nimages and a cast appendix. Select an arc with ` + "`" + `arc_id` + "`" + ` or a\nrange with ` + "`" + `from` + "`" + `/` + "`" + `to` + "`" + ` (either end optional); with neither,\nthe whole serial is exported. Books are built in the\nbackground: when a file for the current content exists this\nredirects to it, otherwise it returns 202 with the export job,\nwhose status URL is in the Location header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Export Whitenest as EPUB",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Arc UUID",
+                        "name": "arc_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First chapter number",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last chapter number",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.WhitenestExportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "Export status URL"
+                            }
+                        }
+                    },
+                    "302": {
+                        "description": "Redirect to the EPUB file"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/exports/{id}": {
+            "get": {
+                "description": "Returns the export's status: pending, running, ready (with\ndownload_url) or failed (with error).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Get a Whitenest export job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.WhitenestExportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/progress": {
+            "get": {
+                "description": "Returns the chapters the reader has opened, which of them are\nread, and where to continue: the chapter last touched, or the\nnext one once that is read. A reader without progress (or\nwithout a reader cookie) continues from chapter 1.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Get the reader's Whitenest progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reader UUID, overrides the reader cookie",
+                        "name": "X-Reader-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.ReaderProgressResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/progress/{number}": {
+            "put": {
+                "description": "Records the Editor.js block the reader is at and/or whether\nthe chapter is read. Omitted fields keep their stored value.\nIssues a reader cookie when the request has no reader.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "Save the reader's position in a chapter",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Chapter number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reader UUID, overrides the reader cookie",
+                        "name": "X-Reader-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Progress",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SaveProgressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.ChapterProgressResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/whitenest/schedule": {
+            "get": {
+                "description": "Returns the queued chapters in release order with their\nnumber, title, release time and arc. A chapter whose time has\npassed stays listed until the scheduler releases it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitenest"
+                ],
+                "summary": "List upcoming Whitenest chapters",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.ScheduledChapterResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dtos.ArcBoundaryItem": {
+            "type": "object",
+            "required": [
+                "arc_id",
+                "start_chapter"
+            ],
+            "properties": {
+                "arc_id": {
+                    "type": "string"
+                },
+                "start_chapter": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "dtos.BrokenLinksPost": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.LinkCheckResult"
+                    }
+                },
+                "post_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.BrokenLinksReport": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.BrokenLinksPost"
+                    }
+                },
+                "total_links": {
+                    "type": "integer"
+                },
+                "total_posts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CastMatrixResponse": {
+            "type": "object",
+            "properties": {
+                "chapters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.WhitenestChapterRef"
+                    }
+                },
+                "characters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CastMatrixRow"
+                    }
+                }
+            }
+        },
+        "dtos.CastMatrixRow": {
+            "type": "object",
+            "properties": {
+                "appearances": {
+                    "$ref": "#/definitions/dtos.CharacterAppearanceSummary"
+                },
+                "chapters": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "portrait": {
+                    "type": "string"
+                },
+                "short_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CastMemberResponse": {
+            "type": "object",
+            "properties": {
+                "appearances": {
+                    "description": "Appearances is only set on list responses.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.CharacterAppearanceSummary"
+                        }
+                    ]
+                },
+                "as_of_chapter": {
+                    "description": "AsOfChapter is set when the response was gated to a reader's position:\nsections, skills, occupation and location are as of that chapter.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "occupation": {
+                    "type": "string"
+                },
+                "portrait": {
+                    "type": "string"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CharacterSection"
+                    }
+                },
+                "short_name": {
+                    "type": "string"
+                },
+                "skills": {
+                    "$ref": "#/definitions/models.CharacterSkills"
+                },
+                "state": {
+                    "$ref": "#/definitions/dtos.CharacterStateResponse"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CategoryCountListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CategoryCountResponse"
+                    }
+                }
+            }
+        },
+        "dtos.CategoryCountResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "total_posts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CategoryListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CategoryResponse"
+                    }
+                }
+            }
+        },
+        "dtos.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_internal": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ChapterOrderItem": {
+            "type": "object",
+            "required": [
+                "number",
+                "post_id"
+            ],
+            "properties": {
+                "number": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "post_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ChapterProgressResponse": {
+            "type": "object",
+            "properties": {
+                "block_id": {
+                    "type": "string"
+                },
+                "chapter": {
+                    "$ref": "#/definitions/dtos.WhitenestChapterRef"
+                },
+                "read": {
+                    "type": "boolean"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CharacterAppearanceItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "whitenest_chapter_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CharacterAppearanceSummary": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "first_chapter": {
+                    "type": "integer"
+                },
+                "last_chapter": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CharacterAppearancesResponse": {
+            "type": "object",
+            "properties": {
+                "chapters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CharacterAppearanceItem"
+                    }
+                },
+                "character_id": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "first_chapter": {
+                    "$ref": "#/definitions/dtos.WhitenestChapterRef"
+                },
+                "last_chapter": {
+                    "$ref": "#/definitions/dtos.WhitenestChapterRef"
+                }
+            }
+        },
+        "dtos.CharacterGraphLink": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "directed": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "since_chapter": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CharacterGraphNode": {
+            "type": "object",
+            "properties": {
+                "appearances": {
+                    "type": "integer"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "portrait": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CharacterGraphResponse": {
+            "type": "object",
+            "properties": {
+                "as_of_chapter": {
+                    "type": "integer"
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CharacterGraphLink"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CharacterGraphNode"
+                    }
+                }
+            }
+        },
+        "dtos.CharacterMergeResponse": {
+            "type": "object",
+            "properties": {
+                "cast_merged": {
+                    "type": "integer"
+                },
+                "cast_moved": {
+                    "type": "integer"
+                },
+                "character": {
+                    "$ref": "#/definitions/dtos.CharacterResponse"
+                },
+                "relationships_dropped": {
+                    "type": "integer"
+                },
+                "relationships_moved": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CharacterProgressionItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/dtos.CharacterStateResponse"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "whitenest_chapter_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CharacterProgressionResponse": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "$ref": "#/definitions/dtos.CharacterStateResponse"
+                },
+                "character_id": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CharacterProgressionItem"
+                    }
+                }
+            }
+        },
+        "dtos.CharacterResponse": {
+            "type": "object",
+            "properties": {
+                "appearances": {
+                    "description": "Appearances is only set on list responses.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.CharacterAppearanceSummary"
+                        }
+                    ]
+                },
+                "as_of_chapter": {
+                    "description": "AsOfChapter is set when the response was gated to a reader's position:\nsections, skills, occupation and location are as of that chapter.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "occupation": {
+                    "type": "string"
+                },
+                "portrait": {
+                    "type": "string"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CharacterSection"
+                    }
+                },
+                "short_name": {
+                    "type": "string"
+                },
+                "skills": {
+                    "$ref": "#/definitions/models.CharacterSkills"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CharacterSectionRequest": {
+            "type": "object",
+            "required": [
+                "body",
+                "revealed_at"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "revealed_at": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 120
+                }
+            }
+        },
+        "dtos.CharacterSkillsRequest": {
+            "type": "object",
+            "properties": {
+                "endurance": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "guns": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "intellect": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "melee": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "persuasion": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "stealth": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                }
+            }
+        },
+        "dtos.CharacterStateRequest": {
+            "type": "object",
+            "properties": {
+                "location": {
+                    "type": "string",
+                    "maxLength": 160,
+                    "minLength": 1
+                },
+                "occupation": {
+                    "type": "string",
+                    "maxLength": 120,
+                    "minLength": 1
+                },
+                "skills": {
+                    "$ref": "#/definitions/dtos.CharacterSkillsRequest"
+                }
+            }
+        },
+        "dtos.CharacterStateResponse": {
+            "type": "object",
+            "properties": {
+                "location": {
+                    "type": "string"
+                },
+                "location_since": {
+                    "type": "integer"
+                },
+                "occupation": {
+                    "type": "string"
+                },
+                "occupation_since": {
+                    "type": "integer"
+                },
+                "skills": {
+                    "$ref": "#/definitions/models.CharacterSkills"
+                },
+                "skills_since": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CommentListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CommentResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "dtos.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "postId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CompleteUploadRequest": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "uploader": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ContinueReading": {
+            "type": "object",
+            "properties": {
+                "block_id": {
+                    "type": "string"
+                },
+                "chapter": {
+                    "$ref": "#/definitions/dtos.WhitenestChapterRef"
+                }
+            }
+        },
+        "dtos.CreateCharacterRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "full_name",
+                "location",
+                "occupation",
+                "portrait",
+                "short_name",
+                "skills"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 120,
+                    "minLength": 1
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 160,
+                    "minLength": 1
+                },
+                "occupation": {
+                    "type": "string",
+                    "maxLength": 120,
+                    "minLength": 1
+                },
+                "portrait": {
+                    "type": "string"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CharacterSectionRequest"
+                    }
+                },
+                "short_name": {
+                    "type": "string",
+                    "maxLength": 60,
+                    "minLength": 1
+                },
+                "skills": {
+                    "$ref": "#/definitions/dtos.CharacterSkillsRequest"
+                }
+            }
+        },
+        "dtos.CreateCommentRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "postId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreatePostRequest": {
+            "type": "object",
+            "required": [
+                "author",
+                "category_id",
+                "description",
+                "title"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "category_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "character_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "content": {
+                    "$ref": "#/definitions/models.EditorJsContent"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "image": {
+                    "type": "string"
+                },
+                "release_at": {
+                    "description": "ReleaseAt queues a Whitenest chapter for release at that time. It is\nnumbered into the upcoming slots in release order and hidden from\nreaders until the scheduler releases it.",
+                    "type": "string"
+                },
+                "subtitle": {
+                    "type": "string",
+                    "maxLength": 300
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                },
+                "whitenest_chapter_number": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "whitenest_insert_at": {
+                    "description": "WhitenestInsertAt places the new chapter at that number instead of\nappending it, shifting later chapters up. Requires WhitenestVersion,\nthe ETag of GET /api/whitenest/chapters.",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "whitenest_version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateRelationshipRequest": {
             "type": "object",
+            "required": [
+                "source_id",
+                "target_id",
+                "type"
+            ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.CategoryCountResponse"
-                    }
+                "description": {
+                    "type": "string"
+                },
+                "directed": {
+                    "type": "boolean"
+                },
+                "since_chapter": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "source_id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.CategoryCountResponse": {
+        "dtos.CreateWhitenestArcRequest": {
             "type": "object",
+            "required": [
+                "start_chapter",
+                "title"
+            ],
             "properties": {
-                "id": {
-                    "type": "integer"
+                "image": {
+                    "type": "string"
                 },
-                "name": {
+                "start_chapter": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "synopsis": {
                     "type": "string"
                 },
-                "total_posts": {
-                    "type": "integer"
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
                 }
             }
         },
-        "dtos.CategoryListResponse": {
+        "dtos.EditorJsErrorDetail": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.CategoryResponse"
-                    }
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.CategoryResponse": {
+        "dtos.EditorJsFileInfo": {
             "type": "object",
             "properties": {
-                "id": {
+                "audio_codec": {
+                    "type": "string"
+                },
+                "bytes_saved": {
                     "type": "integer"
                 },
-                "is_internal": {
+                "captured_at": {
+                    "type": "string"
+                },
+                "deduplicated": {
                     "type": "boolean"
                 },
-                "name": {
+                "duration_seconds": {
+                    "description": "Video only: container duration and codec identifiers. Width/Height\ncarry the video resolution.",
+                    "type": "number"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "media_id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "video_codec": {
                     "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "dtos.ChapterOrderItem": {
+        "dtos.EditorJsURLResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/dtos.EditorJsErrorDetail"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/dtos.URLMetadata"
+                },
+                "success": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.EditorJsUploadByURLRequest": {
             "type": "object",
             "required": [
-                "number",
-                "post_id"
+                "url"
             ],
             "properties": {
-                "number": {
-                    "type": "integer",
-                    "minimum": 1
+                "uploader": {
+                    "type": "string"
                 },
-                "post_id": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "dtos.CharacterResponse": {
+        "dtos.EditorJsUploadResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "error": {
+                    "$ref": "#/definitions/dtos.EditorJsErrorDetail"
+                },
+                "file": {
+                    "$ref": "#/definitions/dtos.EditorJsFileInfo"
+                },
+                "success": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string"
                 },
-                "description": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "dtos.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/dtos.ErrorDetail"
+                }
+            }
+        },
+        "dtos.FacetBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "full_name": {
+                "label": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.LinkCheckResult": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "final_url": {
+                    "type": "string"
+                },
+                "redirects": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.LinkCheckSummary": {
+            "type": "object",
+            "properties": {
+                "broken": {
+                    "type": "integer"
+                },
+                "checked_urls": {
+                    "type": "integer"
+                },
+                "links": {
+                    "type": "integer"
+                },
+                "posts": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.MediaGCAction": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_modified": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.MediaGCReport": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.MediaGCAction"
+                    }
+                },
+                "deleted_bytes": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "quarantined_bytes": {
+                    "type": "integer"
+                },
+                "referenced_urls": {
+                    "type": "integer"
+                },
+                "scanned_objects": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.MediaListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.MediaResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "dtos.MediaResponse": {
+            "type": "object",
+            "properties": {
+                "audio_codec": {
+                    "type": "string"
+                },
+                "captured_at": {
+                    "type": "string"
+                },
+                "createdAt": {
                     "type": "string"
                 },
+                "duration_seconds": {
+                    "description": "Video only.",
+                    "type": "number"
+                },
+                "height": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "string"
                 },
-                "location": {
+                "key": {
                     "type": "string"
                 },
-                "occupation": {
+                "mime_type": {
                     "type": "string"
                 },
-                "portrait": {
+                "sha256": {
                     "type": "string"
                 },
-                "short_name": {
+                "size": {
+                    "type": "integer"
+                },
+                "upload_count": {
+                    "description": "UploadCount is how many uploads resolved to this object, including the\nfirst; anything above 1 was deduplicated.",
+                    "type": "integer"
+                },
+                "uploader": {
                     "type": "string"
                 },
-                "skills": {
-                    "$ref": "#/definitions/models.CharacterSkills"
+                "url": {
+                    "type": "string"
                 },
-                "updatedAt": {
+                "video_codec": {
                     "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "dtos.CharacterSkillsRequest": {
+        "dtos.MediaStatsResponse": {
             "type": "object",
             "properties": {
-                "endurance": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 0
-                },
-                "guns": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 0
+                "bytes_saved": {
+                    "type": "integer"
                 },
-                "intellect": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 0
+                "dedup_hits": {
+                    "type": "integer"
                 },
-                "melee": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 0
+                "objects": {
+                    "type": "integer"
                 },
-                "persuasion": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 0
+                "total_bytes": {
+                    "type": "integer"
                 },
-                "stealth": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 0
+                "uploads": {
+                    "type": "integer"
                 }
             }
         },
-        "dtos.CommentListResponse": {
+        "dtos.MergeCharacterRequest": {
             "type": "object",
+            "required": [
+                "duplicate_id"
+            ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.CommentResponse"
-                    }
+                "duplicate_id": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.CommentResponse": {
+        "dtos.MoveChapterRequest": {
             "type": "object",
+            "required": [
+                "to",
+                "version"
+            ],
             "properties": {
-                "author": {
-                    "type": "string"
-                },
-                "content": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "to": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "postId": {
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "dtos.CreateCharacterRequest": {
+        "dtos.MoveChapterResponse": {
             "type": "object",
-            "required": [
-                "description",
-                "full_name",
-                "location",
-                "occupation",
-                "portrait",
-                "short_name",
-                "skills"
-            ],
             "properties": {
-                "description": {
-                    "type": "string",
-                    "minLength": 1
-                },
-                "full_name": {
-                    "type": "string",
-                    "maxLength": 120,
-                    "minLength": 1
-                },
-                "location": {
-                    "type": "string",
-                    "maxLength": 160,
-                    "minLength": 1
-                },
-                "occupation": {
-                    "type": "string",
-                    "maxLength": 120,
-                    "minLength": 1
+                "chapter": {
+                    "$ref": "#/definitions/dtos.WhitenestChapterRef"
                 },
-                "portrait": {
+                "version": {
                     "type": "string"
-                },
-                "short_name": {
-                    "type": "string",
-                    "maxLength": 60,
-                    "minLength": 1
-                },
-                "skills": {
-                    "$ref": "#/definitions/dtos.CharacterSkillsRequest"
                 }
             }
         },
-        "dtos.CreateCommentRequest": {
+        "dtos.PostListResponse": {
             "type": "object",
             "properties": {
-                "author": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PostResponse"
+                    }
                 },
-                "content": {
-                    "type": "string"
+                "facets": {
+                    "description": "Facets is only present when the request asked for facets, keyed by\nfacet name (category, tag, author, year).",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/dtos.FacetBucket"
+                        }
+                    }
                 },
-                "postId": {
-                    "type": "string"
+                "meta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
                 }
             }
         },
-        "dtos.CreatePostRequest": {
+        "dtos.PostResponse": {
             "type": "object",
-            "required": [
-                "author",
-                "category_id",
-                "description",
-                "title"
-            ],
             "properties": {
                 "author": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                    "type": "string"
+                },
+                "category": {
+                    "$ref": "#/definitions/dtos.CategoryResponse"
                 },
                 "category_id": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "integer"
                 },
-                "character_ids": {
+                "characters": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dtos.CharacterResponse"
                     }
                 },
                 "content": {
                     "$ref": "#/definitions/models.EditorJsContent"
                 },
+                "createdAt": {
+                    "type": "string"
+                },
                 "date": {
                     "type": "string"
                 },
                 "description": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "image": {
                     "type": "string"
                 },
+                "release_at": {
+                    "type": "string"
+                },
                 "subtitle": {
-                    "type": "string",
-                    "maxLength": 300
+                    "type": "string"
                 },
                 "tags": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dtos.TagResponse"
                     }
                 },
                 "title": {
-                    "type": "string",
-                    "maxLength": 200,
-                    "minLength": 1
-                },
-                "whitenest_chapter_number": {
-                    "type": "integer",
-                    "minimum": 1
-                }
-            }
-        },
-        "dtos.EditorJsErrorDetail": {
-            "type": "object",
-            "properties": {
-                "code": {
                     "type": "string"
                 },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "dtos.EditorJsFileInfo": {
-            "type": "object",
-            "properties": {
-                "url": {
-                    "type": "string"
-                }
-            }
-        },
-        "dtos.EditorJsURLResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "$ref": "#/definitions/dtos.EditorJsErrorDetail"
+                "total_views": {
+                    "type": "integer"
                 },
-                "link": {
+                "updatedAt": {
                     "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/dtos.URLMetadata"
-                },
-                "success": {
+                "whitenest_chapter_number": {
                     "type": "integer"
                 }
             }
         },
-        "dtos.EditorJsUploadResponse": {
+        "dtos.PresignUploadRequest": {
             "type": "object",
+            "required": [
+                "content_type",
+                "size"
+            ],
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/dtos.EditorJsErrorDetail"
+                "content_type": {
+                    "type": "string"
                 },
-                "file": {
-                    "$ref": "#/definitions/dtos.EditorJsFileInfo"
+                "filename": {
+                    "type": "string"
                 },
-                "success": {
+                "size": {
                     "type": "integer"
                 }
             }
         },
-        "dtos.ErrorDetail": {
+        "dtos.PresignUploadResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "expires_at": {
                     "type": "string"
                 },
-                "details": {
+                "fields": {
                     "type": "object",
                     "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        }
+                        "type": "string"
                     }
                 },
-                "message": {
+                "key": {
                     "type": "string"
-                }
-            }
-        },
-        "dtos.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "$ref": "#/definitions/dtos.ErrorDetail"
-                }
-            }
-        },
-        "dtos.PostListResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.PostResponse"
-                    }
                 },
-                "meta": {
-                    "$ref": "#/definitions/models.PaginationMeta"
+                "max_size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.PostResponse": {
+        "dtos.ReaderProgressResponse": {
             "type": "object",
             "properties": {
-                "author": {
-                    "type": "string"
-                },
-                "category": {
-                    "$ref": "#/definitions/dtos.CategoryResponse"
-                },
-                "category_id": {
-                    "type": "integer"
+                "caught_up": {
+                    "type": "boolean"
                 },
-                "characters": {
+                "chapters": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.CharacterResponse"
+                        "$ref": "#/definitions/dtos.ChapterProgressResponse"
                     }
                 },
-                "content": {
-                    "$ref": "#/definitions/models.EditorJsContent"
+                "continue": {
+                    "$ref": "#/definitions/dtos.ContinueReading"
                 },
-                "createdAt": {
+                "read_count": {
+                    "type": "integer"
+                },
+                "reader_id": {
                     "type": "string"
                 },
-                "date": {
+                "total_chapters": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.RelationshipResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
+                "directed": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "string"
+                "since_chapter": {
+                    "type": "integer"
                 },
-                "subtitle": {
+                "source_id": {
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.TagResponse"
-                    }
-                },
-                "title": {
+                "target_id": {
                     "type": "string"
                 },
-                "total_views": {
-                    "type": "integer"
+                "type": {
+                    "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
-                },
-                "whitenest_chapter_number": {
-                    "type": "integer"
                 }
             }
         },
@@ -1597,6 +4421,12 @@ const docTemplate = `{
                 "order"
             ],
             "properties": {
+                "arcs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ArcBoundaryItem"
+                    }
+                },
                 "order": {
                     "type": "array",
                     "minItems": 1,
@@ -1606,6 +4436,35 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.SaveProgressRequest": {
+            "type": "object",
+            "properties": {
+                "block_id": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "read": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dtos.ScheduledChapterResponse": {
+            "type": "object",
+            "properties": {
+                "arc": {
+                    "$ref": "#/definitions/dtos.WhitenestArcRef"
+                },
+                "release_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "whitenest_chapter_number": {
+                    "type": "integer"
+                }
+            }
+        },
         "dtos.SuccessResponse": {
             "type": "object",
             "properties": {
@@ -1634,6 +4493,32 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.URLEmbedInfo": {
+            "type": "object",
+            "properties": {
+                "embed": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
         "dtos.URLImageInfo": {
             "type": "object",
             "properties": {
@@ -1645,12 +4530,30 @@ const docTemplate = `{
         "dtos.URLMetadata": {
             "type": "object",
             "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "canonical_url": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
+                "embed": {
+                    "$ref": "#/definitions/dtos.URLEmbedInfo"
+                },
+                "favicon": {
+                    "type": "string"
+                },
                 "image": {
                     "$ref": "#/definitions/dtos.URLImageInfo"
                 },
+                "published_time": {
+                    "type": "string"
+                },
+                "site_name": {
+                    "type": "string"
+                },
                 "title": {
                     "type": "string"
                 }
@@ -1681,6 +4584,12 @@ const docTemplate = `{
                 "portrait": {
                     "type": "string"
                 },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CharacterSectionRequest"
+                    }
+                },
                 "short_name": {
                     "type": "string",
                     "maxLength": 60,
@@ -1718,6 +4627,10 @@ const docTemplate = `{
                 "image": {
                     "type": "string"
                 },
+                "release_at": {
+                    "description": "ReleaseAt queues a post joining Whitenest, or reschedules a queued\nchapter, which then moves to its slot in release order. Released\nchapters can't be queued again.",
+                    "type": "string"
+                },
                 "subtitle": {
                     "type": "string",
                     "maxLength": 300
@@ -1736,6 +4649,121 @@ const docTemplate = `{
                 "whitenest_chapter_number": {
                     "type": "integer",
                     "minimum": 1
+                },
+                "whitenest_insert_at": {
+                    "description": "WhitenestInsertAt places a post joining Whitenest at that number instead of\nappending it, shifting later chapters up. Requires WhitenestVersion,\nthe ETag of GET /api/whitenest/chapters.",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "whitenest_version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.UpdateRelationshipRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "directed": {
+                    "type": "boolean"
+                },
+                "since_chapter": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "source_id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.UpdateWhitenestArcRequest": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "type": "string"
+                },
+                "start_chapter": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                }
+            }
+        },
+        "dtos.WhitenestArcGroup": {
+            "type": "object",
+            "properties": {
+                "arc": {
+                    "$ref": "#/definitions/dtos.WhitenestArcResponse"
+                },
+                "chapters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.WhitenestChapterSummary"
+                    }
+                }
+            }
+        },
+        "dtos.WhitenestArcRef": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.WhitenestArcResponse": {
+            "type": "object",
+            "properties": {
+                "chapter_count": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "end_chapter": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "start_chapter": {
+                    "type": "integer"
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
@@ -1756,10 +4784,18 @@ const docTemplate = `{
         "dtos.WhitenestChapterResponse": {
             "type": "object",
             "properties": {
+                "arc": {
+                    "description": "Arc is null when the chapter comes before the first arc.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.WhitenestArcRef"
+                        }
+                    ]
+                },
                 "cast": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.CharacterResponse"
+                        "$ref": "#/definitions/dtos.CastMemberResponse"
                     }
                 },
                 "chapter": {
@@ -1782,6 +4818,14 @@ const docTemplate = `{
                 "image": {
                     "type": "string"
                 },
+                "read": {
+                    "description": "Read is only set when the request identifies a reader.",
+                    "type": "boolean"
+                },
+                "release_at": {
+                    "description": "ReleaseAt is only set on queued chapters, which only previews list.",
+                    "type": "string"
+                },
                 "tags": {
                     "type": "array",
                     "items": {
@@ -1796,6 +4840,58 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.WhitenestExportResponse": {
+            "type": "object",
+            "properties": {
+                "arc_id": {
+                    "type": "string"
+                },
+                "chapter_count": {
+                    "type": "integer"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "download_url": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "from_chapter": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_chapter": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CharacterSection": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "revealed_at": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "models.CharacterSkills": {
             "type": "object",
             "properties": {
@@ -1862,9 +4958,16 @@ const docTemplate = `{
                 "limit": {
                     "type": "integer"
                 },
+                "nextCursor": {
+                    "description": "NextCursor and PrevCursor are set by listings that support keyset\npaging when there are rows after / before the page.",
+                    "type": "string"
+                },
                 "page": {
                     "type": "integer"
                 },
+                "prevCursor": {
+                    "type": "string"
+                },
                 "total": {
                     "type": "integer"
                 },
@@ -11,6 +11,7 @@ require (
 	github.com/swaggo/files v1.0.1
 	github.com/swaggo/gin-swagger v1.6.1
 	github.com/swaggo/swag v1.16.4
+	golang.org/x/image v0.35.0
 	golang.org/x/net v0.49.0
 	google.golang.org/genai v1.52.1
 	gorm.io/driver/postgres v1.6.0
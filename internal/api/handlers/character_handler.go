@@ -3,7 +3,9 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/davidrdsilva/blog-api/internal/api/middleware"
 	"github.com/davidrdsilva/blog-api/internal/application/dtos"
 	"github.com/davidrdsilva/blog-api/internal/application/services"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
@@ -12,12 +14,17 @@ import (
 )
 
 type CharacterHandler struct {
-	service *services.CharacterService
-	logger  *logging.Logger
+	service         *services.CharacterService
+	progressService *services.ReaderProgressService
+	logger          *logging.Logger
 }
 
-func NewCharacterHandler(service *services.CharacterService, logger *logging.Logger) *CharacterHandler {
-	return &CharacterHandler{service: service, logger: logger}
+func NewCharacterHandler(
+	service *services.CharacterService,
+	progressService *services.ReaderProgressService,
+	logger *logging.Logger,
+) *CharacterHandler {
+	return &CharacterHandler{service: service, progressService: progressService, logger: logger}
 }
 
 // ListCharacters handles GET /api/characters
@@ -25,15 +32,24 @@ func NewCharacterHandler(service *services.CharacterService, logger *logging.Log
 // @Summary      List characters
 // @Description  Returns all characters, alphabetized by short name. Optionally
 // @Description  filter by `?search=` (case-insensitive on full and short name).
+// @Description  Each character carries its chapter appearance count and its
+// @Description  first and last chapter. With `as_of_chapter`, only characters
+// @Description  who have appeared by then are listed, without later spoilers.
 // @Tags         characters
 // @Produce      json
-// @Param        search  query     string  false  "Substring search on short_name/full_name"
-// @Success      200  {object}  dtos.SuccessResponse{data=[]dtos.CharacterResponse}
-// @Failure      500  {object}  dtos.ErrorResponse
+// @Param        search         query     string  false  "Substring search on short_name/full_name"
+// @Param        as_of_chapter  query     string  false  "Only characters introduced by this chapter (or progress), without later spoilers"
+// @Success      200            {object}  dtos.SuccessResponse{data=[]dtos.CharacterResponse}
+// @Failure      400            {object}  dtos.ErrorResponse
+// @Failure      500            {object}  dtos.ErrorResponse
 // @Router       /characters [get]
 func (h *CharacterHandler) ListCharacters(c *gin.Context) {
+	asOf, ok := h.parseAsOfChapter(c)
+	if !ok {
+		return
+	}
 	search := c.Query("search")
-	rows, err := h.service.ListCharacters(search)
+	rows, err := h.service.ListCharacters(search, asOf)
 	if err != nil {
 		h.logger.Error("Failed to list characters", logging.F("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
@@ -47,17 +63,25 @@ func (h *CharacterHandler) ListCharacters(c *gin.Context) {
 // GetCharacter handles GET /api/characters/:id
 //
 // @Summary      Get a character by ID
+// @Description  With `as_of_chapter`, description sections revealed later are
+// @Description  left out, skills/occupation/location are as of that chapter,
+// @Description  and a character not yet introduced returns 404.
 // @Tags         characters
 // @Produce      json
-// @Param        id   path      string  true  "Character UUID"
-// @Success      200  {object}  dtos.SuccessResponse{data=dtos.CharacterResponse}
-// @Failure      400  {object}  dtos.ErrorResponse
-// @Failure      404  {object}  dtos.ErrorResponse
-// @Failure      500  {object}  dtos.ErrorResponse
+// @Param        id             path      string  true   "Character UUID"
+// @Param        as_of_chapter  query     string  false  "Gate the response to a reader at this chapter, or progress for the reader's own"
+// @Success      200            {object}  dtos.SuccessResponse{data=dtos.CharacterResponse}
+// @Failure      400            {object}  dtos.ErrorResponse
+// @Failure      404            {object}  dtos.ErrorResponse
+// @Failure      500            {object}  dtos.ErrorResponse
 // @Router       /characters/{id} [get]
 func (h *CharacterHandler) GetCharacter(c *gin.Context) {
 	id := c.Param("id")
-	resp, err := h.service.GetCharacter(id)
+	asOf, ok := h.parseAsOfChapter(c)
+	if !ok {
+		return
+	}
+	resp, err := h.service.GetCharacter(id, asOf)
 	if err != nil {
 		if containsStr(err.Error(), "invalid UUID") {
 			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
@@ -80,6 +104,116 @@ func (h *CharacterHandler) GetCharacter(c *gin.Context) {
 	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
 }
 
+// GetAppearances handles GET /api/characters/:id/appearances
+//
+// @Summary      List a character's chapter appearances
+// @Description  Returns the Whitenest chapters the character is cast in,
+// @Description  ordered by chapter number, with the first and last appearance.
+// @Tags         characters
+// @Produce      json
+// @Param        id             path      string  true   "Character UUID"
+// @Param        as_of_chapter  query     string  false  "Gate the response to a reader at this chapter, or progress for the reader's own"
+// @Success      200            {object}  dtos.SuccessResponse{data=dtos.CharacterAppearancesResponse}
+// @Failure      400            {object}  dtos.ErrorResponse
+// @Failure      404            {object}  dtos.ErrorResponse
+// @Failure      500            {object}  dtos.ErrorResponse
+// @Router       /characters/{id}/appearances [get]
+func (h *CharacterHandler) GetAppearances(c *gin.Context) {
+	id := c.Param("id")
+	asOf, ok := h.parseAsOfChapter(c)
+	if !ok {
+		return
+	}
+	resp, err := h.service.GetAppearances(id, asOf)
+	if err != nil {
+		if containsStr(err.Error(), "invalid UUID") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid character ID"},
+			})
+			return
+		}
+		h.logger.Error("Failed to fetch character appearances", logging.F("error", err.Error()), logging.F("id", id))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to fetch appearances"},
+		})
+		return
+	}
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "CHARACTER_NOT_FOUND", Message: "Character not found"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// GetProgression handles GET /api/characters/:id/progression
+//
+// @Summary      Character state across chapters
+// @Description  Returns the character's base state and their skills,
+// @Description  occupation and location at every chapter they appear in, with
+// @Description  per-chapter overrides applied in order.
+// @Tags         characters
+// @Produce      json
+// @Param        id             path      string  true   "Character UUID"
+// @Param        as_of_chapter  query     string  false  "Gate the response to a reader at this chapter, or progress for the reader's own"
+// @Success      200            {object}  dtos.SuccessResponse{data=dtos.CharacterProgressionResponse}
+// @Failure      400            {object}  dtos.ErrorResponse
+// @Failure      404            {object}  dtos.ErrorResponse
+// @Failure      500            {object}  dtos.ErrorResponse
+// @Router       /characters/{id}/progression [get]
+func (h *CharacterHandler) GetProgression(c *gin.Context) {
+	id := c.Param("id")
+	asOf, ok := h.parseAsOfChapter(c)
+	if !ok {
+		return
+	}
+	resp, err := h.service.GetProgression(id, asOf)
+	if err != nil {
+		if containsStr(err.Error(), "invalid UUID") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid character ID"},
+			})
+			return
+		}
+		h.logger.Error("Failed to fetch character progression", logging.F("error", err.Error()), logging.F("id", id))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to fetch progression"},
+		})
+		return
+	}
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "CHARACTER_NOT_FOUND", Message: "Character not found"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// CastMatrix handles GET /api/whitenest/cast-matrix
+//
+// @Summary      Characters × chapters presence matrix
+// @Description  Returns every chapter as a column and, for each character cast
+// @Description  in at least one chapter, the chapter numbers they appear in.
+// @Description  Rows are ordered by first appearance, then short name.
+// @Tags         characters
+// @Produce      json
+// @Success      200  {object}  dtos.SuccessResponse{data=dtos.CastMatrixResponse}
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /whitenest/cast-matrix [get]
+func (h *CharacterHandler) CastMatrix(c *gin.Context) {
+	resp, err := h.service.CastMatrix()
+	if err != nil {
+		h.logger.Error("Failed to build cast matrix", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to build cast matrix"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
 // CreateCharacter handles POST /api/characters
 //
 // @Summary      Create a character
@@ -178,17 +312,55 @@ func (h *CharacterHandler) UpdateCharacter(c *gin.Context) {
 // DeleteCharacter handles DELETE /api/characters/:id
 //
 // @Summary      Delete a character
+// @Description  Refuses with 409 CHARACTER_IN_USE, listing the affected
+// @Description  chapters and posts, while the character is cast anywhere.
+// @Description  With `force=true` the character is removed from those casts.
+// @Description  Relationships of the character are always deleted.
 // @Tags         characters
-// @Param        id   path      string  true  "Character UUID"
+// @Param        id     path      string  true   "Character UUID"
+// @Param        force  query     bool    false  "Delete even if cast in posts"
 // @Success      204
-// @Failure      400  {object}  dtos.ErrorResponse
-// @Failure      404  {object}  dtos.ErrorResponse
-// @Failure      500  {object}  dtos.ErrorResponse
+// @Failure      400    {object}  dtos.ErrorResponse
+// @Failure      404    {object}  dtos.ErrorResponse
+// @Failure      409    {object}  dtos.ErrorResponse
+// @Failure      500    {object}  dtos.ErrorResponse
 // @Router       /characters/{id} [delete]
 func (h *CharacterHandler) DeleteCharacter(c *gin.Context) {
 	id := c.Param("id")
-	err := h.service.DeleteCharacter(id)
+	force := false
+	if raw := c.Query("force"); raw != "" {
+		var err error
+		if force, err = strconv.ParseBool(raw); err != nil {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_QUERY_PARAM", Message: "force must be true or false"},
+			})
+			return
+		}
+	}
+
+	err := h.service.DeleteCharacter(id, force)
 	if err != nil {
+		var inUse *services.CharacterInUseError
+		if errors.As(err, &inUse) {
+			chapters, posts := []string{}, []string{}
+			for _, cast := range inUse.Casts {
+				if cast.ChapterNumber != nil {
+					chapters = append(chapters, strconv.Itoa(*cast.ChapterNumber))
+				}
+				posts = append(posts, cast.PostID)
+			}
+			c.JSON(http.StatusConflict, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "CHARACTER_IN_USE",
+					Message: err.Error(),
+					Details: map[string][]string{
+						"chapters": chapters,
+						"post_ids": posts,
+					},
+				},
+			})
+			return
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, dtos.ErrorResponse{
 				Error: dtos.ErrorDetail{Code: "CHARACTER_NOT_FOUND", Message: "Character not found"},
@@ -209,3 +381,349 @@ func (h *CharacterHandler) DeleteCharacter(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// MergeCharacter handles POST /api/characters/:id/merge
+//
+// @Summary      Merge a duplicate character into this one
+// @Description  Moves every cast entry and relationship of `duplicate_id` to
+// @Description  the path character, then deletes the duplicate. Cast entries
+// @Description  keep their position; in chapters where both are cast the two
+// @Description  entries become one at the earlier position. Relationships
+// @Description  between the two, or already held by this character, are
+// @Description  dropped. The duplicate's description, sections and portrait
+// @Description  are not kept.
+// @Tags         characters
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                      true  "Canonical character UUID"
+// @Param        body  body      dtos.MergeCharacterRequest  true  "Duplicate to merge"
+// @Success      200   {object}  dtos.SuccessResponse{data=dtos.CharacterMergeResponse}
+// @Failure      400   {object}  dtos.ErrorResponse
+// @Failure      404   {object}  dtos.ErrorResponse
+// @Failure      500   {object}  dtos.ErrorResponse
+// @Router       /characters/{id}/merge [post]
+func (h *CharacterHandler) MergeCharacter(c *gin.Context) {
+	id := c.Param("id")
+	var req dtos.MergeCharacterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "VALIDATION_ERROR",
+				Message: "Request validation failed",
+				Details: parseValidationErrors(err),
+			},
+		})
+		return
+	}
+
+	resp, err := h.service.MergeCharacter(id, req)
+	if err != nil {
+		switch {
+		case containsStr(err.Error(), "invalid UUID"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid character ID"},
+			})
+			return
+		case containsStr(err.Error(), "invalid merge"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_MERGE", Message: err.Error()},
+			})
+			return
+		}
+		h.logger.Error("Failed to merge characters", logging.F("error", err.Error()), logging.F("id", id))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to merge characters"},
+		})
+		return
+	}
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "CHARACTER_NOT_FOUND", Message: "Character not found"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// CharacterGraph handles GET /api/characters/graph
+//
+// @Summary      Character relationship graph
+// @Description  Returns characters as nodes and relationships as links, ready
+// @Description  for a force-directed layout (link source/target are node IDs).
+// @Description  With `as_of_chapter`, only characters who have appeared by then
+// @Description  and relationships established by then are included.
+// @Description  `as_of_chapter=progress` uses the reader's own progress.
+// @Tags         characters
+// @Produce      json
+// @Param        as_of_chapter  query     string  false  "Gate the graph to a reader at this chapter, or progress for the reader's own"
+// @Success      200            {object}  dtos.SuccessResponse{data=dtos.CharacterGraphResponse}
+// @Failure      400            {object}  dtos.ErrorResponse
+// @Failure      500            {object}  dtos.ErrorResponse
+// @Router       /characters/graph [get]
+func (h *CharacterHandler) CharacterGraph(c *gin.Context) {
+	asOf, ok := h.parseAsOfChapter(c)
+	if !ok {
+		return
+	}
+	resp, err := h.service.Graph(asOf)
+	if err != nil {
+		h.logger.Error("Failed to build character graph", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to build character graph"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// ListRelationships handles GET /api/character-relationships
+//
+// @Summary      List character relationships
+// @Tags         characters
+// @Produce      json
+// @Param        character_id  query     string  false  "Only relationships involving this character"
+// @Success      200           {object}  dtos.SuccessResponse{data=[]dtos.RelationshipResponse}
+// @Failure      400           {object}  dtos.ErrorResponse
+// @Failure      500           {object}  dtos.ErrorResponse
+// @Router       /character-relationships [get]
+func (h *CharacterHandler) ListRelationships(c *gin.Context) {
+	rels, err := h.service.ListRelationships(c.Query("character_id"))
+	if err != nil {
+		if containsStr(err.Error(), "invalid UUID") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid character ID"},
+			})
+			return
+		}
+		h.logger.Error("Failed to list relationships", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to list relationships"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: rels})
+}
+
+// GetRelationship handles GET /api/character-relationships/:id
+//
+// @Summary      Get a character relationship
+// @Tags         characters
+// @Produce      json
+// @Param        id   path      string  true  "Relationship UUID"
+// @Success      200  {object}  dtos.SuccessResponse{data=dtos.RelationshipResponse}
+// @Failure      400  {object}  dtos.ErrorResponse
+// @Failure      404  {object}  dtos.ErrorResponse
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /character-relationships/{id} [get]
+func (h *CharacterHandler) GetRelationship(c *gin.Context) {
+	resp, err := h.service.GetRelationship(c.Param("id"))
+	if err != nil {
+		if containsStr(err.Error(), "invalid UUID") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid relationship ID"},
+			})
+			return
+		}
+		h.logger.Error("Failed to fetch relationship", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to fetch relationship"},
+		})
+		return
+	}
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "RELATIONSHIP_NOT_FOUND", Message: "Relationship not found"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// CreateRelationship handles POST /api/character-relationships
+//
+// @Summary      Create a character relationship
+// @Description  `type` is one of family, sibling, parent, partner, friend,
+// @Description  ally, rival, enemy, mentor, employer, other. A directed
+// @Description  relationship reads "source is <type> of target".
+// @Tags         characters
+// @Accept       json
+// @Produce      json
+// @Param        relationship  body      dtos.CreateRelationshipRequest  true  "Relationship payload"
+// @Success      201           {object}  dtos.SuccessResponse{data=dtos.RelationshipResponse}
+// @Failure      400           {object}  dtos.ErrorResponse
+// @Failure      409           {object}  dtos.ErrorResponse
+// @Failure      500           {object}  dtos.ErrorResponse
+// @Router       /character-relationships [post]
+func (h *CharacterHandler) CreateRelationship(c *gin.Context) {
+	var req dtos.CreateRelationshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "VALIDATION_ERROR",
+				Message: "Request validation failed",
+				Details: parseValidationErrors(err),
+			},
+		})
+		return
+	}
+	resp, err := h.service.CreateRelationship(req)
+	if err != nil {
+		if h.writeRelationshipError(c, err) {
+			return
+		}
+		h.logger.Error("Failed to create relationship", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to create relationship"},
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, dtos.SuccessResponse{Data: resp})
+}
+
+// UpdateRelationship handles PUT /api/character-relationships/:id
+//
+// @Summary      Update a character relationship
+// @Tags         characters
+// @Accept       json
+// @Produce      json
+// @Param        id            path      string                          true  "Relationship UUID"
+// @Param        relationship  body      dtos.UpdateRelationshipRequest  true  "Patch payload"
+// @Success      200           {object}  dtos.SuccessResponse{data=dtos.RelationshipResponse}
+// @Failure      400           {object}  dtos.ErrorResponse
+// @Failure      404           {object}  dtos.ErrorResponse
+// @Failure      409           {object}  dtos.ErrorResponse
+// @Failure      500           {object}  dtos.ErrorResponse
+// @Router       /character-relationships/{id} [put]
+func (h *CharacterHandler) UpdateRelationship(c *gin.Context) {
+	var req dtos.UpdateRelationshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "VALIDATION_ERROR",
+				Message: "Request validation failed",
+				Details: parseValidationErrors(err),
+			},
+		})
+		return
+	}
+	resp, err := h.service.UpdateRelationship(c.Param("id"), req)
+	if err != nil {
+		if containsStr(err.Error(), "invalid UUID") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid relationship ID"},
+			})
+			return
+		}
+		if h.writeRelationshipError(c, err) {
+			return
+		}
+		h.logger.Error("Failed to update relationship", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to update relationship"},
+		})
+		return
+	}
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "RELATIONSHIP_NOT_FOUND", Message: "Relationship not found"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// DeleteRelationship handles DELETE /api/character-relationships/:id
+//
+// @Summary      Delete a character relationship
+// @Tags         characters
+// @Param        id   path      string  true  "Relationship UUID"
+// @Success      204
+// @Failure      400  {object}  dtos.ErrorResponse
+// @Failure      404  {object}  dtos.ErrorResponse
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /character-relationships/{id} [delete]
+func (h *CharacterHandler) DeleteRelationship(c *gin.Context) {
+	err := h.service.DeleteRelationship(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "RELATIONSHIP_NOT_FOUND", Message: "Relationship not found"},
+			})
+			return
+		}
+		if containsStr(err.Error(), "invalid UUID") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid relationship ID"},
+			})
+			return
+		}
+		h.logger.Error("Failed to delete relationship", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to delete relationship"},
+		})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// writeRelationshipError maps relationship validation errors shared by create
+// and update. Returns false when err is not one of them.
+func (h *CharacterHandler) writeRelationshipError(c *gin.Context, err error) bool {
+	msg := err.Error()
+	switch {
+	case containsStr(msg, "invalid relationship"):
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INVALID_RELATIONSHIP", Message: msg},
+		})
+		return true
+	case containsStr(msg, "relationship already exists") || containsStr(msg, "duplicate key"):
+		c.JSON(http.StatusConflict, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "RELATIONSHIP_EXISTS", Message: msg},
+		})
+		return true
+	}
+	return false
+}
+
+// asOfReaderProgress is the as_of_chapter value that gates to the reader's
+// own progress.
+const asOfReaderProgress = "progress"
+
+// parseAsOfChapter reads the optional as_of_chapter query parameter (0 when
+// absent). "progress" resolves to the furthest chapter the request's reader
+// has opened, or chapter 1 for a reader who hasn't started. On a malformed
+// value it writes the 400 and returns ok=false.
+func (h *CharacterHandler) parseAsOfChapter(c *gin.Context) (asOf int, ok bool) {
+	raw := c.Query("as_of_chapter")
+	if raw == "" {
+		return 0, true
+	}
+	if raw == asOfReaderProgress {
+		c.Writer.Header().Add("Vary", "Cookie, "+middleware.ReaderIDHeader)
+		reached, err := h.progressService.ReachedChapter(middleware.ReaderID(c))
+		if err != nil {
+			h.logger.Error("Failed to resolve reader progress",
+				logging.F("error", err.Error()),
+			)
+			c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: "Failed to fetch reading progress",
+				},
+			})
+			return 0, false
+		}
+		return max(reached, 1), true
+	}
+	asOf, err := strconv.Atoi(raw)
+	if err != nil || asOf < 1 {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "INVALID_QUERY_PARAM",
+				Message: `as_of_chapter must be a positive integer or "progress"`,
+			},
+		})
+		return 0, false
+	}
+	return asOf, true
+}
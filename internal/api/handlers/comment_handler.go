@@ -102,8 +102,12 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 // @Produce      json
 // @Param        postId     query     string  false  "Filter by post ID"
 // @Param        author     query     string  false  "Filter by author"
-// @Param        sortBy     query     string  false  "Sort field"
-// @Param        sortOrder  query     string  false  "asc or desc"
+// @Param        sortBy     query     string  false  "Sort field: createdAt (default) or author"
+// @Param        sortOrder  query     string  false  "asc or desc (default)"
+// @Param        page       query     int     false  "Page number (default 1)"
+// @Param        limit      query     int     false  "Comments per page (default 20, max 100)"
+// @Param        cursor     query     string  false  "meta.nextCursor or meta.prevCursor of another page; overrides page"
+// @Param        count      query     bool    false  "Set to false to skip the total count (default true)"
 // @Success      200        {object}  dtos.CommentListResponse
 // @Failure      400        {object}  dtos.ErrorResponse
 // @Failure      500        {object}  dtos.ErrorResponse
@@ -115,9 +119,23 @@ func (h *CommentHandler) ListComments(c *gin.Context) {
 		Author:    c.Query("author"),
 		SortBy:    c.Query("sortBy"),
 		SortOrder: c.Query("sortOrder"),
+		Page:      parseIntQuery(c, "page", 1),
+		Limit:     parseIntQuery(c, "limit", 20),
+		Cursor:    c.Query("cursor"),
+		SkipCount: !parseBoolQuery(c, "count", true),
 	}
 	comments, err := h.service.GetComments(filters)
 	if err != nil {
+		if containsStr(err.Error(), "invalid cursor") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INVALID_CURSOR",
+					Message: "Cursor is malformed or was issued for a different sort",
+				},
+			})
+			return
+		}
+
 		if containsStr(err.Error(), "invalid input syntax for type uuid") {
 			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
 				Error: dtos.ErrorDetail{
@@ -128,6 +146,7 @@ func (h *CommentHandler) ListComments(c *gin.Context) {
 			return
 		}
 
+		h.logger.Error("Failed to list comments", logging.F("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
 			Error: dtos.ErrorDetail{
 				Code:    "INTERNAL_ERROR",
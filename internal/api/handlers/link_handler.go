@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/application/services"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// LinkHandler handles outbound link monitoring requests
+type LinkHandler struct {
+	service *services.LinkCheckService
+	logger  *logging.Logger
+}
+
+// NewLinkHandler creates a new link handler
+func NewLinkHandler(service *services.LinkCheckService, logger *logging.Logger) *LinkHandler {
+	return &LinkHandler{service: service, logger: logger}
+}
+
+// BrokenLinks handles GET /api/links/broken
+//
+// @Summary      List posts with broken outbound links
+// @Description  Reports, per post, the links that failed the most recent
+// @Description  link check: request errors and 4xx/5xx statuses other than 429.
+// @Tags         links
+// @Produce      json
+// @Success      200  {object}  dtos.BrokenLinksReport
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /links/broken [get]
+func (h *LinkHandler) BrokenLinks(c *gin.Context) {
+	report, err := h.service.BrokenLinks()
+	if err != nil {
+		h.logger.Error("Failed to build broken link report", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to build broken link report"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// CheckLinks handles POST /api/links/check
+//
+// @Summary      Run the link checker now
+// @Description  Checks every outbound link in post content and replaces the
+// @Description  stored results. Returns when the sweep finishes.
+// @Tags         links
+// @Produce      json
+// @Success      200  {object}  dtos.LinkCheckSummary
+// @Failure      409  {object}  dtos.ErrorResponse
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /links/check [post]
+func (h *LinkHandler) CheckLinks(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Minute)
+	defer cancel()
+
+	summary, err := h.service.Sweep(ctx)
+	if err != nil {
+		if containsStr(err.Error(), "link check already running") {
+			c.JSON(http.StatusConflict, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "LINK_CHECK_RUNNING", Message: "A link check is already running"},
+			})
+			return
+		}
+		h.logger.Error("Link check failed", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Link check failed"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/application/services"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MediaHandler handles media library requests
+type MediaHandler struct {
+	service   *services.MediaService
+	gcService *services.MediaGCService
+	logger    *logging.Logger
+}
+
+// NewMediaHandler creates a new media handler
+func NewMediaHandler(
+	service *services.MediaService,
+	gcService *services.MediaGCService,
+	logger *logging.Logger,
+) *MediaHandler {
+	return &MediaHandler{service: service, gcService: gcService, logger: logger}
+}
+
+// ListMedia handles GET /api/media
+//
+// @Summary      List uploaded media
+// @Description  Returns the media library newest first, with the same
+// @Description  pagination envelope as the post listing.
+// @Tags         media
+// @Produce      json
+// @Param        type       query     string  false  "MIME top-level type"  Enums(image, video)
+// @Param        mime_type  query     string  false  "Exact MIME type, e.g. image/png"
+// @Param        uploader   query     string  false  "Filter by uploader"
+// @Param        search     query     string  false  "Substring search on the object key"
+// @Param        sortOrder  query     string  false  "asc or desc by upload time"  Enums(asc, desc)
+// @Param        page       query     int     false  "Page number (default 1)"
+// @Param        limit      query     int     false  "Items per page (default 24, max 100)"
+// @Success      200        {object}  dtos.MediaListResponse
+// @Failure      500        {object}  dtos.ErrorResponse
+// @Router       /media [get]
+func (h *MediaHandler) ListMedia(c *gin.Context) {
+	filters := models.MediaFilters{
+		Kind:      c.Query("type"),
+		MimeType:  c.Query("mime_type"),
+		Uploader:  c.Query("uploader"),
+		Search:    c.Query("search"),
+		SortOrder: c.Query("sortOrder"),
+		Page:      parseIntQuery(c, "page", 1),
+		Limit:     parseIntQuery(c, "limit", 24),
+	}
+
+	resp, err := h.service.ListMedia(filters)
+	if err != nil {
+		h.logger.Error("Failed to list media", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to list media"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetMediaStats handles GET /api/media/stats
+//
+// @Summary      Media storage statistics
+// @Description  Object count and stored bytes, plus how many uploads were
+// @Description  deduplicated against an existing object and the bytes that saved.
+// @Tags         media
+// @Produce      json
+// @Success      200  {object}  dtos.MediaStatsResponse
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /media/stats [get]
+func (h *MediaHandler) GetMediaStats(c *gin.Context) {
+	stats, err := h.service.GetStats()
+	if err != nil {
+		h.logger.Error("Failed to get media stats", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to get media stats"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// DeleteMedia handles DELETE /api/media/:id
+//
+// @Summary      Delete a media object
+// @Description  Removes the object from storage and the media library. Refused
+// @Description  with 409 while any post cover image, Editor.js image or video
+// @Description  block, character portrait or arc cover references it; the
+// @Description  response details list the referencing post, character and
+// @Description  arc IDs.
+// @Tags         media
+// @Param        id   path      string  true  "Media UUID"
+// @Success      204
+// @Failure      400  {object}  dtos.ErrorResponse
+// @Failure      404  {object}  dtos.ErrorResponse
+// @Failure      409  {object}  dtos.ErrorResponse
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /media/{id} [delete]
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	id := c.Param("id")
+	err := h.service.DeleteMedia(id)
+	if err != nil {
+		var inUse *services.MediaInUseError
+		if errors.As(err, &inUse) {
+			c.JSON(http.StatusConflict, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "MEDIA_IN_USE",
+					Message: err.Error(),
+					Details: map[string][]string{
+						"post_images":         inUse.References.PostImageIDs,
+						"post_content":        inUse.References.PostContentIDs,
+						"character_portraits": inUse.References.CharacterIDs,
+						"arc_images":          inUse.References.ArcIDs,
+					},
+				},
+			})
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "MEDIA_NOT_FOUND", Message: "Media not found"},
+			})
+			return
+		}
+		if containsStr(err.Error(), "invalid UUID") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid media ID"},
+			})
+			return
+		}
+		h.logger.Error("Failed to delete media", logging.F("error", err.Error()), logging.F("id", id))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to delete media"},
+		})
+		return
+	}
+
+	h.logger.Info("Media deleted successfully", logging.F("id", id))
+	c.Status(http.StatusNoContent)
+}
+
+// MediaGCReport handles GET /api/media/gc
+//
+// @Summary      Preview the orphaned-media garbage collector
+// @Description  Runs a dry-run sweep and reports, per unreferenced object,
+// @Description  whether the collector would leave it pending, quarantine it,
+// @Description  restore it from quarantine or delete it. Nothing is changed.
+// @Tags         media
+// @Produce      json
+// @Success      200  {object}  dtos.MediaGCReport
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /media/gc [get]
+func (h *MediaHandler) MediaGCReport(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	report, err := h.gcService.Sweep(ctx, true)
+	if err != nil {
+		h.logger.Error("Failed to build media GC report", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to build media GC report"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
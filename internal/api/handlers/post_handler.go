@@ -1,14 +1,19 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/davidrdsilva/blog-api/internal/api/middleware"
 	"github.com/davidrdsilva/blog-api/internal/application/dtos"
 	"github.com/davidrdsilva/blog-api/internal/application/services"
 	"github.com/davidrdsilva/blog-api/internal/domain/models"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/timezone"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -36,6 +41,7 @@ func NewPostHandler(service *services.PostService, logger *logging.Logger) *Post
 // @Param        post  body      dtos.CreatePostRequest  true  "Post payload"
 // @Success      201   {object}  dtos.SuccessResponse
 // @Failure      400   {object}  dtos.ErrorResponse
+// @Failure      409   {object}  dtos.ErrorResponse
 // @Failure      500   {object}  dtos.ErrorResponse
 // @Router       /posts [post]
 func (h *PostHandler) CreatePost(c *gin.Context) {
@@ -77,6 +83,26 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 			return
 		}
 
+		if containsStr(err.Error(), "chapter version mismatch") {
+			c.JSON(http.StatusConflict, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "CHAPTER_VERSION_MISMATCH",
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
+		if containsStr(err.Error(), "invalid chapter position") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INVALID_CHAPTER_POSITION",
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
 		if containsStr(err.Error(), "whitenest invariant") {
 			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
 				Error: dtos.ErrorDetail{
@@ -103,18 +129,28 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 // GetPost handles GET /api/posts/:id
 //
 // @Summary      Get a post by ID
+// @Description  Queued Whitenest chapters are 404 except in a preview
+// @Description  (`preview=true` with the editor's X-Preview-Token), which
+// @Description  doesn't count as a view.
 // @Tags         posts
 // @Produce      json
-// @Param        id   path      string  true  "Post UUID"
+// @Param        id               path      string  true   "Post UUID"
+// @Param        preview          query     bool    false  "Include queued chapters"
+// @Param        X-Preview-Token  header    string  false  "Editor token, required with preview=true"
 // @Success      200  {object}  dtos.SuccessResponse
 // @Failure      400  {object}  dtos.ErrorResponse
+// @Failure      403  {object}  dtos.ErrorResponse
 // @Failure      404  {object}  dtos.ErrorResponse
 // @Failure      500  {object}  dtos.ErrorResponse
 // @Router       /posts/{id} [get]
 func (h *PostHandler) GetPost(c *gin.Context) {
 	id := c.Param("id")
+	preview, ok := parsePreview(c)
+	if !ok {
+		return
+	}
 
-	post, err := h.service.GetPost(id)
+	post, err := h.service.GetPost(id, preview)
 	if err != nil {
 		if containsStr(err.Error(), "invalid input syntax for type uuid") {
 			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
@@ -156,19 +192,45 @@ func (h *PostHandler) GetPost(c *gin.Context) {
 // @Produce      json
 // @Param        search       query     string    false  "Search term"
 // @Param        author       query     string    false  "Filter by author"
-// @Param        category_id  query     int       false  "Filter by category ID"
-// @Param        tags         query     []string  false  "Filter by tag names (OR semantics; repeat the param or comma-join)"  collectionFormat(multi)
+// @Param        category_id  query     []int     false  "Filter by category IDs (any of them; repeat the param or comma-join)"  collectionFormat(multi)
+// @Param        tags         query     []string  false  "Filter by tag names (repeat the param or comma-join)"  collectionFormat(multi)
+// @Param        tag_mode     query     string    false  "any (default) or all of the tags"  Enums(any, all)
+// @Param        date_from    query     string    false  "Posts dated on or after (YYYY-MM-DD or RFC 3339)"
+// @Param        date_to      query     string    false  "Posts dated up to (YYYY-MM-DD inclusive, or RFC 3339 exclusive)"
+// @Param        facets       query     []string  false  "Facets to compute over the filtered set"  collectionFormat(csv)  Enums(category, tag, author, year)
 // @Param        sortBy       query     string    false  "Sort field"  Enums(date, title, createdAt, updatedAt)
 // @Param        sortOrder    query     string    false  "asc or desc"  Enums(asc, desc)
 // @Param        page         query     int       false  "Page number (default 1)"
 // @Param        limit        query     int       false  "Items per page (default 6, max 50)"
+// @Param        cursor       query     string    false  "meta.nextCursor or meta.prevCursor of another page; overrides page"
+// @Param        count        query     bool      false  "Set to false to skip the total count (default true)"
 // @Success      200          {object}  dtos.PostListResponse
+// @Failure      400          {object}  dtos.ErrorResponse
 // @Failure      500          {object}  dtos.ErrorResponse
 // @Router       /posts [get]
 func (h *PostHandler) ListPosts(c *gin.Context) {
-	filters := parsePostFilters(c)
+	filters, err := parsePostFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "INVALID_QUERY_PARAM",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
 	posts, err := h.service.ListPosts(filters)
 	if err != nil {
+		if containsStr(err.Error(), "invalid cursor") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INVALID_CURSOR",
+					Message: "Cursor is malformed or was issued for a different sort",
+				},
+			})
+			return
+		}
+
 		h.logger.Error("Failed to list posts", logging.F("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
 			Error: dtos.ErrorDetail{
@@ -189,15 +251,32 @@ func (h *PostHandler) ListPosts(c *gin.Context) {
 // @Tags         posts
 // @Produce      json
 // @Param        search    query     string  false  "Full-text search"
+// @Param        tags      query     []string  false  "Filter by tag names (repeat the param or comma-join)"  collectionFormat(multi)
+// @Param        tag_mode  query     string  false  "any (default) or all of the tags"  Enums(any, all)
+// @Param        date_from query     string  false  "Posts dated on or after (YYYY-MM-DD or RFC 3339)"
+// @Param        date_to   query     string  false  "Posts dated up to (YYYY-MM-DD inclusive, or RFC 3339 exclusive)"
+// @Param        facets    query     []string  false  "Facets to compute over the filtered set"  collectionFormat(csv)  Enums(category, tag, author, year)
 // @Param        sortBy    query     string  false  "Sort field"  Enums(date, title, createdAt, updatedAt)
 // @Param        sortOrder query     string  false  "asc or desc"  Enums(asc, desc)
 // @Param        page      query     int     false  "Page number (default 1)"
 // @Param        limit     query     int     false  "Items per page (default 6, max 50)"
+// @Param        cursor    query     string  false  "meta.nextCursor or meta.prevCursor of another page; overrides page"
+// @Param        count     query     bool    false  "Set to false to skip the total count (default true)"
 // @Success      200       {object}  dtos.PostListResponse
+// @Failure      400       {object}  dtos.ErrorResponse
 // @Failure      500       {object}  dtos.ErrorResponse
 // @Router       /posts/drafts [get]
 func (h *PostHandler) ListDrafts(c *gin.Context) {
-	filters := parsePostFilters(c)
+	filters, err := parsePostFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "INVALID_QUERY_PARAM",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
 	filters.OnlyInternalCategories = true
 	// Whitenest invariants forbid drafts from carrying a chapter number, so the
 	// chapter-exclusion default is irrelevant — clear it to avoid an unnecessary
@@ -206,6 +285,16 @@ func (h *PostHandler) ListDrafts(c *gin.Context) {
 
 	posts, err := h.service.ListPosts(filters)
 	if err != nil {
+		if containsStr(err.Error(), "invalid cursor") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INVALID_CURSOR",
+					Message: "Cursor is malformed or was issued for a different sort",
+				},
+			})
+			return
+		}
+
 		h.logger.Error("Failed to list drafts", logging.F("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
 			Error: dtos.ErrorDetail{
@@ -220,8 +309,10 @@ func (h *PostHandler) ListDrafts(c *gin.Context) {
 }
 
 // parsePostFilters extracts the standard list-posts query parameters into a
-// PostFilters value. Shared between ListPosts and ListDrafts.
-func parsePostFilters(c *gin.Context) models.PostFilters {
+// PostFilters value. Shared between ListPosts and ListDrafts. Malformed
+// dates, tag modes and facet names are rejected rather than ignored, since
+// dropping them would silently widen the result set.
+func parsePostFilters(c *gin.Context) (models.PostFilters, error) {
 	filters := models.PostFilters{
 		Search:    c.Query("search"),
 		Author:    c.Query("author"),
@@ -229,12 +320,40 @@ func parsePostFilters(c *gin.Context) models.PostFilters {
 		SortOrder: c.Query("sortOrder"),
 		Page:      parseIntQuery(c, "page", 1),
 		Limit:     parseIntQuery(c, "limit", 6),
+		Cursor:    c.Query("cursor"),
+		SkipCount: !parseBoolQuery(c, "count", true),
 		TagNames:  parseTagsQuery(c),
 	}
 
-	if categoryStr := c.Query("category_id"); categoryStr != "" {
-		if cid, err := strconv.Atoi(categoryStr); err == nil && cid > 0 {
-			filters.CategoryID = &cid
+	for _, v := range parseListQuery(c, "category_id") {
+		if cid, err := strconv.Atoi(v); err == nil && cid > 0 {
+			filters.CategoryIDs = append(filters.CategoryIDs, cid)
+		}
+	}
+
+	switch mode := strings.ToLower(c.Query("tag_mode")); mode {
+	case "", "any":
+	case "all":
+		filters.TagMatchAll = true
+	default:
+		return filters, fmt.Errorf("tag_mode must be any or all, got %q", mode)
+	}
+
+	var err error
+	if filters.DateFrom, err = parseDateQuery(c, "date_from", false); err != nil {
+		return filters, err
+	}
+	if filters.DateTo, err = parseDateQuery(c, "date_to", true); err != nil {
+		return filters, err
+	}
+
+	for _, name := range parseListQuery(c, "facets") {
+		name = strings.ToLower(name)
+		if !slices.Contains(models.PostFacetNames, name) {
+			return filters, fmt.Errorf("unknown facet %q", name)
+		}
+		if !slices.Contains(filters.Facets, name) {
+			filters.Facets = append(filters.Facets, name)
 		}
 	}
 
@@ -248,7 +367,28 @@ func parsePostFilters(c *gin.Context) models.PostFilters {
 		}
 	}
 
-	return filters
+	return filters, nil
+}
+
+// parseDateQuery reads a YYYY-MM-DD or RFC 3339 query parameter. For an
+// exclusive upper bound (end=true) a bare date is moved to the start of the
+// next day so the whole day is included.
+func parseDateQuery(c *gin.Context, key string, end bool) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, timezone.BRT)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be YYYY-MM-DD or RFC 3339, got %q", key, value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
 }
 
 // Similar handles GET /api/posts/:id/similar
@@ -323,6 +463,7 @@ func (h *PostHandler) MostViewed(c *gin.Context) {
 // @Success      200   {object}  dtos.SuccessResponse
 // @Failure      400   {object}  dtos.ErrorResponse
 // @Failure      404   {object}  dtos.ErrorResponse
+// @Failure      409   {object}  dtos.ErrorResponse
 // @Failure      500   {object}  dtos.ErrorResponse
 // @Router       /posts/{id} [put]
 func (h *PostHandler) UpdatePost(c *gin.Context) {
@@ -382,6 +523,26 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 			return
 		}
 
+		if containsStr(err.Error(), "chapter version mismatch") {
+			c.JSON(http.StatusConflict, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "CHAPTER_VERSION_MISMATCH",
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
+		if containsStr(err.Error(), "invalid chapter position") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INVALID_CHAPTER_POSITION",
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
 		if containsStr(err.Error(), "whitenest invariant") {
 			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
 				Error: dtos.ErrorDetail{
@@ -478,7 +639,13 @@ func (h *PostHandler) DeletePost(c *gin.Context) {
 // parseTagsQuery returns the tag-name filter values. Accepts repeated
 // `?tags=foo&tags=bar` plus a comma-joined `?tags=foo,bar` form for convenience.
 func parseTagsQuery(c *gin.Context) []string {
-	raw := c.QueryArray("tags")
+	return parseListQuery(c, "tags")
+}
+
+// parseListQuery reads a multi-valued query parameter given either repeated
+// or comma-joined, dropping empty entries.
+func parseListQuery(c *gin.Context, key string) []string {
+	raw := c.QueryArray(key)
 	out := make([]string, 0, len(raw))
 	for _, v := range raw {
 		for _, p := range strings.Split(v, ",") {
@@ -504,6 +671,37 @@ func parseIntQuery(c *gin.Context, key string, defaultValue int) int {
 	return intValue
 }
 
+// parseBoolQuery parses a boolean query parameter, falling back to
+// defaultValue when it is missing or malformed.
+func parseBoolQuery(c *gin.Context, key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(c.Query(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// parsePreview reads the `preview` query flag, which shows queued Whitenest
+// chapters and needs the editor token (X-Preview-Token). A preview without a
+// valid token is answered with 403 and ok is false.
+func parsePreview(c *gin.Context) (preview, ok bool) {
+	if !parseBoolQuery(c, "preview", false) {
+		return false, true
+	}
+	if !middleware.PreviewAllowed(c) {
+		c.JSON(http.StatusForbidden, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "PREVIEW_NOT_ALLOWED",
+				Message: "Previews require a valid " + middleware.PreviewTokenHeader + " header",
+			},
+		})
+		return false, false
+	}
+	// Unreleased content must not land in shared caches.
+	c.Header("Cache-Control", "private, no-store")
+	return true, true
+}
+
 // parseValidationErrors parses validation errors into a map
 func parseValidationErrors(err error) map[string][]string {
 	// Simple error message for now
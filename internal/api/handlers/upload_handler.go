@@ -1,13 +1,24 @@
 package handlers
 
 import (
+	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
 	"github.com/davidrdsilva/blog-api/internal/application/services"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
 	"github.com/gin-gonic/gin"
 )
 
+// multipartOverheadBytes is allowed on top of the file size limit for the
+// multipart boundaries, part headers and small form fields.
+const multipartOverheadBytes = 1 << 20
+
+// maxFormFieldBytes bounds the text form fields read ahead of the file.
+const maxFormFieldBytes = 1 << 10
+
 // UploadHandler handles file upload requests
 type UploadHandler struct {
 	service *services.UploadService
@@ -28,14 +39,35 @@ func NewUploadHandler(service *services.UploadService, logger *logging.Logger) *
 // @Tags         upload
 // @Accept       multipart/form-data
 // @Produce      json
-// @Param        file  formData  file  true  "Image or video file"
-// @Success      200   {object}  dtos.EditorJsUploadResponse
-// @Failure      500   {object}  dtos.EditorJsUploadResponse
+// @Param        file      formData  file    true   "Image or video file"
+// @Param        uploader  formData  string  false  "Name recorded as the uploader in the media library; must come before file"
+// @Param        uploader  query     string  false  "Alternative to the uploader form field"
+// @Success      200       {object}  dtos.EditorJsUploadResponse
+// @Failure      500       {object}  dtos.EditorJsUploadResponse
 // @Router       /upload [post]
 func (h *UploadHandler) UploadImage(c *gin.Context) {
-	// Get file from multipart form
-	file, header, err := c.Request.FormFile("file")
+	// Cap the request body so an oversized upload is cut off while it's still
+	// arriving. The slack covers the multipart framing and the other form
+	// fields.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.service.MaxUploadBytes()+multipartOverheadBytes)
+
+	// Read the form part by part so the file streams straight into storage
+	// instead of being parsed into memory or a temp file first.
+	uploader := c.Query("uploader")
+	file, err := nextFilePart(c.Request, &uploader)
 	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.logger.Warn("Upload request body too large", logging.F("limit_bytes", tooLarge.Limit))
+			c.JSON(http.StatusOK, dtos.EditorJsUploadResponse{
+				Success: 0,
+				Error: &dtos.EditorJsErrorDetail{
+					Code:    "FILE_TOO_LARGE",
+					Message: "File exceeds the maximum upload size",
+				},
+			})
+			return
+		}
 		h.logger.Warn("No file provided in upload request")
 		c.JSON(http.StatusOK, map[string]interface{}{
 			"success": 0,
@@ -48,20 +80,19 @@ func (h *UploadHandler) UploadImage(c *gin.Context) {
 	}
 	defer file.Close()
 
-	// Get content type
-	contentType := header.Header.Get("Content-Type")
+	// Client-declared type; storage sniffs the real one from the file.
+	contentType := file.Header.Get("Content-Type")
 	if contentType == "" {
 		contentType = "application/octet-stream"
 	}
 
 	h.logger.Info("Processing file upload",
-		logging.F("filename", header.Filename),
+		logging.F("filename", file.FileName()),
 		logging.F("content_type", contentType),
-		logging.F("size_bytes", header.Size),
 	)
 
 	// Upload file
-	response, err := h.service.UploadImage(file, header.Filename, contentType)
+	response, err := h.service.UploadImage(file, file.FileName(), contentType, uploader)
 	if err != nil {
 		h.logger.Error("Failed to upload image", logging.F("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, map[string]interface{}{
@@ -83,3 +114,189 @@ func (h *UploadHandler) UploadImage(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// nextFilePart advances req's multipart body to the "file" part. The file is
+// only streamed, so fields after it can't be read: an "uploader" field is
+// picked up only when it comes first, and doesn't override a non-empty
+// uploader.
+func nextFilePart(req *http.Request, uploader *string) (*multipart.Part, error) {
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		switch part.FormName() {
+		case "file":
+			return part, nil
+		case "uploader":
+			value, err := io.ReadAll(io.LimitReader(part, maxFormFieldBytes))
+			if err != nil {
+				return nil, err
+			}
+			if *uploader == "" {
+				*uploader = string(value)
+			}
+		}
+		part.Close()
+	}
+}
+
+// UploadImageByURL handles POST /api/upload/by-url
+//
+// @Summary      Upload a file from a remote URL
+// @Description  Fetches the URL server-side and stores the file as if it had
+// @Description  been uploaded, so posts don't hotlink third-party hosts.
+// @Description  Private, loopback and link-local destinations are refused,
+// @Description  including via redirects.
+// @Tags         upload
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dtos.EditorJsUploadByURLRequest  true  "Remote file URL"
+// @Success      200      {object}  dtos.EditorJsUploadResponse
+// @Failure      500      {object}  dtos.EditorJsUploadResponse
+// @Router       /upload/by-url [post]
+func (h *UploadHandler) UploadImageByURL(c *gin.Context) {
+	var req dtos.EditorJsUploadByURLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, dtos.EditorJsUploadResponse{
+			Success: 0,
+			Error: &dtos.EditorJsErrorDetail{
+				Code:    "INVALID_URL",
+				Message: "URL is missing or malformed",
+			},
+		})
+		return
+	}
+
+	h.logger.Info("Processing upload by URL", logging.F("url", req.URL))
+
+	response, err := h.service.UploadImageFromURL(c.Request.Context(), req.URL, req.Uploader)
+	if err != nil {
+		h.logger.Error("Failed to upload image by URL", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": 0,
+			"error": map[string]string{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to process upload",
+			},
+		})
+		return
+	}
+
+	if response.Success == 1 {
+		h.logger.Info("Image uploaded by URL successfully", logging.F("url", response.File.URL))
+	} else {
+		h.logger.Warn("Upload by URL failed", logging.F("error", response.Error.Code))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PresignUpload handles POST /api/upload/presign
+//
+// @Summary      Presign a direct-to-bucket video upload
+// @Description  Validates the declared video type and size and returns a signed
+// @Description  multipart form. POST the fields plus the file (field "file",
+// @Description  last) to the returned URL, then call /upload/complete with the
+// @Description  key. Images must use /upload so their metadata is stripped.
+// @Tags         upload
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dtos.PresignUploadRequest  true  "Declared file"
+// @Success      200      {object}  dtos.PresignUploadResponse
+// @Failure      400      {object}  dtos.ErrorResponse
+// @Failure      501      {object}  dtos.ErrorResponse
+// @Failure      500      {object}  dtos.ErrorResponse
+// @Router       /upload/presign [post]
+func (h *UploadHandler) PresignUpload(c *gin.Context) {
+	var req dtos.PresignUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "VALIDATION_ERROR", Message: err.Error()},
+		})
+		return
+	}
+
+	resp, err := h.service.PresignUpload(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case containsStr(err.Error(), "not supported"):
+			c.JSON(http.StatusNotImplemented, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "PRESIGN_UNSUPPORTED", Message: err.Error()},
+			})
+		case containsStr(err.Error(), "invalid file size"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "VALIDATION_ERROR", Message: err.Error()},
+			})
+		case containsStr(err.Error(), "file size"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "FILE_TOO_LARGE", Message: err.Error()},
+			})
+		case containsStr(err.Error(), "invalid file type"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_FILE_TYPE", Message: err.Error()},
+			})
+		default:
+			h.logger.Error("Failed to presign upload", logging.F("error", err.Error()))
+			c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to presign upload"},
+			})
+		}
+		return
+	}
+
+	h.logger.Info("Presigned direct upload", logging.F("key", resp.Key))
+	c.JSON(http.StatusOK, resp)
+}
+
+// CompleteUpload handles POST /api/upload/complete
+//
+// @Summary      Complete a direct-to-bucket upload
+// @Description  Verifies the uploaded object's size and sniffed type, moves it
+// @Description  to its content-addressed key and records it in the media
+// @Description  library. Rejected objects are deleted. Answers like /upload.
+// @Tags         upload
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dtos.CompleteUploadRequest  true  "Key returned by /upload/presign"
+// @Success      200      {object}  dtos.EditorJsUploadResponse
+// @Failure      500      {object}  dtos.EditorJsUploadResponse
+// @Router       /upload/complete [post]
+func (h *UploadHandler) CompleteUpload(c *gin.Context) {
+	var req dtos.CompleteUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, dtos.EditorJsUploadResponse{
+			Success: 0,
+			Error: &dtos.EditorJsErrorDetail{
+				Code:    "INVALID_UPLOAD_KEY",
+				Message: "Upload key is missing",
+			},
+		})
+		return
+	}
+
+	response, err := h.service.CompleteUpload(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to complete upload", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": 0,
+			"error": map[string]string{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to process upload",
+			},
+		})
+		return
+	}
+
+	if response.Success == 1 {
+		h.logger.Info("Direct upload completed", logging.F("url", response.File.URL))
+	} else {
+		h.logger.Warn("Direct upload rejected", logging.F("key", req.Key), logging.F("error", response.Error.Code))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -25,6 +25,7 @@ func NewURLHandler(service *services.URLService, logger *logging.Logger) *URLHan
 // FetchURLMetadata handles GET /api/fetch-url
 //
 // @Summary      Fetch URL metadata
+// @Description  Fetches the page server-side (private/loopback destinations are refused) and extracts its title, description and image. Results are cached for LINK_PREVIEW_CACHE_TTL_MINUTES.
 // @Tags         url
 // @Produce      json
 // @Param        url  query     string  true  "URL to fetch metadata from"
@@ -47,7 +48,7 @@ func (h *URLHandler) FetchURLMetadata(c *gin.Context) {
 
 	h.logger.Info("Fetching URL metadata", logging.F("url", url))
 
-	response, err := h.service.FetchURLMetadata(url)
+	response, err := h.service.FetchURLMetadata(c.Request.Context(), url)
 	if err != nil {
 		h.logger.Error("Failed to fetch URL metadata", logging.F("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, map[string]interface{}{
@@ -1,24 +1,36 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/davidrdsilva/blog-api/internal/api/middleware"
 	"github.com/davidrdsilva/blog-api/internal/application/dtos"
 	"github.com/davidrdsilva/blog-api/internal/application/services"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type WhitenestHandler struct {
-	service *services.WhitenestService
-	logger  *logging.Logger
+	service         *services.WhitenestService
+	progressService *services.ReaderProgressService
+	exportService   *services.WhitenestExportService
+	logger          *logging.Logger
 }
 
-func NewWhitenestHandler(service *services.WhitenestService, logger *logging.Logger) *WhitenestHandler {
+func NewWhitenestHandler(
+	service *services.WhitenestService,
+	progressService *services.ReaderProgressService,
+	exportService *services.WhitenestExportService,
+	logger *logging.Logger,
+) *WhitenestHandler {
 	return &WhitenestHandler{
-		service: service,
-		logger:  logger,
+		service:         service,
+		progressService: progressService,
+		exportService:   exportService,
+		logger:          logger,
 	}
 }
 
@@ -27,11 +39,23 @@ func NewWhitenestHandler(service *services.WhitenestService, logger *logging.Log
 // @Summary      Get a Whitenest chapter by number
 // @Description  Returns the chapter with the given serial number along with
 // @Description  minimal references to the previous and next chapters, if any.
+// @Description  Each cast member carries their state as of this chapter.
+// @Description  Opening a chapter records it in the reader's progress; a
+// @Description  reader cookie is issued when the request carries neither the
+// @Description  cookie nor an X-Reader-ID header.
+// @Description  Queued chapters are 404 except with `preview=true`, which
+// @Description  editors use to proofread them and which needs their
+// @Description  X-Preview-Token; a preview links queued neighbours and
+// @Description  records neither a view nor progress.
 // @Tags         whitenest
 // @Produce      json
-// @Param        number  path      int  true  "Chapter number (1-indexed)"
-// @Success      200     {object}  dtos.SuccessResponse{data=dtos.WhitenestChapterResponse}
+// @Param        number           path      int     true   "Chapter number (1-indexed)"
+// @Param        preview          query     bool    false  "Include queued chapters"
+// @Param        X-Preview-Token  header    string  false  "Editor token, required with preview=true"
+// @Param        X-Reader-ID      header    string  false  "Reader UUID, overrides the reader cookie"
+// @Success      200          {object}  dtos.SuccessResponse{data=dtos.WhitenestChapterResponse}
 // @Failure      400     {object}  dtos.ErrorResponse
+// @Failure      403     {object}  dtos.ErrorResponse
 // @Failure      404     {object}  dtos.ErrorResponse
 // @Failure      500     {object}  dtos.ErrorResponse
 // @Router       /whitenest/chapters/{number} [get]
@@ -48,7 +72,11 @@ func (h *WhitenestHandler) GetChapter(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.service.GetChapterByNumber(number)
+	preview, ok := parsePreview(c)
+	if !ok {
+		return
+	}
+	resp, err := h.service.GetChapterByNumber(number, preview)
 	if err != nil {
 		h.logger.Error("Failed to fetch Whitenest chapter",
 			logging.F("error", err.Error()),
@@ -73,6 +101,9 @@ func (h *WhitenestHandler) GetChapter(c *gin.Context) {
 		return
 	}
 
+	if !preview {
+		h.progressService.RecordOpen(middleware.EnsureReaderID(c), resp.Chapter.ID)
+	}
 	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
 }
 
@@ -82,8 +113,11 @@ func (h *WhitenestHandler) GetChapter(c *gin.Context) {
 // @Description  Accepts the full ordered list of (post_id, number) pairs and
 // @Description  rewrites chapter numbers atomically. The submitted set must
 // @Description  cover every existing chapter exactly once with contiguous
-// @Description  numbers 1..N. A mismatch (e.g. concurrent publish/unpublish)
-// @Description  returns 409 so the client can refresh and retry.
+// @Description  numbers 1..N, queued chapters included. A mismatch (e.g.
+// @Description  concurrent publish/unpublish) returns 409 so the client can
+// @Description  refresh and retry. Released chapters must all come before the
+// @Description  queued ones. Arcs keep their start chapter unless moved in the
+// @Description  optional `arcs` list.
 // @Tags         whitenest
 // @Accept       json
 // @Produce      json
@@ -116,6 +150,30 @@ func (h *WhitenestHandler) ReorderChapters(c *gin.Context) {
 				},
 			})
 			return
+		case containsStr(msg, "invalid arc start"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INVALID_ARC_START",
+					Message: msg,
+				},
+			})
+			return
+		case containsStr(msg, "schedule order violation"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INVALID_SCHEDULE_ORDER",
+					Message: msg,
+				},
+			})
+			return
+		case containsStr(msg, "unknown arc"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "ARC_NOT_FOUND",
+					Message: msg,
+				},
+			})
+			return
 		case containsStr(msg, "duplicate") || containsStr(msg, "must be contiguous") || containsStr(msg, "must not be empty"):
 			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
 				Error: dtos.ErrorDetail{
@@ -142,17 +200,34 @@ func (h *WhitenestHandler) ReorderChapters(c *gin.Context) {
 
 // ListChapters handles GET /api/whitenest/chapters
 //
-// @Summary      List all Whitenest chapters
+// @Summary      List all Whitenest chapters grouped by arc
 // @Description  Returns every Whitenest chapter ordered by chapter number ASC
 // @Description  with the lightweight fields needed for list views (id, title,
-// @Description  image, tags, chapter number).
+// @Description  image, tags, chapter number), grouped by arc. Chapters before
+// @Description  the first arc come first in a group whose arc is null.
+// @Description  The ETag header carries the chapter set's version, required by
+// @Description  the move endpoint and by whitenest_insert_at on posts.
+// @Description  When the request identifies a reader, each chapter carries
+// @Description  a `read` flag. Queued chapters are left out unless
+// @Description  `preview=true` (with the editor's X-Preview-Token), where
+// @Description  they carry their `release_at`; the version covers them
+// @Description  either way.
 // @Tags         whitenest
 // @Produce      json
-// @Success      200  {object}  dtos.SuccessResponse{data=[]dtos.WhitenestChapterSummary}
-// @Failure      500  {object}  dtos.ErrorResponse
+// @Param        preview          query     bool    false  "Include queued chapters"
+// @Param        X-Preview-Token  header    string  false  "Editor token, required with preview=true"
+// @Param        X-Reader-ID      header    string  false  "Reader UUID, overrides the reader cookie"
+// @Success      200          {object}  dtos.SuccessResponse{data=[]dtos.WhitenestArcGroup}
+// @Header       200          {string}  ETag  "Chapter set version"
+// @Failure      403          {object}  dtos.ErrorResponse
+// @Failure      500          {object}  dtos.ErrorResponse
 // @Router       /whitenest/chapters [get]
 func (h *WhitenestHandler) ListChapters(c *gin.Context) {
-	chapters, err := h.service.ListChapters()
+	preview, ok := parsePreview(c)
+	if !ok {
+		return
+	}
+	chapters, version, err := h.service.ListChapters(preview)
 	if err != nil {
 		h.logger.Error("Failed to list Whitenest chapters",
 			logging.F("error", err.Error()),
@@ -166,5 +241,624 @@ func (h *WhitenestHandler) ListChapters(c *gin.Context) {
 		return
 	}
 
+	if err := h.progressService.MarkRead(middleware.ReaderID(c), chapters); err != nil {
+		// The list is still useful without read markers.
+		h.logger.Warn("Failed to load reader progress for chapter list",
+			logging.F("error", err.Error()),
+		)
+	}
+
+	c.Header("ETag", strconv.Quote(version))
+	c.Writer.Header().Add("Vary", "Cookie, "+middleware.ReaderIDHeader)
 	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: chapters})
 }
+
+// SetCastState handles PUT /api/whitenest/chapters/:number/cast/:character_id
+//
+// @Summary      Set a cast member's state in a chapter
+// @Description  Replaces the character's per-chapter override of skills,
+// @Description  occupation and location. Omitted fields carry over from
+// @Description  earlier chapters; an empty body clears the override. Returns
+// @Description  the character's resulting state as of that chapter.
+// @Tags         whitenest
+// @Accept       json
+// @Produce      json
+// @Param        number        path      int                         true  "Chapter number"
+// @Param        character_id  path      string                      true  "Character UUID"
+// @Param        body          body      dtos.CharacterStateRequest  true  "State override"
+// @Success      200           {object}  dtos.SuccessResponse{data=dtos.CastMemberResponse}
+// @Failure      400           {object}  dtos.ErrorResponse
+// @Failure      404           {object}  dtos.ErrorResponse
+// @Failure      500           {object}  dtos.ErrorResponse
+// @Router       /whitenest/chapters/{number}/cast/{character_id} [put]
+func (h *WhitenestHandler) SetCastState(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "INVALID_CHAPTER_NUMBER",
+				Message: "Chapter number must be a positive integer",
+			},
+		})
+		return
+	}
+
+	var req dtos.CharacterStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "VALIDATION_ERROR",
+				Message: "Request validation failed",
+				Details: parseValidationErrors(err),
+			},
+		})
+		return
+	}
+
+	characterID := c.Param("character_id")
+	resp, err := h.service.SetCastState(number, characterID, req)
+	if err != nil {
+		msg := err.Error()
+		switch {
+		case containsStr(msg, "invalid UUID"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid character ID"},
+			})
+			return
+		case containsStr(msg, "invalid skills"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_SKILLS", Message: msg},
+			})
+			return
+		case containsStr(msg, "character not in chapter cast"):
+			c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "CAST_MEMBER_NOT_FOUND", Message: msg},
+			})
+			return
+		}
+		h.logger.Error("Failed to set cast state",
+			logging.F("error", msg),
+			logging.F("number", number),
+			logging.F("characterId", characterID),
+		)
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to set cast state"},
+		})
+		return
+	}
+
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "CHAPTER_NOT_FOUND",
+				Message: "No Whitenest chapter exists with that number",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// MoveChapter handles POST /api/whitenest/chapters/:number/move
+//
+// @Summary      Move a Whitenest chapter
+// @Description  Moves the chapter to number `to`, shifting the chapters in
+// @Description  between by one. `version` is the ETag of the chapter list; if
+// @Description  the chapter set changed since, the move is rejected with 409.
+// @Description  Arc boundaries follow the other chapters, so the moved chapter
+// @Description  joins the arc covering its new number. A released chapter
+// @Description  can't move after a queued one, nor a queued one before it.
+// @Tags         whitenest
+// @Accept       json
+// @Produce      json
+// @Param        number  path      int                       true  "Current chapter number"
+// @Param        body    body      dtos.MoveChapterRequest  true  "Target position and chapter set version"
+// @Success      200     {object}  dtos.SuccessResponse{data=dtos.MoveChapterResponse}
+// @Failure      400     {object}  dtos.ErrorResponse
+// @Failure      404     {object}  dtos.ErrorResponse
+// @Failure      409     {object}  dtos.ErrorResponse
+// @Failure      500     {object}  dtos.ErrorResponse
+// @Router       /whitenest/chapters/{number}/move [post]
+func (h *WhitenestHandler) MoveChapter(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "INVALID_CHAPTER_NUMBER",
+				Message: "Chapter number must be a positive integer",
+			},
+		})
+		return
+	}
+
+	var req dtos.MoveChapterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "VALIDATION_ERROR",
+				Message: "Request validation failed",
+				Details: parseValidationErrors(err),
+			},
+		})
+		return
+	}
+
+	resp, err := h.service.MoveChapter(number, req)
+	if err != nil {
+		msg := err.Error()
+		switch {
+		case containsStr(msg, "chapter version mismatch"):
+			c.JSON(http.StatusConflict, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "CHAPTER_VERSION_MISMATCH",
+					Message: msg,
+				},
+			})
+			return
+		case containsStr(msg, "invalid chapter position"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INVALID_CHAPTER_POSITION",
+					Message: msg,
+				},
+			})
+			return
+		case containsStr(msg, "schedule order violation"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{
+					Code:    "INVALID_SCHEDULE_ORDER",
+					Message: msg,
+				},
+			})
+			return
+		}
+		h.logger.Error("Failed to move Whitenest chapter",
+			logging.F("error", msg),
+			logging.F("number", number),
+		)
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to move chapter",
+			},
+		})
+		return
+	}
+
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "CHAPTER_NOT_FOUND",
+				Message: "No Whitenest chapter exists with that number",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// ListArcs handles GET /api/whitenest/arcs
+//
+// @Summary      List Whitenest arcs
+// @Description  Returns every arc in reading order with the chapter range it
+// @Description  currently covers. Queued chapters only count with
+// @Description  `preview=true` and the editor's X-Preview-Token.
+// @Tags         whitenest
+// @Produce      json
+// @Param        preview          query     bool    false  "Include queued chapters"
+// @Param        X-Preview-Token  header    string  false  "Editor token, required with preview=true"
+// @Success      200      {object}  dtos.SuccessResponse{data=[]dtos.WhitenestArcResponse}
+// @Failure      403      {object}  dtos.ErrorResponse
+// @Failure      500      {object}  dtos.ErrorResponse
+// @Router       /whitenest/arcs [get]
+func (h *WhitenestHandler) ListArcs(c *gin.Context) {
+	preview, ok := parsePreview(c)
+	if !ok {
+		return
+	}
+	arcs, err := h.service.ListArcs(preview)
+	if err != nil {
+		h.logger.Error("Failed to list Whitenest arcs", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to list arcs"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: arcs})
+}
+
+// GetSchedule handles GET /api/whitenest/schedule
+//
+// @Summary      List upcoming Whitenest chapters
+// @Description  Returns the queued chapters in release order with their
+// @Description  number, title, release time and arc. A chapter whose time has
+// @Description  passed stays listed until the scheduler releases it.
+// @Tags         whitenest
+// @Produce      json
+// @Success      200  {object}  dtos.SuccessResponse{data=[]dtos.ScheduledChapterResponse}
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /whitenest/schedule [get]
+func (h *WhitenestHandler) GetSchedule(c *gin.Context) {
+	schedule, err := h.service.GetSchedule()
+	if err != nil {
+		h.logger.Error("Failed to load Whitenest schedule", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to load schedule"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: schedule})
+}
+
+// CreateArc handles POST /api/whitenest/arcs
+//
+// @Summary      Create a Whitenest arc
+// @Description  The arc starts at start_chapter and runs until the next arc.
+// @Description  start_chapter may be one past the latest chapter to set up an
+// @Description  arc before its first chapter is published.
+// @Tags         whitenest
+// @Accept       json
+// @Produce      json
+// @Param        arc  body      dtos.CreateWhitenestArcRequest  true  "Arc payload"
+// @Success      201  {object}  dtos.SuccessResponse{data=dtos.WhitenestArcResponse}
+// @Failure      400  {object}  dtos.ErrorResponse
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /whitenest/arcs [post]
+func (h *WhitenestHandler) CreateArc(c *gin.Context) {
+	var req dtos.CreateWhitenestArcRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "VALIDATION_ERROR",
+				Message: "Request validation failed",
+				Details: parseValidationErrors(err),
+			},
+		})
+		return
+	}
+	resp, err := h.service.CreateArc(req)
+	if err != nil {
+		switch {
+		case containsStr(err.Error(), "invalid arc start"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ARC_START", Message: err.Error()},
+			})
+			return
+		case containsStr(err.Error(), "invalid image URL"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_IMAGE_URL", Message: err.Error()},
+			})
+			return
+		}
+		h.logger.Error("Failed to create Whitenest arc", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to create arc"},
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, dtos.SuccessResponse{Data: resp})
+}
+
+// UpdateArc handles PUT /api/whitenest/arcs/:id
+//
+// @Summary      Update a Whitenest arc
+// @Tags         whitenest
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string                          true  "Arc UUID"
+// @Param        arc  body      dtos.UpdateWhitenestArcRequest  true  "Patch payload"
+// @Success      200  {object}  dtos.SuccessResponse{data=dtos.WhitenestArcResponse}
+// @Failure      400  {object}  dtos.ErrorResponse
+// @Failure      404  {object}  dtos.ErrorResponse
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /whitenest/arcs/{id} [put]
+func (h *WhitenestHandler) UpdateArc(c *gin.Context) {
+	id := c.Param("id")
+	var req dtos.UpdateWhitenestArcRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "VALIDATION_ERROR",
+				Message: "Request validation failed",
+				Details: parseValidationErrors(err),
+			},
+		})
+		return
+	}
+	resp, err := h.service.UpdateArc(id, req)
+	if err != nil {
+		switch {
+		case containsStr(err.Error(), "invalid UUID"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid arc ID"},
+			})
+			return
+		case containsStr(err.Error(), "invalid arc start"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ARC_START", Message: err.Error()},
+			})
+			return
+		case containsStr(err.Error(), "invalid image URL"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_IMAGE_URL", Message: err.Error()},
+			})
+			return
+		}
+		h.logger.Error("Failed to update Whitenest arc", logging.F("error", err.Error()), logging.F("id", id))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to update arc"},
+		})
+		return
+	}
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "ARC_NOT_FOUND", Message: "Arc not found"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// DeleteArc handles DELETE /api/whitenest/arcs/:id
+//
+// @Summary      Delete a Whitenest arc
+// @Description  Chapters are kept; they join the preceding arc, or become
+// @Description  ungrouped if the deleted arc was the first.
+// @Tags         whitenest
+// @Param        id   path  string  true  "Arc UUID"
+// @Success      204
+// @Failure      400  {object}  dtos.ErrorResponse
+// @Failure      404  {object}  dtos.ErrorResponse
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /whitenest/arcs/{id} [delete]
+func (h *WhitenestHandler) DeleteArc(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.service.DeleteArc(id); err != nil {
+		switch {
+		case containsStr(err.Error(), "invalid UUID"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid arc ID"},
+			})
+			return
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "ARC_NOT_FOUND", Message: "Arc not found"},
+			})
+			return
+		}
+		h.logger.Error("Failed to delete Whitenest arc", logging.F("error", err.Error()), logging.F("id", id))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to delete arc"},
+		})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetProgress handles GET /api/whitenest/progress
+//
+// @Summary      Get the reader's Whitenest progress
+// @Description  Returns the chapters the reader has opened, which of them are
+// @Description  read, and where to continue: the chapter last touched, or the
+// @Description  next one once that is read. A reader without progress (or
+// @Description  without a reader cookie) continues from chapter 1.
+// @Tags         whitenest
+// @Produce      json
+// @Param        X-Reader-ID  header    string  false  "Reader UUID, overrides the reader cookie"
+// @Success      200          {object}  dtos.SuccessResponse{data=dtos.ReaderProgressResponse}
+// @Failure      500          {object}  dtos.ErrorResponse
+// @Router       /whitenest/progress [get]
+func (h *WhitenestHandler) GetProgress(c *gin.Context) {
+	resp, err := h.progressService.GetProgress(middleware.ReaderID(c))
+	if err != nil {
+		h.logger.Error("Failed to fetch reader progress",
+			logging.F("error", err.Error()),
+		)
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to fetch reading progress",
+			},
+		})
+		return
+	}
+
+	c.Writer.Header().Add("Vary", "Cookie, "+middleware.ReaderIDHeader)
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// SaveProgress handles PUT /api/whitenest/progress/:number
+//
+// @Summary      Save the reader's position in a chapter
+// @Description  Records the Editor.js block the reader is at and/or whether
+// @Description  the chapter is read. Omitted fields keep their stored value.
+// @Description  Issues a reader cookie when the request has no reader.
+// @Tags         whitenest
+// @Accept       json
+// @Produce      json
+// @Param        number       path      int                       true   "Chapter number"
+// @Param        X-Reader-ID  header    string                    false  "Reader UUID, overrides the reader cookie"
+// @Param        body         body      dtos.SaveProgressRequest  true   "Progress"
+// @Success      200          {object}  dtos.SuccessResponse{data=dtos.ChapterProgressResponse}
+// @Failure      400          {object}  dtos.ErrorResponse
+// @Failure      404          {object}  dtos.ErrorResponse
+// @Failure      500          {object}  dtos.ErrorResponse
+// @Router       /whitenest/progress/{number} [put]
+func (h *WhitenestHandler) SaveProgress(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "INVALID_CHAPTER_NUMBER",
+				Message: "Chapter number must be a positive integer",
+			},
+		})
+		return
+	}
+
+	var req dtos.SaveProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "VALIDATION_ERROR",
+				Message: "Request validation failed",
+				Details: parseValidationErrors(err),
+			},
+		})
+		return
+	}
+
+	resp, err := h.progressService.SaveProgress(middleware.EnsureReaderID(c), number, req)
+	if err != nil {
+		h.logger.Error("Failed to save reader progress",
+			logging.F("error", err.Error()),
+			logging.F("number", number),
+		)
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to save reading progress",
+			},
+		})
+		return
+	}
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{
+				Code:    "CHAPTER_NOT_FOUND",
+				Message: "No Whitenest chapter exists with that number",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
+
+// ExportEPUB handles GET /api/whitenest/export.epub
+//
+// @Summary      Export Whitenest as EPUB
+// @Description  Exports the selected chapters as an EPUB 3 book with a table
+// @Description  of contents, the first chapter's image as cover, embedded
+// @Description  images and a cast appendix. Select an arc with `arc_id` or a
+// @Description  range with `from`/`to` (either end optional); with neither,
+// @Description  the whole serial is exported. Books are built in the
+// @Description  background: when a file for the current content exists this
+// @Description  redirects to it, otherwise it returns 202 with the export job,
+// @Description  whose status URL is in the Location header.
+// @Tags         whitenest
+// @Produce      json
+// @Param        arc_id  query     string  false  "Arc UUID"
+// @Param        from    query     int     false  "First chapter number"
+// @Param        to      query     int     false  "Last chapter number"
+// @Success      202     {object}  dtos.SuccessResponse{data=dtos.WhitenestExportResponse}
+// @Header       202     {string}  Location  "Export status URL"
+// @Success      302     "Redirect to the EPUB file"
+// @Failure      400     {object}  dtos.ErrorResponse
+// @Failure      404     {object}  dtos.ErrorResponse
+// @Failure      500     {object}  dtos.ErrorResponse
+// @Failure      503     {object}  dtos.ErrorResponse
+// @Router       /whitenest/export.epub [get]
+func (h *WhitenestHandler) ExportEPUB(c *gin.Context) {
+	var req dtos.WhitenestExportRequest
+	if raw := c.Query("arc_id"); raw != "" {
+		req.ArcID = &raw
+	}
+	for _, param := range []struct {
+		name string
+		dst  **int
+	}{{"from", &req.From}, {"to", &req.To}} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_QUERY_PARAM", Message: param.name + " must be a positive integer"},
+			})
+			return
+		}
+		*param.dst = &n
+	}
+
+	resp, err := h.exportService.RequestExport(req)
+	if err != nil {
+		switch {
+		case containsStr(err.Error(), "invalid export selection"):
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_EXPORT_SELECTION", Message: err.Error()},
+			})
+			return
+		case containsStr(err.Error(), "export arc not found"):
+			c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "ARC_NOT_FOUND", Message: "Arc not found"},
+			})
+			return
+		case containsStr(err.Error(), "no chapters in export selection"):
+			c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "EMPTY_EXPORT", Message: "No chapters match the selection"},
+			})
+			return
+		case containsStr(err.Error(), "export queue full"):
+			c.Header("Retry-After", "30")
+			c.JSON(http.StatusServiceUnavailable, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "EXPORT_QUEUE_FULL", Message: "Too many exports in progress, try again later"},
+			})
+			return
+		}
+		h.logger.Error("Failed to request Whitenest export", logging.F("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to export chapters"},
+		})
+		return
+	}
+
+	if resp.DownloadURL != nil {
+		c.Redirect(http.StatusFound, *resp.DownloadURL)
+		return
+	}
+	c.Header("Location", "/api/whitenest/exports/"+resp.ID)
+	c.Header("Retry-After", "5")
+	c.JSON(http.StatusAccepted, dtos.SuccessResponse{Data: resp})
+}
+
+// GetExport handles GET /api/whitenest/exports/:id
+//
+// @Summary      Get a Whitenest export job
+// @Description  Returns the export's status: pending, running, ready (with
+// @Description  download_url) or failed (with error).
+// @Tags         whitenest
+// @Produce      json
+// @Param        id   path      string  true  "Export UUID"
+// @Success      200  {object}  dtos.SuccessResponse{data=dtos.WhitenestExportResponse}
+// @Failure      400  {object}  dtos.ErrorResponse
+// @Failure      404  {object}  dtos.ErrorResponse
+// @Failure      500  {object}  dtos.ErrorResponse
+// @Router       /whitenest/exports/{id} [get]
+func (h *WhitenestHandler) GetExport(c *gin.Context) {
+	id := c.Param("id")
+	resp, err := h.exportService.GetExport(id)
+	if err != nil {
+		if containsStr(err.Error(), "invalid UUID") {
+			c.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error: dtos.ErrorDetail{Code: "INVALID_ID", Message: "Invalid export ID"},
+			})
+			return
+		}
+		h.logger.Error("Failed to fetch Whitenest export", logging.F("error", err.Error()), logging.F("id", id))
+		c.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "INTERNAL_ERROR", Message: "Failed to fetch export"},
+		})
+		return
+	}
+	if resp == nil {
+		c.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error: dtos.ErrorDetail{Code: "EXPORT_NOT_FOUND", Message: "Export not found"},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dtos.SuccessResponse{Data: resp})
+}
@@ -10,23 +10,30 @@ func CORS(allowedOrigins []string) gin.HandlerFunc {
 		origin := c.Request.Header.Get("Origin")
 
 		// Check if origin is allowed
-		allowed := false
+		allowed, listed := false, false
 		for _, allowedOrigin := range allowedOrigins {
 			if origin == allowedOrigin || allowedOrigin == "*" {
 				allowed = true
+				listed = origin == allowedOrigin
 				break
 			}
 		}
 
 		if allowed {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			c.Writer.Header().Add("Vary", "Origin")
+			if listed {
+				// The Whitenest reader cookie only travels on credentialed
+				// requests; a wildcard entry doesn't extend to those.
+				c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
 		} else if len(allowedOrigins) > 0 {
 			// Default to first origin if not matched
 			c.Writer.Header().Set("Access-Control-Allow-Origin", allowedOrigins[0])
 		}
 
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, "+ReaderIDHeader+", "+PreviewTokenHeader)
 		c.Writer.Header().Set("Access-Control-Max-Age", "86400")
 
 		// Handle preflight requests
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewTokenHeader carries the editor token that unlocks `preview=true`,
+// under which queued Whitenest chapters are shown before their release.
+const PreviewTokenHeader = "X-Preview-Token"
+
+const previewAllowedKey = "previewAllowed"
+
+// Preview records whether the request carries the configured editor token.
+// An empty token disables previews. Handlers that honour `preview=true` check
+// the result with PreviewAllowed.
+func Preview(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got := c.GetHeader(PreviewTokenHeader)
+		if token != "" && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+			c.Set(previewAllowedKey, true)
+		}
+		c.Next()
+	}
+}
+
+// PreviewAllowed reports whether Preview accepted the request's editor token.
+func PreviewAllowed(c *gin.Context) bool {
+	return c.GetBool(previewAllowedKey)
+}
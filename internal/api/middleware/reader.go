@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/davidrdsilva/blog-api/config"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReaderIDHeader lets a frontend that knows who its reader is (e.g. one with
+// its own login) pass a stable ID instead of relying on the cookie.
+const ReaderIDHeader = "X-Reader-ID"
+
+const (
+	readerIDKey     = "readerID"
+	readerConfigKey = "readerConfig"
+)
+
+// Reader resolves the Whitenest reader for the request from the X-Reader-ID
+// header, falling back to the reader cookie. Values that aren't UUIDs are
+// ignored. Nothing is issued here; handlers that record progress call
+// EnsureReaderID.
+func Reader(cfg config.ReaderConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(readerConfigKey, cfg)
+		id := c.GetHeader(ReaderIDHeader)
+		if _, err := uuid.Parse(id); err != nil {
+			id = ""
+			if cookie, err := c.Cookie(cfg.CookieName); err == nil {
+				if _, err := uuid.Parse(cookie); err == nil {
+					id = cookie
+				}
+			}
+		}
+		if id != "" {
+			c.Set(readerIDKey, id)
+		}
+		c.Next()
+	}
+}
+
+// ReaderID returns the reader resolved by Reader, or "" for an unknown reader.
+func ReaderID(c *gin.Context) string {
+	return c.GetString(readerIDKey)
+}
+
+// EnsureReaderID returns the request's reader, issuing a new reader cookie
+// first when there is none.
+func EnsureReaderID(c *gin.Context) string {
+	if id := ReaderID(c); id != "" {
+		return id
+	}
+	value, ok := c.Get(readerConfigKey)
+	if !ok {
+		return ""
+	}
+	cfg := value.(config.ReaderConfig)
+
+	id := uuid.NewString()
+	sameSite := http.SameSiteLaxMode
+	if cfg.CookieSecure {
+		// Cross-origin frontends only get the cookie back with SameSite=None,
+		// which browsers accept only on secure cookies.
+		sameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     cfg.CookieName,
+		Value:    id,
+		Path:     "/",
+		MaxAge:   cfg.CookieMaxAgeDays * 24 * 60 * 60,
+		Secure:   cfg.CookieSecure,
+		HttpOnly: true,
+		SameSite: sameSite,
+	})
+	c.Set(readerIDKey, id)
+	return id
+}
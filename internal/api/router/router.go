@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/davidrdsilva/blog-api/config"
 	"github.com/davidrdsilva/blog-api/internal/api/handlers"
 	"github.com/davidrdsilva/blog-api/internal/api/middleware"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
@@ -19,8 +20,12 @@ func SetupRouter(
 	tagHandler *handlers.TagHandler,
 	whitenestHandler *handlers.WhitenestHandler,
 	characterHandler *handlers.CharacterHandler,
+	mediaHandler *handlers.MediaHandler,
+	linkHandler *handlers.LinkHandler,
 	logger *logging.Logger,
 	corsOrigins []string,
+	readerCfg config.ReaderConfig,
+	previewToken string,
 ) *gin.Engine {
 	// Set Gin to release mode
 	gin.SetMode(gin.ReleaseMode)
@@ -32,6 +37,8 @@ func SetupRouter(
 	r.Use(middleware.ErrorHandler(logger))
 	r.Use(middleware.Logger(logger))
 	r.Use(middleware.CORS(corsOrigins))
+	r.Use(middleware.Reader(readerCfg))
+	r.Use(middleware.Preview(previewToken))
 
 	// API routes
 	api := r.Group("/api")
@@ -59,10 +66,19 @@ func SetupRouter(
 
 		// Character endpoints (used by Whitenest chapter cast)
 		api.GET("/characters", characterHandler.ListCharacters)
+		api.GET("/characters/graph", characterHandler.CharacterGraph)
 		api.GET("/characters/:id", characterHandler.GetCharacter)
+		api.GET("/characters/:id/appearances", characterHandler.GetAppearances)
+		api.GET("/characters/:id/progression", characterHandler.GetProgression)
 		api.POST("/characters", characterHandler.CreateCharacter)
 		api.PUT("/characters/:id", characterHandler.UpdateCharacter)
 		api.DELETE("/characters/:id", characterHandler.DeleteCharacter)
+		api.POST("/characters/:id/merge", characterHandler.MergeCharacter)
+		api.GET("/character-relationships", characterHandler.ListRelationships)
+		api.GET("/character-relationships/:id", characterHandler.GetRelationship)
+		api.POST("/character-relationships", characterHandler.CreateRelationship)
+		api.PUT("/character-relationships/:id", characterHandler.UpdateRelationship)
+		api.DELETE("/character-relationships/:id", characterHandler.DeleteRelationship)
 
 		// Whitenest serial-fiction endpoints. The /order route is mounted before
 		// the :number route so the literal segment isn't shadowed by the
@@ -70,12 +86,37 @@ func SetupRouter(
 		api.GET("/whitenest/chapters", whitenestHandler.ListChapters)
 		api.PUT("/whitenest/chapters/order", whitenestHandler.ReorderChapters)
 		api.GET("/whitenest/chapters/:number", whitenestHandler.GetChapter)
+		api.POST("/whitenest/chapters/:number/move", whitenestHandler.MoveChapter)
+		api.PUT("/whitenest/chapters/:number/cast/:character_id", whitenestHandler.SetCastState)
+		api.GET("/whitenest/cast-matrix", characterHandler.CastMatrix)
+		api.GET("/whitenest/schedule", whitenestHandler.GetSchedule)
+		api.GET("/whitenest/progress", whitenestHandler.GetProgress)
+		api.PUT("/whitenest/progress/:number", whitenestHandler.SaveProgress)
+		api.GET("/whitenest/export.epub", whitenestHandler.ExportEPUB)
+		api.GET("/whitenest/exports/:id", whitenestHandler.GetExport)
+		api.GET("/whitenest/arcs", whitenestHandler.ListArcs)
+		api.POST("/whitenest/arcs", whitenestHandler.CreateArc)
+		api.PUT("/whitenest/arcs/:id", whitenestHandler.UpdateArc)
+		api.DELETE("/whitenest/arcs/:id", whitenestHandler.DeleteArc)
 
 		// Upload endpoint
 		api.POST("/upload", uploadHandler.UploadImage)
+		api.POST("/upload/by-url", uploadHandler.UploadImageByURL)
+		api.POST("/upload/presign", uploadHandler.PresignUpload)
+		api.POST("/upload/complete", uploadHandler.CompleteUpload)
+
+		// Media library endpoints
+		api.GET("/media", mediaHandler.ListMedia)
+		api.GET("/media/stats", mediaHandler.GetMediaStats)
+		api.GET("/media/gc", mediaHandler.MediaGCReport)
+		api.DELETE("/media/:id", mediaHandler.DeleteMedia)
 
 		// URL metadata endpoint
 		api.GET("/fetch-url", urlHandler.FetchURLMetadata)
+
+		// Outbound link monitoring
+		api.GET("/links/broken", linkHandler.BrokenLinks)
+		api.POST("/links/check", linkHandler.CheckLinks)
 	}
 
 	// Health check endpoint
@@ -1,6 +1,10 @@
 package dtos
 
-import "github.com/davidrdsilva/blog-api/internal/domain/models"
+import (
+	"strings"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
 
 type CharacterSkillsRequest struct {
 	Melee      int `json:"melee" binding:"min=0,max=100"`
@@ -23,38 +27,168 @@ func (s CharacterSkillsRequest) ToModel() models.CharacterSkills {
 }
 
 type CreateCharacterRequest struct {
-	FullName    string                 `json:"full_name" binding:"required,min=1,max=120"`
-	ShortName   string                 `json:"short_name" binding:"required,min=1,max=60"`
-	Description string                 `json:"description" binding:"required,min=1"`
-	Occupation  string                 `json:"occupation" binding:"required,min=1,max=120"`
-	Location    string                 `json:"location" binding:"required,min=1,max=160"`
-	Portrait    string                 `json:"portrait" binding:"required,url"`
-	Skills      CharacterSkillsRequest `json:"skills" binding:"required"`
+	FullName    string                    `json:"full_name" binding:"required,min=1,max=120"`
+	ShortName   string                    `json:"short_name" binding:"required,min=1,max=60"`
+	Description string                    `json:"description" binding:"required,min=1"`
+	Occupation  string                    `json:"occupation" binding:"required,min=1,max=120"`
+	Location    string                    `json:"location" binding:"required,min=1,max=160"`
+	Portrait    string                    `json:"portrait" binding:"required,url"`
+	Skills      CharacterSkillsRequest    `json:"skills" binding:"required"`
+	Sections    []CharacterSectionRequest `json:"sections" binding:"omitempty,dive"`
 }
 
+// UpdateCharacterRequest patches a character; sections, when present,
+// replaces the whole list.
 type UpdateCharacterRequest struct {
-	FullName    *string                 `json:"full_name" binding:"omitempty,min=1,max=120"`
-	ShortName   *string                 `json:"short_name" binding:"omitempty,min=1,max=60"`
-	Description *string                 `json:"description" binding:"omitempty,min=1"`
-	Occupation  *string                 `json:"occupation" binding:"omitempty,min=1,max=120"`
-	Location    *string                 `json:"location" binding:"omitempty,min=1,max=160"`
-	Portrait    *string                 `json:"portrait" binding:"omitempty,url"`
-	Skills      *CharacterSkillsRequest `json:"skills"`
+	FullName    *string                    `json:"full_name" binding:"omitempty,min=1,max=120"`
+	ShortName   *string                    `json:"short_name" binding:"omitempty,min=1,max=60"`
+	Description *string                    `json:"description" binding:"omitempty,min=1"`
+	Occupation  *string                    `json:"occupation" binding:"omitempty,min=1,max=120"`
+	Location    *string                    `json:"location" binding:"omitempty,min=1,max=160"`
+	Portrait    *string                    `json:"portrait" binding:"omitempty,url"`
+	Skills      *CharacterSkillsRequest    `json:"skills"`
+	Sections    *[]CharacterSectionRequest `json:"sections" binding:"omitempty,dive"`
+}
+
+// CharacterSectionRequest is a spoiler-gated part of a character's
+// description, shown once the reader reaches chapter revealed_at.
+type CharacterSectionRequest struct {
+	Title      string `json:"title" binding:"omitempty,max=120"`
+	Body       string `json:"body" binding:"required,min=1"`
+	RevealedAt int    `json:"revealed_at" binding:"required,min=1"`
+}
+
+func (r CharacterSectionRequest) ToModel() models.CharacterSection {
+	return models.CharacterSection{
+		Title:      strings.TrimSpace(r.Title),
+		Body:       strings.TrimSpace(r.Body),
+		RevealedAt: r.RevealedAt,
+	}
 }
 
 type CharacterResponse struct {
-	ID          string                 `json:"id"`
-	FullName    string                 `json:"full_name"`
-	ShortName   string                 `json:"short_name"`
-	Description string                 `json:"description"`
-	Occupation  string                 `json:"occupation"`
-	Location    string                 `json:"location"`
-	Portrait    string                 `json:"portrait"`
-	Skills      models.CharacterSkills `json:"skills"`
-	CreatedAt   string                 `json:"createdAt"`
-	UpdatedAt   string                 `json:"updatedAt"`
+	ID          string                    `json:"id"`
+	FullName    string                    `json:"full_name"`
+	ShortName   string                    `json:"short_name"`
+	Description string                    `json:"description"`
+	Occupation  string                    `json:"occupation"`
+	Location    string                    `json:"location"`
+	Portrait    string                    `json:"portrait"`
+	Skills      models.CharacterSkills    `json:"skills"`
+	Sections    []models.CharacterSection `json:"sections"`
+	CreatedAt   string                    `json:"createdAt"`
+	UpdatedAt   string                    `json:"updatedAt"`
+	// AsOfChapter is set when the response was gated to a reader's position:
+	// sections, skills, occupation and location are as of that chapter.
+	AsOfChapter *int `json:"as_of_chapter,omitempty"`
+	// Appearances is only set on list responses.
+	Appearances *CharacterAppearanceSummary `json:"appearances,omitempty"`
+}
+
+// CharacterAppearanceSummary counts the Whitenest chapters a character is
+// cast in. The chapter numbers are null when the count is 0.
+type CharacterAppearanceSummary struct {
+	Count        int  `json:"count"`
+	FirstChapter *int `json:"first_chapter"`
+	LastChapter  *int `json:"last_chapter"`
+}
+
+// CharacterAppearancesResponse is the chapter timeline of one character.
+type CharacterAppearancesResponse struct {
+	CharacterID  string                    `json:"character_id"`
+	Count        int                       `json:"count"`
+	FirstChapter *WhitenestChapterRef      `json:"first_chapter"`
+	LastChapter  *WhitenestChapterRef      `json:"last_chapter"`
+	Chapters     []CharacterAppearanceItem `json:"chapters"`
+}
+
+// CharacterAppearanceItem is one chapter in a character's timeline. Position
+// is the character's place in that chapter's cast (0-based).
+type CharacterAppearanceItem struct {
+	ID                     string `json:"id"`
+	Title                  string `json:"title"`
+	WhitenestChapterNumber int    `json:"whitenest_chapter_number"`
+	Position               int    `json:"position"`
+}
+
+// CastMatrixResponse is the characters × chapters presence grid. Chapters
+// are the columns in order; each row lists the chapter numbers the character
+// is cast in.
+type CastMatrixResponse struct {
+	Chapters   []WhitenestChapterRef `json:"chapters"`
+	Characters []CastMatrixRow       `json:"characters"`
+}
+
+// CastMatrixRow is one character in the cast matrix.
+type CastMatrixRow struct {
+	ID          string                     `json:"id"`
+	ShortName   string                     `json:"short_name"`
+	Portrait    string                     `json:"portrait"`
+	Appearances CharacterAppearanceSummary `json:"appearances"`
+	Chapters    []int                      `json:"chapters"`
 }
 
 type CharacterListResponse struct {
 	Data []CharacterResponse `json:"data"`
 }
+
+// CharacterStateRequest is the body of
+// PUT /api/whitenest/chapters/:number/cast/:character_id. It replaces the
+// character's override for that chapter: omitted fields carry over from
+// earlier chapters, and an empty body clears the override.
+type CharacterStateRequest struct {
+	Skills     *CharacterSkillsRequest `json:"skills"`
+	Occupation *string                 `json:"occupation" binding:"omitempty,min=1,max=120"`
+	Location   *string                 `json:"location" binding:"omitempty,min=1,max=160"`
+}
+
+// CharacterStateResponse is a character as of a chapter. The *_since fields
+// are the chapter that last changed each value, null while it is still the
+// character's base value.
+type CharacterStateResponse struct {
+	Skills          models.CharacterSkills `json:"skills"`
+	Occupation      string                 `json:"occupation"`
+	Location        string                 `json:"location"`
+	SkillsSince     *int                   `json:"skills_since"`
+	OccupationSince *int                   `json:"occupation_since"`
+	LocationSince   *int                   `json:"location_since"`
+}
+
+// CastMemberResponse is a character in a chapter's cast with their state as
+// of that chapter.
+type CastMemberResponse struct {
+	CharacterResponse
+	State CharacterStateResponse `json:"state"`
+}
+
+// CharacterProgressionResponse is a character's state at every chapter they
+// appear in, for skill charts. Base is the state before any override.
+type CharacterProgressionResponse struct {
+	CharacterID string                     `json:"character_id"`
+	Base        CharacterStateResponse     `json:"base"`
+	Points      []CharacterProgressionItem `json:"points"`
+}
+
+// CharacterProgressionItem is the character's state in one chapter.
+type CharacterProgressionItem struct {
+	ID                     string                 `json:"id"`
+	Title                  string                 `json:"title"`
+	WhitenestChapterNumber int                    `json:"whitenest_chapter_number"`
+	State                  CharacterStateResponse `json:"state"`
+}
+
+// MergeCharacterRequest is the body of POST /api/characters/:id/merge; the
+// path character is the canonical one that is kept.
+type MergeCharacterRequest struct {
+	DuplicateID string `json:"duplicate_id" binding:"required,uuid"`
+}
+
+// CharacterMergeResponse is the canonical character after a merge, with
+// counts of what moved over from the duplicate.
+type CharacterMergeResponse struct {
+	Character            CharacterResponse `json:"character"`
+	CastMoved            int               `json:"cast_moved"`
+	CastMerged           int               `json:"cast_merged"`
+	RelationshipsMoved   int               `json:"relationships_moved"`
+	RelationshipsDropped int               `json:"relationships_dropped"`
+}
@@ -0,0 +1,63 @@
+package dtos
+
+// CreateRelationshipRequest is the body of POST /api/character-relationships.
+type CreateRelationshipRequest struct {
+	SourceID     string  `json:"source_id" binding:"required,uuid"`
+	TargetID     string  `json:"target_id" binding:"required,uuid"`
+	Type         string  `json:"type" binding:"required"`
+	Directed     bool    `json:"directed"`
+	Description  *string `json:"description"`
+	SinceChapter *int    `json:"since_chapter" binding:"omitempty,min=1"`
+}
+
+// UpdateRelationshipRequest patches a relationship. since_chapter 0 clears
+// the marker (the relationship then holds from the start).
+type UpdateRelationshipRequest struct {
+	SourceID     *string `json:"source_id" binding:"omitempty,uuid"`
+	TargetID     *string `json:"target_id" binding:"omitempty,uuid"`
+	Type         *string `json:"type"`
+	Directed     *bool   `json:"directed"`
+	Description  *string `json:"description"`
+	SinceChapter *int    `json:"since_chapter" binding:"omitempty,min=0"`
+}
+
+type RelationshipResponse struct {
+	ID           string  `json:"id"`
+	SourceID     string  `json:"source_id"`
+	TargetID     string  `json:"target_id"`
+	Type         string  `json:"type"`
+	Directed     bool    `json:"directed"`
+	Description  *string `json:"description"`
+	SinceChapter *int    `json:"since_chapter"`
+	CreatedAt    string  `json:"createdAt"`
+	UpdatedAt    string  `json:"updatedAt"`
+}
+
+// CharacterGraphResponse is the relationship graph in the node/link shape
+// force-directed layouts (d3-force and friends) consume directly: link
+// source and target are node IDs.
+type CharacterGraphResponse struct {
+	Nodes       []CharacterGraphNode `json:"nodes"`
+	Links       []CharacterGraphLink `json:"links"`
+	AsOfChapter *int                 `json:"as_of_chapter,omitempty"`
+}
+
+// CharacterGraphNode is a character. Appearances can drive node size.
+type CharacterGraphNode struct {
+	ID          string `json:"id"`
+	Label       string `json:"label"`
+	FullName    string `json:"full_name"`
+	Portrait    string `json:"portrait"`
+	Appearances int    `json:"appearances"`
+}
+
+// CharacterGraphLink is a relationship between two nodes.
+type CharacterGraphLink struct {
+	ID           string  `json:"id"`
+	Source       string  `json:"source"`
+	Target       string  `json:"target"`
+	Type         string  `json:"type"`
+	Directed     bool    `json:"directed"`
+	Description  *string `json:"description"`
+	SinceChapter *int    `json:"since_chapter"`
+}
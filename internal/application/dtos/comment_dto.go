@@ -1,5 +1,7 @@
 package dtos
 
+import "github.com/davidrdsilva/blog-api/internal/domain/models"
+
 type CommentResponse struct {
 	ID        string `json:"id"`
 	PostID    string `json:"postId"`
@@ -9,7 +11,8 @@ type CommentResponse struct {
 }
 
 type CommentListResponse struct {
-	Data []CommentResponse `json:"data"`
+	Data []CommentResponse     `json:"data"`
+	Meta models.PaginationMeta `json:"meta"`
 }
 
 type CreateCommentRequest struct {
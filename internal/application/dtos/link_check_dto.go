@@ -0,0 +1,35 @@
+package dtos
+
+// LinkCheckResult is the latest check of one outbound link
+type LinkCheckResult struct {
+	URL        string  `json:"url"`
+	Source     string  `json:"source"`
+	StatusCode *int    `json:"status_code,omitempty"`
+	FinalURL   *string `json:"final_url,omitempty"`
+	Redirects  int     `json:"redirects"`
+	Error      *string `json:"error,omitempty"`
+	CheckedAt  string  `json:"checked_at"`
+}
+
+// BrokenLinksPost lists the broken links of one post
+type BrokenLinksPost struct {
+	PostID string            `json:"post_id"`
+	Title  string            `json:"title"`
+	Links  []LinkCheckResult `json:"links"`
+}
+
+// BrokenLinksReport represents the response for GET /api/links/broken
+type BrokenLinksReport struct {
+	Data       []BrokenLinksPost `json:"data"`
+	TotalPosts int               `json:"total_posts"`
+	TotalLinks int               `json:"total_links"`
+}
+
+// LinkCheckSummary summarises one link checker sweep
+type LinkCheckSummary struct {
+	StartedAt string `json:"started_at"`
+	Posts     int    `json:"posts"`
+	Links     int    `json:"links"`
+	Checked   int    `json:"checked_urls"`
+	Broken    int    `json:"broken"`
+}
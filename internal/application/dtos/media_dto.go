@@ -0,0 +1,67 @@
+package dtos
+
+import "github.com/davidrdsilva/blog-api/internal/domain/models"
+
+// MediaResponse represents a single media library entry in API responses
+type MediaResponse struct {
+	ID         string  `json:"id"`
+	Key        string  `json:"key"`
+	URL        string  `json:"url"`
+	MimeType   string  `json:"mime_type"`
+	Size       int64   `json:"size"`
+	Width      *int    `json:"width,omitempty"`
+	Height     *int    `json:"height,omitempty"`
+	SHA256     string  `json:"sha256"`
+	Uploader   *string `json:"uploader,omitempty"`
+	CapturedAt *string `json:"captured_at,omitempty"`
+	// Video only.
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+	VideoCodec      *string  `json:"video_codec,omitempty"`
+	AudioCodec      *string  `json:"audio_codec,omitempty"`
+	// UploadCount is how many uploads resolved to this object, including the
+	// first; anything above 1 was deduplicated.
+	UploadCount int    `json:"upload_count"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// MediaStatsResponse reports storage use and deduplication savings across the
+// media library.
+type MediaStatsResponse struct {
+	Objects    int64 `json:"objects"`
+	TotalBytes int64 `json:"total_bytes"`
+	Uploads    int64 `json:"uploads"`
+	DedupHits  int64 `json:"dedup_hits"`
+	BytesSaved int64 `json:"bytes_saved"`
+}
+
+// MediaListResponse represents a paginated list of media entries
+type MediaListResponse struct {
+	Data []MediaResponse       `json:"data"`
+	Meta models.PaginationMeta `json:"meta"`
+}
+
+// MediaGCAction is what the garbage collector did (or, in a dry run, would do)
+// with one object. Action is one of:
+//   - "pending": unreferenced but still inside the grace period
+//   - "quarantine": unreferenced past the grace period, moved to quarantine
+//   - "restore": quarantined but referenced again, moved back
+//   - "delete": quarantined past the quarantine period, deleted
+type MediaGCAction struct {
+	Key          string `json:"key"`
+	URL          string `json:"url"`
+	Action       string `json:"action"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"last_modified"`
+	Error        string `json:"error,omitempty"`
+}
+
+// MediaGCReport summarises one garbage-collector sweep.
+type MediaGCReport struct {
+	DryRun           bool            `json:"dry_run"`
+	StartedAt        string          `json:"started_at"`
+	ScannedObjects   int             `json:"scanned_objects"`
+	ReferencedURLs   int             `json:"referenced_urls"`
+	QuarantinedBytes int64           `json:"quarantined_bytes"`
+	DeletedBytes     int64           `json:"deleted_bytes"`
+	Actions          []MediaGCAction `json:"actions"`
+}
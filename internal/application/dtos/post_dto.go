@@ -19,6 +19,15 @@ type CreatePostRequest struct {
 	Tags                   []string                `json:"tags" binding:"omitempty,dive,min=1,max=60"`
 	WhitenestChapterNumber *int                    `json:"whitenest_chapter_number,omitempty" binding:"omitempty,min=1"`
 	CharacterIDs           *[]string               `json:"character_ids,omitempty" binding:"omitempty,dive,uuid"`
+	// WhitenestInsertAt places the new chapter at that number instead of
+	// appending it, shifting later chapters up. Requires WhitenestVersion,
+	// the ETag of GET /api/whitenest/chapters.
+	WhitenestInsertAt *int    `json:"whitenest_insert_at,omitempty" binding:"omitempty,min=1"`
+	WhitenestVersion  *string `json:"whitenest_version,omitempty" binding:"required_with=WhitenestInsertAt"`
+	// ReleaseAt queues a Whitenest chapter for release at that time. It is
+	// numbered into the upcoming slots in release order and hidden from
+	// readers until the scheduler releases it.
+	ReleaseAt *time.Time `json:"release_at,omitempty" binding:"omitempty"`
 }
 
 // UpdatePostRequest represents the request body for updating a post
@@ -33,6 +42,15 @@ type UpdatePostRequest struct {
 	Tags                   *[]string               `json:"tags" binding:"omitempty,dive,min=1,max=60"`
 	WhitenestChapterNumber *int                    `json:"whitenest_chapter_number,omitempty" binding:"omitempty,min=1"`
 	CharacterIDs           *[]string               `json:"character_ids,omitempty" binding:"omitempty,dive,uuid"`
+	// WhitenestInsertAt places a post joining Whitenest at that number instead of
+	// appending it, shifting later chapters up. Requires WhitenestVersion,
+	// the ETag of GET /api/whitenest/chapters.
+	WhitenestInsertAt *int    `json:"whitenest_insert_at,omitempty" binding:"omitempty,min=1"`
+	WhitenestVersion  *string `json:"whitenest_version,omitempty" binding:"required_with=WhitenestInsertAt"`
+	// ReleaseAt queues a post joining Whitenest, or reschedules a queued
+	// chapter, which then moves to its slot in release order. Released
+	// chapters can't be queued again.
+	ReleaseAt *time.Time `json:"release_at,omitempty" binding:"omitempty"`
 }
 
 // PostResponse represents a single post in API responses
@@ -51,6 +69,7 @@ type PostResponse struct {
 	Characters             []CharacterResponse     `json:"characters"`
 	TotalViews             int                     `json:"total_views"`
 	WhitenestChapterNumber *int                    `json:"whitenest_chapter_number,omitempty"`
+	ReleaseAt              *string                 `json:"release_at,omitempty"`
 	CreatedAt              string                  `json:"createdAt"`
 	UpdatedAt              string                  `json:"updatedAt"`
 }
@@ -69,21 +88,52 @@ type WhitenestChapterSummary struct {
 	Image                  string        `json:"image"`
 	Tags                   []TagResponse `json:"tags"`
 	WhitenestChapterNumber int           `json:"whitenest_chapter_number"`
+	// Read is only set when the request identifies a reader.
+	Read *bool `json:"read,omitempty"`
+	// ReleaseAt is only set on queued chapters, which only previews list.
+	ReleaseAt *string `json:"release_at,omitempty"`
+}
+
+// ScheduledChapterResponse is a queued chapter in GET /api/whitenest/schedule.
+// Arc is null when the chapter comes before the first arc.
+type ScheduledChapterResponse struct {
+	WhitenestChapterNumber int              `json:"whitenest_chapter_number"`
+	Title                  string           `json:"title"`
+	ReleaseAt              string           `json:"release_at"`
+	Arc                    *WhitenestArcRef `json:"arc"`
 }
 
 type WhitenestChapterResponse struct {
 	Chapter  PostResponse         `json:"chapter"`
 	Previous *WhitenestChapterRef `json:"previous"`
 	Next     *WhitenestChapterRef `json:"next"`
-	Cast     []CharacterResponse  `json:"cast"`
+	Cast     []CastMemberResponse `json:"cast"`
+	// Arc is null when the chapter comes before the first arc.
+	Arc *WhitenestArcRef `json:"arc"`
 }
 
 // ReorderChaptersRequest is the body of PUT /api/whitenest/chapters/order.
 // `order` must list every existing Whitenest chapter exactly once with numbers
 // 1..N. The service rejects partial submissions so the resulting state is
-// always a valid contiguous numbering.
+// always a valid contiguous numbering. `arcs` optionally moves arc
+// boundaries in the same transaction; arcs not listed keep their start.
 type ReorderChaptersRequest struct {
 	Order []ChapterOrderItem `json:"order" binding:"required,min=1,dive"`
+	Arcs  []ArcBoundaryItem  `json:"arcs,omitempty" binding:"omitempty,dive"`
+}
+
+// MoveChapterRequest is the body of POST /api/whitenest/chapters/:number/move.
+// Version is the ETag of GET /api/whitenest/chapters; the move is rejected if
+// the chapter set changed since.
+type MoveChapterRequest struct {
+	To      int    `json:"to" binding:"required,min=1"`
+	Version string `json:"version" binding:"required"`
+}
+
+// MoveChapterResponse is the moved chapter and the chapter set's new version.
+type MoveChapterResponse struct {
+	Chapter WhitenestChapterRef `json:"chapter"`
+	Version string              `json:"version"`
 }
 
 // ChapterOrderItem is one (post_id, number) assignment in a reorder request.
@@ -96,6 +146,17 @@ type ChapterOrderItem struct {
 type PostListResponse struct {
 	Data []PostResponse        `json:"data"`
 	Meta models.PaginationMeta `json:"meta"`
+	// Facets is only present when the request asked for facets, keyed by
+	// facet name (category, tag, author, year).
+	Facets map[string][]FacetBucket `json:"facets,omitempty"`
+}
+
+// FacetBucket is one facet value and how many posts match it. Value is what
+// the matching filter parameter accepts; label is the category name.
+type FacetBucket struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
 }
 
 // SuccessResponse is a generic success response wrapper
@@ -122,9 +183,32 @@ type EditorJsUploadResponse struct {
 	Error   *EditorJsErrorDetail `json:"error,omitempty"`
 }
 
-// EditorJsFileInfo contains uploaded file information
+// EditorJsFileInfo contains uploaded file information. MediaID is the media
+// library entry recorded for the upload. Width and Height are set for images
+// and videos; CapturedAt only for images, from the photo's EXIF before it is
+// stripped from the stored file. When the same bytes were already stored, Deduplicated
+// is true, URL is the existing object's, and BytesSaved is the size that was
+// not written again.
 type EditorJsFileInfo struct {
-	URL string `json:"url"`
+	MediaID      string  `json:"media_id,omitempty"`
+	URL          string  `json:"url"`
+	Width        int     `json:"width,omitempty"`
+	Height       int     `json:"height,omitempty"`
+	CapturedAt   *string `json:"captured_at,omitempty"`
+	Deduplicated bool    `json:"deduplicated,omitempty"`
+	BytesSaved   int64   `json:"bytes_saved,omitempty"`
+	// Video only: container duration and codec identifiers. Width/Height
+	// carry the video resolution.
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+	VideoCodec      string   `json:"video_codec,omitempty"`
+	AudioCodec      string   `json:"audio_codec,omitempty"`
+}
+
+// EditorJsUploadByURLRequest is the body the Editor.js Image Tool sends to its
+// uploadByUrl endpoint.
+type EditorJsUploadByURLRequest struct {
+	URL      string `json:"url" binding:"required"`
+	Uploader string `json:"uploader,omitempty"`
 }
 
 // EditorJsErrorDetail contains error information for Editor.js
@@ -143,9 +227,29 @@ type EditorJsURLResponse struct {
 
 // URLMetadata contains metadata extracted from a URL
 type URLMetadata struct {
-	Title       string        `json:"title,omitempty"`
-	Description string        `json:"description,omitempty"`
-	Image       *URLImageInfo `json:"image,omitempty"`
+	Title         string        `json:"title,omitempty"`
+	Description   string        `json:"description,omitempty"`
+	Image         *URLImageInfo `json:"image,omitempty"`
+	SiteName      string        `json:"site_name,omitempty"`
+	Favicon       string        `json:"favicon,omitempty"`
+	CanonicalURL  string        `json:"canonical_url,omitempty"`
+	Author        string        `json:"author,omitempty"`
+	PublishedTime string        `json:"published_time,omitempty"`
+	Embed         *URLEmbedInfo `json:"embed,omitempty"`
+}
+
+// URLEmbedInfo carries oEmbed data shaped like the Editor.js Embed tool's
+// block data (service, source, embed, width, height). HTML is a sandboxed
+// iframe of Embed built by the API; the provider's own markup is never
+// returned.
+type URLEmbedInfo struct {
+	Type    string `json:"type"`
+	Service string `json:"service,omitempty"`
+	Source  string `json:"source"`
+	Embed   string `json:"embed,omitempty"`
+	HTML    string `json:"html,omitempty"`
+	Width   int    `json:"width,omitempty"`
+	Height  int    `json:"height,omitempty"`
 }
 
 // URLImageInfo contains image URL information
@@ -0,0 +1,38 @@
+package dtos
+
+// SaveProgressRequest is the body of PUT /api/whitenest/progress/:number.
+// Omitted fields leave the stored value unchanged; an empty block_id clears
+// the position.
+type SaveProgressRequest struct {
+	BlockID *string `json:"block_id" binding:"omitempty,max=64"`
+	Read    *bool   `json:"read"`
+}
+
+// ChapterProgressResponse is a reader's progress on one chapter.
+type ChapterProgressResponse struct {
+	Chapter   WhitenestChapterRef `json:"chapter"`
+	Read      bool                `json:"read"`
+	ReadAt    *string             `json:"read_at"`
+	BlockID   *string             `json:"block_id"`
+	UpdatedAt string              `json:"updated_at"`
+}
+
+// ContinueReading is where the reader should pick up: the chapter they last
+// touched, or the one after it once that is read. BlockID is only set when
+// resuming mid-chapter.
+type ContinueReading struct {
+	Chapter WhitenestChapterRef `json:"chapter"`
+	BlockID *string             `json:"block_id"`
+}
+
+// ReaderProgressResponse is the body of GET /api/whitenest/progress.
+// Continue is null when there are no chapters, or when the reader has read
+// the latest one (CaughtUp).
+type ReaderProgressResponse struct {
+	ReaderID      *string                   `json:"reader_id"`
+	Continue      *ContinueReading          `json:"continue"`
+	CaughtUp      bool                      `json:"caught_up"`
+	ReadCount     int                       `json:"read_count"`
+	TotalChapters int                       `json:"total_chapters"`
+	Chapters      []ChapterProgressResponse `json:"chapters"`
+}
@@ -0,0 +1,26 @@
+package dtos
+
+// PresignUploadRequest declares the file a client wants to upload directly to
+// the bucket.
+type PresignUploadRequest struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type" binding:"required"`
+	Size        int64  `json:"size" binding:"required"`
+}
+
+// PresignUploadResponse is a signed multipart form. The client POSTs Fields
+// followed by the file (field name "file", last) to URL, then calls
+// POST /api/upload/complete with Key.
+type PresignUploadResponse struct {
+	Key       string            `json:"key"`
+	URL       string            `json:"url"`
+	Fields    map[string]string `json:"fields"`
+	MaxSize   int64             `json:"max_size"`
+	ExpiresAt string            `json:"expires_at"`
+}
+
+// CompleteUploadRequest finishes a direct upload.
+type CompleteUploadRequest struct {
+	Key      string `json:"key" binding:"required"`
+	Uploader string `json:"uploader,omitempty"`
+}
@@ -0,0 +1,53 @@
+package dtos
+
+// CreateWhitenestArcRequest is the body of POST /api/whitenest/arcs.
+type CreateWhitenestArcRequest struct {
+	Title        string  `json:"title" binding:"required,min=1,max=200"`
+	Synopsis     *string `json:"synopsis"`
+	Image        *string `json:"image" binding:"omitempty,url"`
+	StartChapter int     `json:"start_chapter" binding:"required,min=1"`
+}
+
+// UpdateWhitenestArcRequest is the body of PUT /api/whitenest/arcs/:id. Only
+// supplied fields change.
+type UpdateWhitenestArcRequest struct {
+	Title        *string `json:"title" binding:"omitempty,min=1,max=200"`
+	Synopsis     *string `json:"synopsis"`
+	Image        *string `json:"image" binding:"omitempty,url"`
+	StartChapter *int    `json:"start_chapter" binding:"omitempty,min=1"`
+}
+
+// WhitenestArcResponse is an arc with the chapter range it currently covers.
+// EndChapter is nil while the arc has no chapters.
+type WhitenestArcResponse struct {
+	ID           string  `json:"id"`
+	Number       int     `json:"number"`
+	Title        string  `json:"title"`
+	Synopsis     *string `json:"synopsis"`
+	Image        *string `json:"image"`
+	StartChapter int     `json:"start_chapter"`
+	EndChapter   *int    `json:"end_chapter"`
+	ChapterCount int     `json:"chapter_count"`
+	CreatedAt    string  `json:"createdAt"`
+	UpdatedAt    string  `json:"updatedAt"`
+}
+
+// WhitenestArcRef is the minimal arc reference attached to a chapter.
+type WhitenestArcRef struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// WhitenestArcGroup is one arc and its chapters in GET /api/whitenest/chapters.
+// Arc is null for chapters that come before the first arc.
+type WhitenestArcGroup struct {
+	Arc      *WhitenestArcResponse     `json:"arc"`
+	Chapters []WhitenestChapterSummary `json:"chapters"`
+}
+
+// ArcBoundaryItem moves an arc's first chapter as part of a reorder.
+type ArcBoundaryItem struct {
+	ArcID        string `json:"arc_id" binding:"required,uuid"`
+	StartChapter int    `json:"start_chapter" binding:"required,min=1"`
+}
@@ -0,0 +1,27 @@
+package dtos
+
+// WhitenestExportRequest selects the chapters of an EPUB export, parsed from
+// the query of GET /api/whitenest/export.epub. ArcID and the From/To range
+// are mutually exclusive; with neither, every chapter is exported. An open
+// end of the range runs to the first or latest chapter.
+type WhitenestExportRequest struct {
+	ArcID *string
+	From  *int
+	To    *int
+}
+
+// WhitenestExportResponse is the state of an export job. DownloadURL is set
+// once the status is "ready"; Error once it is "failed".
+type WhitenestExportResponse struct {
+	ID           string  `json:"id"`
+	Status       string  `json:"status"`
+	ArcID        *string `json:"arc_id"`
+	FromChapter  int     `json:"from_chapter"`
+	ToChapter    int     `json:"to_chapter"`
+	ChapterCount int     `json:"chapter_count"`
+	Size         int64   `json:"size"`
+	DownloadURL  *string `json:"download_url"`
+	Error        *string `json:"error"`
+	CreatedAt    string  `json:"createdAt"`
+	CompletedAt  *string `json:"completedAt"`
+}
@@ -0,0 +1,7 @@
+package jobs
+
+// BuildWhitenestExportJob carries the ID of a pending Whitenest export for
+// the export worker to build.
+type BuildWhitenestExportJob struct {
+	ExportID string
+}
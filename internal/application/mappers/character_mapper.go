@@ -5,6 +5,7 @@ import (
 
 	"github.com/davidrdsilva/blog-api/internal/application/dtos"
 	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/timezone"
 )
 
 func ToCharacterResponse(c *models.Character) dtos.CharacterResponse {
@@ -17,11 +18,28 @@ func ToCharacterResponse(c *models.Character) dtos.CharacterResponse {
 		Location:    c.Location,
 		Portrait:    c.Portrait,
 		Skills:      c.Skills,
-		CreatedAt:   c.CreatedAt.In(brt).Format(time.RFC3339),
-		UpdatedAt:   c.UpdatedAt.In(brt).Format(time.RFC3339),
+		Sections:    sectionsOrEmpty(c.Sections),
+		CreatedAt:   c.CreatedAt.In(timezone.BRT).Format(time.RFC3339),
+		UpdatedAt:   c.UpdatedAt.In(timezone.BRT).Format(time.RFC3339),
 	}
 }
 
+// ToCharacterResponseAsOf gates the response to a reader who has reached
+// chapter asOf: only revealed sections, and the state as of that chapter.
+// asOf 0 is the ungated response.
+func ToCharacterResponseAsOf(c *models.Character, state models.CharacterState, asOf int) dtos.CharacterResponse {
+	resp := ToCharacterResponse(c)
+	if asOf == 0 {
+		return resp
+	}
+	resp.Sections = sectionsOrEmpty(c.Sections.RevealedBy(asOf))
+	resp.Skills = state.Skills
+	resp.Occupation = state.Occupation
+	resp.Location = state.Location
+	resp.AsOfChapter = &asOf
+	return resp
+}
+
 func ToCharacterResponses(characters []models.Character) []dtos.CharacterResponse {
 	out := make([]dtos.CharacterResponse, len(characters))
 	for i := range characters {
@@ -29,3 +47,121 @@ func ToCharacterResponses(characters []models.Character) []dtos.CharacterRespons
 	}
 	return out
 }
+
+func sectionsOrEmpty(sections models.CharacterSections) []models.CharacterSection {
+	if sections == nil {
+		return []models.CharacterSection{}
+	}
+	return sections
+}
+
+func ToCharacterAppearanceSummary(stats models.CharacterAppearanceStats) dtos.CharacterAppearanceSummary {
+	out := dtos.CharacterAppearanceSummary{Count: stats.Count}
+	if stats.Count > 0 {
+		first, last := stats.FirstChapter, stats.LastChapter
+		out.FirstChapter = &first
+		out.LastChapter = &last
+	}
+	return out
+}
+
+func ToCharacterAppearancesResponse(characterID string, rows []models.CharacterAppearance) dtos.CharacterAppearancesResponse {
+	items := make([]dtos.CharacterAppearanceItem, len(rows))
+	for i, row := range rows {
+		items[i] = dtos.CharacterAppearanceItem{
+			ID:                     row.PostID,
+			Title:                  row.Title,
+			WhitenestChapterNumber: row.ChapterNumber,
+			Position:               row.Position,
+		}
+	}
+	resp := dtos.CharacterAppearancesResponse{
+		CharacterID: characterID,
+		Count:       len(rows),
+		Chapters:    items,
+	}
+	if len(rows) > 0 {
+		resp.FirstChapter = appearanceRef(rows[0])
+		resp.LastChapter = appearanceRef(rows[len(rows)-1])
+	}
+	return resp
+}
+
+func appearanceRef(row models.CharacterAppearance) *dtos.WhitenestChapterRef {
+	return &dtos.WhitenestChapterRef{
+		ID:                     row.PostID,
+		Title:                  row.Title,
+		WhitenestChapterNumber: row.ChapterNumber,
+	}
+}
+
+func ToCharacterStateResponse(state models.CharacterState) dtos.CharacterStateResponse {
+	return dtos.CharacterStateResponse{
+		Skills:          state.Skills,
+		Occupation:      state.Occupation,
+		Location:        state.Location,
+		SkillsSince:     chapterOrNil(state.SkillsSince),
+		OccupationSince: chapterOrNil(state.OccupationSince),
+		LocationSince:   chapterOrNil(state.LocationSince),
+	}
+}
+
+// ToCastMemberResponse maps a cast member of chapter number; sections are
+// gated to that chapter.
+func ToCastMemberResponse(c *models.Character, state models.CharacterState, number int) dtos.CastMemberResponse {
+	resp := ToCharacterResponse(c)
+	resp.Sections = sectionsOrEmpty(c.Sections.RevealedBy(number))
+	return dtos.CastMemberResponse{
+		CharacterResponse: resp,
+		State:             ToCharacterStateResponse(state),
+	}
+}
+
+// chapterOrNil maps the "no chapter" zero value to null.
+func chapterOrNil(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+func ToRelationshipResponse(r *models.CharacterRelationship) dtos.RelationshipResponse {
+	return dtos.RelationshipResponse{
+		ID:           r.ID,
+		SourceID:     r.SourceID,
+		TargetID:     r.TargetID,
+		Type:         r.Type,
+		Directed:     r.Directed,
+		Description:  r.Description,
+		SinceChapter: r.SinceChapter,
+		CreatedAt:    r.CreatedAt.In(timezone.BRT).Format(time.RFC3339),
+		UpdatedAt:    r.UpdatedAt.In(timezone.BRT).Format(time.RFC3339),
+	}
+}
+
+func ToCharacterGraphLink(r *models.CharacterRelationship) dtos.CharacterGraphLink {
+	return dtos.CharacterGraphLink{
+		ID:           r.ID,
+		Source:       r.SourceID,
+		Target:       r.TargetID,
+		Type:         r.Type,
+		Directed:     r.Directed,
+		Description:  r.Description,
+		SinceChapter: r.SinceChapter,
+	}
+}
+
+// ToCharacterMergeResponse converts the canonical character after a merge,
+// with its appearance summary, and the merge counts.
+func ToCharacterMergeResponse(c *models.Character, stats models.CharacterAppearanceStats, result *models.CharacterMergeResult) dtos.CharacterMergeResponse {
+	character := ToCharacterResponse(c)
+	summary := ToCharacterAppearanceSummary(stats)
+	character.Appearances = &summary
+	return dtos.CharacterMergeResponse{
+		Character:            character,
+		CastMoved:            result.CastMoved,
+		CastMerged:           result.CastMerged,
+		RelationshipsMoved:   result.RelationshipsMoved,
+		RelationshipsDropped: result.RelationshipsDropped,
+	}
+}
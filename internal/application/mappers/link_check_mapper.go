@@ -0,0 +1,41 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/timezone"
+)
+
+// ToLinkCheckResult converts a stored link check to its response DTO
+func ToLinkCheckResult(c models.LinkCheck) dtos.LinkCheckResult {
+	return dtos.LinkCheckResult{
+		URL:        c.URL,
+		Source:     c.Source,
+		StatusCode: c.StatusCode,
+		FinalURL:   c.FinalURL,
+		Redirects:  c.Redirects,
+		Error:      c.Error,
+		CheckedAt:  c.CheckedAt.In(timezone.BRT).Format(time.RFC3339),
+	}
+}
+
+// ToBrokenLinksReport converts the per-post broken links to the report DTO
+func ToBrokenLinksReport(posts []models.PostBrokenLinks) dtos.BrokenLinksReport {
+	report := dtos.BrokenLinksReport{Data: make([]dtos.BrokenLinksPost, 0, len(posts))}
+	for _, p := range posts {
+		entry := dtos.BrokenLinksPost{
+			PostID: p.PostID,
+			Title:  p.Title,
+			Links:  make([]dtos.LinkCheckResult, 0, len(p.Links)),
+		}
+		for _, l := range p.Links {
+			entry.Links = append(entry.Links, ToLinkCheckResult(l))
+		}
+		report.Data = append(report.Data, entry)
+		report.TotalLinks += len(p.Links)
+	}
+	report.TotalPosts = len(report.Data)
+	return report
+}
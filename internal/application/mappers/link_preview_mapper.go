@@ -0,0 +1,37 @@
+package mappers
+
+import (
+	"strings"
+
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
+
+// ToURLMetadata converts extracted link metadata to the Link Tool's meta DTO.
+// source is the link itself, echoed in the embed block.
+func ToURLMetadata(m models.LinkMetadata, source string) *dtos.URLMetadata {
+	meta := &dtos.URLMetadata{
+		Title:         m.Title,
+		Description:   m.Description,
+		SiteName:      m.SiteName,
+		Favicon:       m.FaviconURL,
+		CanonicalURL:  m.CanonicalURL,
+		Author:        m.Author,
+		PublishedTime: m.PublishedTime,
+	}
+	if m.ImageURL != "" {
+		meta.Image = &dtos.URLImageInfo{URL: m.ImageURL}
+	}
+	if m.Embed != nil {
+		meta.Embed = &dtos.URLEmbedInfo{
+			Type:    m.Embed.Type,
+			Service: strings.ToLower(m.Embed.Provider),
+			Source:  source,
+			Embed:   m.Embed.URL,
+			HTML:    m.Embed.HTML,
+			Width:   m.Embed.Width,
+			Height:  m.Embed.Height,
+		}
+	}
+	return meta
+}
@@ -0,0 +1,61 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/timezone"
+)
+
+// ToMediaResponse converts a domain Media row to its response DTO
+func ToMediaResponse(m *models.Media) dtos.MediaResponse {
+	var capturedAt *string
+	if m.CapturedAt != nil {
+		// Capture dates are camera-local; format them as stored rather than
+		// shifting them into BRT.
+		v := m.CapturedAt.Format(time.RFC3339)
+		capturedAt = &v
+	}
+
+	return dtos.MediaResponse{
+		ID:              m.ID,
+		Key:             m.Key,
+		URL:             m.URL,
+		MimeType:        m.MimeType,
+		Size:            m.Size,
+		Width:           m.Width,
+		Height:          m.Height,
+		SHA256:          m.SHA256,
+		Uploader:        m.Uploader,
+		CapturedAt:      capturedAt,
+		DurationSeconds: m.DurationSeconds,
+		VideoCodec:      m.VideoCodec,
+		AudioCodec:      m.AudioCodec,
+		UploadCount:     m.UploadCount,
+		CreatedAt:       m.CreatedAt.In(timezone.BRT).Format(time.RFC3339),
+	}
+}
+
+// ToMediaStatsResponse converts aggregated media stats to the response DTO
+func ToMediaStatsResponse(s *models.MediaStats) dtos.MediaStatsResponse {
+	return dtos.MediaStatsResponse{
+		Objects:    s.Objects,
+		TotalBytes: s.TotalBytes,
+		Uploads:    s.Uploads,
+		DedupHits:  s.DedupHits,
+		BytesSaved: s.BytesSaved,
+	}
+}
+
+// ToMediaListResponse converts a page of media rows to the list DTO
+func ToMediaListResponse(rows []*models.Media, meta *models.PaginationMeta) dtos.MediaListResponse {
+	out := make([]dtos.MediaResponse, len(rows))
+	for i, m := range rows {
+		out[i] = ToMediaResponse(m)
+	}
+	return dtos.MediaListResponse{
+		Data: out,
+		Meta: *meta,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/davidrdsilva/blog-api/internal/application/dtos"
 	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/timezone"
 )
 
 func ToPostResponse(post *models.Post) dtos.PostResponse {
@@ -20,6 +21,18 @@ func ToPostResponse(post *models.Post) dtos.PostResponse {
 	}
 
 	characters := ToCharacterResponses(post.Characters)
+	if post.WhitenestChapterNumber != nil {
+		// A chapter's cast must not spoil what comes after it.
+		for i := range characters {
+			characters[i].Sections = sectionsOrEmpty(post.Characters[i].Sections.RevealedBy(*post.WhitenestChapterNumber))
+		}
+	}
+
+	var releaseAt *string
+	if post.ReleaseAt != nil {
+		t := post.ReleaseAt.In(timezone.BRT).Format(time.RFC3339)
+		releaseAt = &t
+	}
 
 	return dtos.PostResponse{
 		ID:                     post.ID,
@@ -27,7 +40,7 @@ func ToPostResponse(post *models.Post) dtos.PostResponse {
 		Subtitle:               post.Subtitle,
 		Description:            post.Description,
 		Image:                  post.Image,
-		Date:                   post.Date.In(brt).Format(time.RFC3339),
+		Date:                   post.Date.In(timezone.BRT).Format(time.RFC3339),
 		Author:                 post.Author,
 		Content:                post.Content,
 		CategoryID:             post.CategoryID,
@@ -36,8 +49,9 @@ func ToPostResponse(post *models.Post) dtos.PostResponse {
 		Characters:             characters,
 		TotalViews:             post.TotalViews,
 		WhitenestChapterNumber: post.WhitenestChapterNumber,
-		CreatedAt:              post.CreatedAt.In(brt).Format(time.RFC3339),
-		UpdatedAt:              post.UpdatedAt.In(brt).Format(time.RFC3339),
+		ReleaseAt:              releaseAt,
+		CreatedAt:              post.CreatedAt.In(timezone.BRT).Format(time.RFC3339),
+		UpdatedAt:              post.UpdatedAt.In(timezone.BRT).Format(time.RFC3339),
 	}
 }
 
@@ -62,17 +76,53 @@ func ToWhitenestChapterSummaries(posts []*models.Post) []dtos.WhitenestChapterSu
 		if tags == nil {
 			tags = []dtos.TagResponse{}
 		}
-		out = append(out, dtos.WhitenestChapterSummary{
+		summary := dtos.WhitenestChapterSummary{
 			ID:                     p.ID,
 			Title:                  p.Title,
 			Image:                  p.Image,
 			Tags:                   tags,
 			WhitenestChapterNumber: *p.WhitenestChapterNumber,
-		})
+		}
+		if p.ReleaseAt != nil {
+			t := p.ReleaseAt.In(timezone.BRT).Format(time.RFC3339)
+			summary.ReleaseAt = &t
+		}
+		out = append(out, summary)
 	}
 	return out
 }
 
+// ToScheduledChapterResponse converts a queued chapter to its schedule entry.
+// arc is the arc the chapter falls in, or nil.
+func ToScheduledChapterResponse(post *models.Post, arc *dtos.WhitenestArcRef) dtos.ScheduledChapterResponse {
+	return dtos.ScheduledChapterResponse{
+		WhitenestChapterNumber: *post.WhitenestChapterNumber,
+		Title:                  post.Title,
+		ReleaseAt:              post.ReleaseAt.In(timezone.BRT).Format(time.RFC3339),
+		Arc:                    arc,
+	}
+}
+
+// ToWhitenestArcResponse converts an arc to its DTO. number is the arc's
+// 1-based position in reading order; chapters are the chapters it covers.
+func ToWhitenestArcResponse(arc *models.WhitenestArc, number int, chapters []*models.Post) dtos.WhitenestArcResponse {
+	resp := dtos.WhitenestArcResponse{
+		ID:           arc.ID,
+		Number:       number,
+		Title:        arc.Title,
+		Synopsis:     arc.Synopsis,
+		Image:        arc.Image,
+		StartChapter: arc.StartChapter,
+		ChapterCount: len(chapters),
+		CreatedAt:    arc.CreatedAt.In(timezone.BRT).Format(time.RFC3339),
+		UpdatedAt:    arc.UpdatedAt.In(timezone.BRT).Format(time.RFC3339),
+	}
+	if len(chapters) > 0 {
+		resp.EndChapter = chapters[len(chapters)-1].WhitenestChapterNumber
+	}
+	return resp
+}
+
 // ToPostListResponse converts a slice of Posts to a PostListResponse
 func ToPostListResponse(posts []*models.Post, meta *models.PaginationMeta) dtos.PostListResponse {
 	responses := make([]dtos.PostResponse, len(posts))
@@ -86,6 +136,19 @@ func ToPostListResponse(posts []*models.Post, meta *models.PaginationMeta) dtos.
 	}
 }
 
+// ToFacetResponse converts computed post facets to their DTO
+func ToFacetResponse(facets models.PostFacets) map[string][]dtos.FacetBucket {
+	out := make(map[string][]dtos.FacetBucket, len(facets))
+	for name, buckets := range facets {
+		rows := make([]dtos.FacetBucket, len(buckets))
+		for i, b := range buckets {
+			rows[i] = dtos.FacetBucket{Value: b.Value, Label: b.Label, Count: b.Count}
+		}
+		out[name] = rows
+	}
+	return out
+}
+
 // CreatePostRequestToPost converts a CreatePostRequest to a domain Post
 func CreatePostRequestToPost(req dtos.CreatePostRequest) *models.Post {
 	postDate := time.Now()
@@ -104,6 +167,7 @@ func CreatePostRequestToPost(req dtos.CreatePostRequest) *models.Post {
 		UpdatedAt:              postDate,
 		CategoryID:             req.CategoryID,
 		WhitenestChapterNumber: req.WhitenestChapterNumber,
+		ReleaseAt:              req.ReleaseAt,
 	}
 }
 
@@ -132,4 +196,7 @@ func UpdatePostRequestToPost(post *models.Post, req dtos.UpdatePostRequest) {
 	if req.WhitenestChapterNumber != nil {
 		post.WhitenestChapterNumber = req.WhitenestChapterNumber
 	}
+	if req.ReleaseAt != nil {
+		post.ReleaseAt = req.ReleaseAt
+	}
 }
@@ -0,0 +1,29 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/timezone"
+)
+
+// ToChapterProgressResponse converts a progress row; chapter is the post it
+// belongs to.
+func ToChapterProgressResponse(p models.ChapterProgress, chapter *models.Post) dtos.ChapterProgressResponse {
+	resp := dtos.ChapterProgressResponse{
+		Chapter: dtos.WhitenestChapterRef{
+			ID:                     p.PostID,
+			Title:                  chapter.Title,
+			WhitenestChapterNumber: p.ChapterNumber,
+		},
+		Read:      p.ReadAt != nil,
+		BlockID:   p.BlockID,
+		UpdatedAt: p.UpdatedAt.In(timezone.BRT).Format(time.RFC3339),
+	}
+	if p.ReadAt != nil {
+		readAt := p.ReadAt.In(timezone.BRT).Format(time.RFC3339)
+		resp.ReadAt = &readAt
+	}
+	return resp
+}
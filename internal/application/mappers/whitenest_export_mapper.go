@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/timezone"
+)
+
+// ToWhitenestExportResponse converts an export; downloadURL is the public URL
+// of its file, "" while it has none.
+func ToWhitenestExportResponse(e *models.WhitenestExport, downloadURL string) dtos.WhitenestExportResponse {
+	resp := dtos.WhitenestExportResponse{
+		ID:           e.ID,
+		Status:       e.Status,
+		ArcID:        e.ArcID,
+		FromChapter:  e.FromChapter,
+		ToChapter:    e.ToChapter,
+		ChapterCount: e.ChapterCount,
+		Size:         e.Size,
+		Error:        e.Error,
+		CreatedAt:    e.CreatedAt.In(timezone.BRT).Format(time.RFC3339),
+	}
+	if downloadURL != "" {
+		resp.DownloadURL = &downloadURL
+	}
+	if e.CompletedAt != nil {
+		completed := e.CompletedAt.In(timezone.BRT).Format(time.RFC3339)
+		resp.CompletedAt = &completed
+	}
+	return resp
+}
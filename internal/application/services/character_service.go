@@ -3,6 +3,8 @@ package services
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/davidrdsilva/blog-api/internal/application/dtos"
@@ -12,12 +14,43 @@ import (
 	"gorm.io/gorm"
 )
 
+// Matched as substrings by the character handler.
+const (
+	errInvalidRelationship = "invalid relationship"
+	errRelationshipExists  = "relationship already exists"
+	errInvalidMerge        = "invalid merge"
+)
+
+// CharacterInUseError is returned by DeleteCharacter when the character is
+// still cast in posts and the delete isn't forced. The handler unwraps it
+// with errors.As to list the affected chapters in the 409 response.
+type CharacterInUseError struct {
+	Casts []models.CastEntry
+}
+
+func (e *CharacterInUseError) Error() string {
+	chapters := 0
+	for _, c := range e.Casts {
+		if c.ChapterNumber != nil {
+			chapters++
+		}
+	}
+	return fmt.Sprintf("character in use: cast in %d chapter(s) and %d other post(s)",
+		chapters, len(e.Casts)-chapters)
+}
+
 type CharacterService struct {
-	repo repositories.CharacterRepository
+	repo     repositories.CharacterRepository
+	postRepo repositories.PostRepository
+	relRepo  repositories.CharacterRelationshipRepository
 }
 
-func NewCharacterService(repo repositories.CharacterRepository) *CharacterService {
-	return &CharacterService{repo: repo}
+func NewCharacterService(
+	repo repositories.CharacterRepository,
+	postRepo repositories.PostRepository,
+	relRepo repositories.CharacterRelationshipRepository,
+) *CharacterService {
+	return &CharacterService{repo: repo, postRepo: postRepo, relRepo: relRepo}
 }
 
 func (s *CharacterService) CreateCharacter(req dtos.CreateCharacterRequest) (*dtos.CharacterResponse, error) {
@@ -34,6 +67,7 @@ func (s *CharacterService) CreateCharacter(req dtos.CreateCharacterRequest) (*dt
 		Location:    strings.TrimSpace(req.Location),
 		Portrait:    req.Portrait,
 		Skills:      skills,
+		Sections:    toCharacterSections(req.Sections),
 	}
 	if err := s.repo.Create(character); err != nil {
 		return nil, fmt.Errorf("failed to create character: %w", err)
@@ -43,7 +77,10 @@ func (s *CharacterService) CreateCharacter(req dtos.CreateCharacterRequest) (*dt
 	return &resp, nil
 }
 
-func (s *CharacterService) GetCharacter(id string) (*dtos.CharacterResponse, error) {
+// GetCharacter returns the character. With asOf > 0 the response is gated to
+// a reader who has reached that chapter, and a character who hasn't appeared
+// by then is reported as not found. Returns (nil, nil) when not found.
+func (s *CharacterService) GetCharacter(id string, asOf int) (*dtos.CharacterResponse, error) {
 	if !isValidUUID(id) {
 		return nil, fmt.Errorf("invalid UUID format")
 	}
@@ -54,22 +91,178 @@ func (s *CharacterService) GetCharacter(id string) (*dtos.CharacterResponse, err
 	if character == nil {
 		return nil, nil
 	}
-	resp := mappers.ToCharacterResponse(character)
+	if asOf == 0 {
+		resp := mappers.ToCharacterResponse(character)
+		return &resp, nil
+	}
+
+	stats, err := s.repo.AppearanceStats([]string{id}, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count appearances: %w", err)
+	}
+	if stats[id].Count == 0 {
+		return nil, nil
+	}
+	states, err := s.statesAsOf([]*models.Character{character}, asOf)
+	if err != nil {
+		return nil, err
+	}
+	resp := mappers.ToCharacterResponseAsOf(character, states[id], asOf)
 	return &resp, nil
 }
 
-func (s *CharacterService) ListCharacters(search string) ([]dtos.CharacterResponse, error) {
+// ListCharacters returns characters with their appearance counts. With
+// asOf > 0 only characters who have appeared by that chapter are listed,
+// gated as in GetCharacter, and counts stop at that chapter.
+func (s *CharacterService) ListCharacters(search string, asOf int) ([]dtos.CharacterResponse, error) {
 	rows, err := s.repo.FindAll(models.CharacterFilters{Search: search})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list characters: %w", err)
 	}
+	stats, err := s.repo.AppearanceStats(nil, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count appearances: %w", err)
+	}
+	if asOf > 0 {
+		introduced := rows[:0]
+		for _, c := range rows {
+			if stats[c.ID].Count > 0 {
+				introduced = append(introduced, c)
+			}
+		}
+		rows = introduced
+	}
+	states, err := s.statesAsOf(rows, asOf)
+	if err != nil {
+		return nil, err
+	}
+
 	out := make([]dtos.CharacterResponse, len(rows))
 	for i, c := range rows {
-		out[i] = mappers.ToCharacterResponse(c)
+		out[i] = mappers.ToCharacterResponseAsOf(c, states[c.ID], asOf)
+		summary := mappers.ToCharacterAppearanceSummary(stats[c.ID])
+		out[i].Appearances = &summary
 	}
 	return out, nil
 }
 
+// statesAsOf resolves each character's state as of chapter asOf, keyed by
+// character ID. asOf 0 skips the lookup; the mapper then ignores the state.
+func (s *CharacterService) statesAsOf(characters []*models.Character, asOf int) (map[string]models.CharacterState, error) {
+	states := make(map[string]models.CharacterState, len(characters))
+	if asOf == 0 || len(characters) == 0 {
+		return states, nil
+	}
+	ids := make([]string, len(characters))
+	for i, c := range characters {
+		ids[i] = c.ID
+		states[c.ID] = models.BaseState(c)
+	}
+	overrides, err := s.repo.FindStateOverrides(ids, asOf, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range overrides {
+		state := states[o.CharacterID]
+		state.Apply(o.ChapterNumber, o.CharacterStateOverride)
+		states[o.CharacterID] = state
+	}
+	return states, nil
+}
+
+func toCharacterSections(reqs []dtos.CharacterSectionRequest) models.CharacterSections {
+	out := make(models.CharacterSections, len(reqs))
+	for i, r := range reqs {
+		out[i] = r.ToModel()
+	}
+	return out
+}
+
+// GetAppearances returns the chapters the character is cast in, in reading
+// order, up to chapter asOf when it is > 0. Returns (nil, nil) when the
+// character doesn't exist.
+func (s *CharacterService) GetAppearances(id string, asOf int) (*dtos.CharacterAppearancesResponse, error) {
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("invalid UUID format")
+	}
+	exists, err := s.repo.Exists(id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	rows, err := s.repo.FindAppearances(id)
+	if err != nil {
+		return nil, err
+	}
+	rows = appearancesUpTo(rows, asOf)
+	resp := mappers.ToCharacterAppearancesResponse(id, rows)
+	return &resp, nil
+}
+
+// CastMatrix returns which characters appear in which chapters. Every
+// released chapter is a column, including chapters with no cast; only
+// characters cast in at least one of them get a row, ordered by first
+// appearance, then short name.
+func (s *CharacterService) CastMatrix() (*dtos.CastMatrixResponse, error) {
+	chapters, err := s.postRepo.ListWhitenestChapters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chapters: %w", err)
+	}
+	chapters = releasedChapters(chapters)
+	entries, err := s.repo.FindAllAppearances()
+	if err != nil {
+		return nil, err
+	}
+
+	byCharacter := make(map[string][]int)
+	var ids []string
+	for _, e := range entries {
+		if _, seen := byCharacter[e.CharacterID]; !seen {
+			ids = append(ids, e.CharacterID)
+		}
+		byCharacter[e.CharacterID] = append(byCharacter[e.CharacterID], e.ChapterNumber)
+	}
+	characters, err := s.repo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dtos.CastMatrixResponse{
+		Chapters:   make([]dtos.WhitenestChapterRef, 0, len(chapters)),
+		Characters: make([]dtos.CastMatrixRow, 0, len(characters)),
+	}
+	for _, p := range chapters {
+		if ref := mappers.ToWhitenestChapterRef(p); ref != nil {
+			resp.Chapters = append(resp.Chapters, *ref)
+		}
+	}
+	for _, c := range characters {
+		numbers := byCharacter[c.ID]
+		resp.Characters = append(resp.Characters, dtos.CastMatrixRow{
+			ID:        c.ID,
+			ShortName: c.ShortName,
+			Portrait:  c.Portrait,
+			Appearances: mappers.ToCharacterAppearanceSummary(models.CharacterAppearanceStats{
+				CharacterID:  c.ID,
+				Count:        len(numbers),
+				FirstChapter: numbers[0],
+				LastChapter:  numbers[len(numbers)-1],
+			}),
+			Chapters: numbers,
+		})
+	}
+	sort.SliceStable(resp.Characters, func(i, j int) bool {
+		a, b := resp.Characters[i], resp.Characters[j]
+		if *a.Appearances.FirstChapter != *b.Appearances.FirstChapter {
+			return *a.Appearances.FirstChapter < *b.Appearances.FirstChapter
+		}
+		return a.ShortName < b.ShortName
+	})
+	return resp, nil
+}
+
 func (s *CharacterService) UpdateCharacter(id string, req dtos.UpdateCharacterRequest) (*dtos.CharacterResponse, error) {
 	if !isValidUUID(id) {
 		return nil, fmt.Errorf("invalid UUID format")
@@ -107,6 +300,9 @@ func (s *CharacterService) UpdateCharacter(id string, req dtos.UpdateCharacterRe
 		}
 		current.Skills = skills
 	}
+	if req.Sections != nil {
+		current.Sections = toCharacterSections(*req.Sections)
+	}
 
 	if err := s.repo.Update(id, current); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -123,10 +319,23 @@ func (s *CharacterService) UpdateCharacter(id string, req dtos.UpdateCharacterRe
 	return &resp, nil
 }
 
-func (s *CharacterService) DeleteCharacter(id string) error {
+// DeleteCharacter deletes a character. While the character is cast in any
+// post it refuses with *CharacterInUseError unless force is set; a forced
+// delete removes them from those casts. Their relationships are deleted
+// either way.
+func (s *CharacterService) DeleteCharacter(id string, force bool) error {
 	if !isValidUUID(id) {
 		return fmt.Errorf("invalid UUID format")
 	}
+	if !force {
+		casts, err := s.repo.FindCastEntries(id)
+		if err != nil {
+			return err
+		}
+		if len(casts) > 0 {
+			return &CharacterInUseError{Casts: casts}
+		}
+	}
 	if err := s.repo.Delete(id); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return err
@@ -135,3 +344,276 @@ func (s *CharacterService) DeleteCharacter(id string) error {
 	}
 	return nil
 }
+
+// MergeCharacter folds the duplicate character into canonicalID: cast
+// entries and relationships move over (see CharacterRepository.MergeInto)
+// and the duplicate is deleted. The duplicate's own description, sections
+// and portrait are discarded. Returns (nil, nil) when either character
+// doesn't exist.
+func (s *CharacterService) MergeCharacter(canonicalID string, req dtos.MergeCharacterRequest) (*dtos.CharacterMergeResponse, error) {
+	if !isValidUUID(canonicalID) {
+		return nil, fmt.Errorf("invalid UUID format")
+	}
+	if req.DuplicateID == canonicalID {
+		return nil, fmt.Errorf("%s: a character can't be merged into itself", errInvalidMerge)
+	}
+
+	result, err := s.repo.MergeInto(req.DuplicateID, canonicalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to merge characters: %w", err)
+	}
+
+	character, err := s.repo.FindByID(canonicalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch character: %w", err)
+	}
+	if character == nil {
+		return nil, nil
+	}
+	stats, err := s.repo.AppearanceStats([]string{canonicalID}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count appearances: %w", err)
+	}
+	resp := mappers.ToCharacterMergeResponse(character, stats[canonicalID], result)
+	return &resp, nil
+}
+
+// GetProgression returns the character's state at each chapter they appear
+// in, in reading order, up to chapter asOf when it is > 0. Returns (nil, nil)
+// when the character doesn't exist.
+func (s *CharacterService) GetProgression(id string, asOf int) (*dtos.CharacterProgressionResponse, error) {
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("invalid UUID format")
+	}
+	character, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch character: %w", err)
+	}
+	if character == nil {
+		return nil, nil
+	}
+	rows, err := s.repo.FindAppearances(id)
+	if err != nil {
+		return nil, err
+	}
+	rows = appearancesUpTo(rows, asOf)
+
+	state := models.BaseState(character)
+	resp := &dtos.CharacterProgressionResponse{
+		CharacterID: id,
+		Base:        mappers.ToCharacterStateResponse(state),
+		Points:      make([]dtos.CharacterProgressionItem, len(rows)),
+	}
+	for i, row := range rows {
+		state.Apply(row.ChapterNumber, row.CharacterStateOverride)
+		resp.Points[i] = dtos.CharacterProgressionItem{
+			ID:                     row.PostID,
+			Title:                  row.Title,
+			WhitenestChapterNumber: row.ChapterNumber,
+			State:                  mappers.ToCharacterStateResponse(state),
+		}
+	}
+	return resp, nil
+}
+
+// appearancesUpTo drops appearances after chapter asOf; asOf 0 keeps all.
+// rows are ordered by chapter number.
+func appearancesUpTo(rows []models.CharacterAppearance, asOf int) []models.CharacterAppearance {
+	if asOf == 0 {
+		return rows
+	}
+	for i, row := range rows {
+		if row.ChapterNumber > asOf {
+			return rows[:i]
+		}
+	}
+	return rows
+}
+
+// ListRelationships returns every relationship, or only those involving
+// characterID when it is set.
+func (s *CharacterService) ListRelationships(characterID string) ([]dtos.RelationshipResponse, error) {
+	if characterID != "" && !isValidUUID(characterID) {
+		return nil, fmt.Errorf("invalid UUID format")
+	}
+	rels, err := s.relRepo.FindAll(models.RelationshipFilters{CharacterID: characterID})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]dtos.RelationshipResponse, len(rels))
+	for i, r := range rels {
+		out[i] = mappers.ToRelationshipResponse(r)
+	}
+	return out, nil
+}
+
+// GetRelationship returns (nil, nil) when no relationship has that ID.
+func (s *CharacterService) GetRelationship(id string) (*dtos.RelationshipResponse, error) {
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("invalid UUID format")
+	}
+	rel, err := s.relRepo.FindByID(id)
+	if err != nil || rel == nil {
+		return nil, err
+	}
+	resp := mappers.ToRelationshipResponse(rel)
+	return &resp, nil
+}
+
+func (s *CharacterService) CreateRelationship(req dtos.CreateRelationshipRequest) (*dtos.RelationshipResponse, error) {
+	rel := &models.CharacterRelationship{
+		SourceID:     req.SourceID,
+		TargetID:     req.TargetID,
+		Type:         strings.ToLower(strings.TrimSpace(req.Type)),
+		Directed:     req.Directed,
+		Description:  trimmedOrNil(req.Description),
+		SinceChapter: req.SinceChapter,
+	}
+	if err := s.validateRelationship(rel, ""); err != nil {
+		return nil, err
+	}
+	if err := s.relRepo.Create(rel); err != nil {
+		return nil, err
+	}
+	return s.GetRelationship(rel.ID)
+}
+
+// UpdateRelationship returns (nil, nil) when no relationship has that ID.
+func (s *CharacterService) UpdateRelationship(id string, req dtos.UpdateRelationshipRequest) (*dtos.RelationshipResponse, error) {
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("invalid UUID format")
+	}
+	rel, err := s.relRepo.FindByID(id)
+	if err != nil || rel == nil {
+		return nil, err
+	}
+
+	if req.SourceID != nil {
+		rel.SourceID = *req.SourceID
+	}
+	if req.TargetID != nil {
+		rel.TargetID = *req.TargetID
+	}
+	if req.Type != nil {
+		rel.Type = strings.ToLower(strings.TrimSpace(*req.Type))
+	}
+	if req.Directed != nil {
+		rel.Directed = *req.Directed
+	}
+	if req.Description != nil {
+		rel.Description = trimmedOrNil(req.Description)
+	}
+	if req.SinceChapter != nil {
+		rel.SinceChapter = req.SinceChapter
+		if *req.SinceChapter == 0 {
+			rel.SinceChapter = nil
+		}
+	}
+	if err := s.validateRelationship(rel, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.relRepo.Update(id, rel); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s.GetRelationship(id)
+}
+
+func (s *CharacterService) DeleteRelationship(id string) error {
+	if !isValidUUID(id) {
+		return fmt.Errorf("invalid UUID format")
+	}
+	return s.relRepo.Delete(id)
+}
+
+// validateRelationship normalizes rel (undirected pairs are stored with the
+// smaller ID as source) and checks it against the characters and the
+// existing relationships, ignoring selfID.
+func (s *CharacterService) validateRelationship(rel *models.CharacterRelationship, selfID string) error {
+	if !slices.Contains(models.RelationshipTypes, rel.Type) {
+		return fmt.Errorf("%s: type must be one of %s, got %q",
+			errInvalidRelationship, strings.Join(models.RelationshipTypes, ", "), rel.Type)
+	}
+	if rel.SourceID == rel.TargetID {
+		return fmt.Errorf("%s: a character can't be related to itself", errInvalidRelationship)
+	}
+	if !rel.Directed && rel.SourceID > rel.TargetID {
+		rel.SourceID, rel.TargetID = rel.TargetID, rel.SourceID
+	}
+
+	for _, id := range []string{rel.SourceID, rel.TargetID} {
+		exists, err := s.repo.Exists(id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%s: character %s does not exist", errInvalidRelationship, id)
+		}
+	}
+
+	existing, err := s.relRepo.FindAll(models.RelationshipFilters{CharacterID: rel.SourceID})
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.ID != selfID && other.SourceID == rel.SourceID && other.TargetID == rel.TargetID && other.Type == rel.Type {
+			return fmt.Errorf("%s: %s", errRelationshipExists, other.ID)
+		}
+	}
+	return nil
+}
+
+// Graph returns characters as nodes and relationships as links. With
+// asOf > 0 it only includes characters who have appeared by that chapter and
+// relationships established by then between them.
+func (s *CharacterService) Graph(asOf int) (*dtos.CharacterGraphResponse, error) {
+	characters, err := s.repo.FindAll(models.CharacterFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list characters: %w", err)
+	}
+	stats, err := s.repo.AppearanceStats(nil, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count appearances: %w", err)
+	}
+	rels, err := s.relRepo.FindAll(models.RelationshipFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dtos.CharacterGraphResponse{
+		Nodes: make([]dtos.CharacterGraphNode, 0, len(characters)),
+		Links: make([]dtos.CharacterGraphLink, 0, len(rels)),
+	}
+	if asOf > 0 {
+		resp.AsOfChapter = &asOf
+	}
+	nodes := make(map[string]struct{}, len(characters))
+	for _, c := range characters {
+		count := stats[c.ID].Count
+		if asOf > 0 && count == 0 {
+			continue
+		}
+		nodes[c.ID] = struct{}{}
+		resp.Nodes = append(resp.Nodes, dtos.CharacterGraphNode{
+			ID:          c.ID,
+			Label:       c.ShortName,
+			FullName:    c.FullName,
+			Portrait:    c.Portrait,
+			Appearances: count,
+		})
+	}
+	for _, r := range rels {
+		_, hasSource := nodes[r.SourceID]
+		_, hasTarget := nodes[r.TargetID]
+		if hasSource && hasTarget && r.VisibleAt(asOf) {
+			resp.Links = append(resp.Links, mappers.ToCharacterGraphLink(r))
+		}
+	}
+	return resp, nil
+}
@@ -52,11 +52,11 @@ func (s *CommentService) CreateComment(req dtos.CreateCommentRequest) (*dtos.Com
 }
 
 func (s *CommentService) GetComments(filters models.CommentFilters) (*dtos.CommentListResponse, error) {
-	comments, err := s.repo.FindAll(filters)
+	comments, meta, err := s.repo.FindAll(filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list comments: %w", err)
 	}
-	response := mappers.ToCommentListResponse(comments)
+	response := mappers.ToCommentListResponse(comments, meta)
 	return &response, nil
 }
 
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/config"
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/application/mappers"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/fetcher"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+)
+
+// errLinkCheckRunning is matched as a substring by the link handler to map to
+// LINK_CHECK_RUNNING.
+const errLinkCheckRunning = "link check already running"
+
+// LinkCheckService checks every outbound link in post content (Link Tool
+// targets, embed sources and inline anchors) and records the status, final
+// URL and redirect count of each. Requests go through the SSRF-safe fetcher,
+// so links to private addresses are recorded as broken rather than followed.
+type LinkCheckService struct {
+	repo    repositories.LinkCheckRepository
+	fetcher *fetcher.Fetcher
+	cfg     config.LinkCheckConfig
+	logger  *logging.Logger
+
+	// sweepMu keeps a manual sweep from overlapping the scheduled one.
+	sweepMu sync.Mutex
+}
+
+func NewLinkCheckService(
+	repo repositories.LinkCheckRepository,
+	fetcher *fetcher.Fetcher,
+	cfg config.LinkCheckConfig,
+	logger *logging.Logger,
+) *LinkCheckService {
+	return &LinkCheckService{
+		repo:    repo,
+		fetcher: fetcher,
+		cfg:     cfg,
+		logger:  logger,
+	}
+}
+
+// linkStatus is the outcome of requesting one URL.
+type linkStatus struct {
+	statusCode *int
+	finalURL   *string
+	redirects  int
+	err        *string
+	broken     bool
+}
+
+// Sweep checks every distinct link once and replaces the stored results. If
+// ctx ends before all links are checked nothing is written, so an interrupted
+// sweep can't mark live links as broken.
+func (s *LinkCheckService) Sweep(ctx context.Context) (*dtos.LinkCheckSummary, error) {
+	if !s.sweepMu.TryLock() {
+		return nil, errors.New(errLinkCheckRunning)
+	}
+	defer s.sweepMu.Unlock()
+
+	started := time.Now()
+	posts, err := s.repo.FindPostLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	urls := map[string]*linkStatus{}
+	links := 0
+	for _, p := range posts {
+		for _, l := range p.Links {
+			urls[l.URL] = nil
+			links++
+		}
+	}
+
+	// Fan the distinct URLs out to a fixed pool of checkers.
+	var mu sync.Mutex
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < s.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range jobs {
+				status := s.check(ctx, u)
+				mu.Lock()
+				urls[u] = status
+				mu.Unlock()
+			}
+		}()
+	}
+	for u := range urls {
+		jobs <- u
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("link check interrupted: %w", err)
+	}
+
+	checkedAt := time.Now()
+	checks := make([]models.LinkCheck, 0, links)
+	broken := 0
+	for _, p := range posts {
+		for _, l := range p.Links {
+			status := urls[l.URL]
+			checks = append(checks, models.LinkCheck{
+				PostID:     p.PostID,
+				URL:        l.URL,
+				Source:     l.Source,
+				StatusCode: status.statusCode,
+				FinalURL:   status.finalURL,
+				Redirects:  status.redirects,
+				Error:      status.err,
+				Broken:     status.broken,
+				CheckedAt:  checkedAt,
+			})
+			if status.broken {
+				broken++
+			}
+		}
+	}
+	if err := s.repo.ReplaceAll(checks); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Link check sweep finished",
+		logging.F("posts", len(posts)),
+		logging.F("urls", len(urls)),
+		logging.F("broken", broken),
+		logging.F("duration", time.Since(started).String()),
+	)
+	return &dtos.LinkCheckSummary{
+		StartedAt: started.UTC().Format(time.RFC3339),
+		Posts:     len(posts),
+		Links:     links,
+		Checked:   len(urls),
+		Broken:    broken,
+	}, nil
+}
+
+// check requests u with HEAD, retrying with GET for servers that don't
+// support HEAD. 429 is not counted as broken: it says nothing about the link.
+func (s *LinkCheckService) check(ctx context.Context, u string) *linkStatus {
+	resp, err := s.fetcher.Head(ctx, u)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp.Body.Close()
+		resp, err = s.fetcher.Get(ctx, u)
+	}
+	if err != nil {
+		msg := err.Error()
+		return &linkStatus{err: &msg, broken: true}
+	}
+	// Only the status matters; the body is never read.
+	resp.Body.Close()
+
+	code := resp.StatusCode
+	finalURL := resp.Request.URL.String()
+	status := &linkStatus{
+		statusCode: &code,
+		redirects:  fetcher.Redirects(resp),
+		broken:     code >= 400 && code != http.StatusTooManyRequests,
+	}
+	if finalURL != u {
+		status.finalURL = &finalURL
+	}
+	return status
+}
+
+// BrokenLinks returns the posts whose links failed the most recent sweep.
+func (s *LinkCheckService) BrokenLinks() (*dtos.BrokenLinksReport, error) {
+	posts, err := s.repo.FindBroken()
+	if err != nil {
+		return nil, err
+	}
+	report := mappers.ToBrokenLinksReport(posts)
+	return &report, nil
+}
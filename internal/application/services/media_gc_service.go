@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/config"
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/storage"
+)
+
+// Garbage-collector actions reported per object.
+const (
+	gcActionPending    = "pending"
+	gcActionQuarantine = "quarantine"
+	gcActionRestore    = "restore"
+	gcActionDelete     = "delete"
+)
+
+// MediaGCService finds uploads nothing references any more and removes them
+// in two stages. An unreferenced object first sits out a grace period (so a
+// freshly uploaded image isn't collected before the post that uses it is
+// saved), then moves under the quarantine prefix, and is only deleted once it
+// has stayed unreferenced for the quarantine period as well. A quarantined
+// object that becomes referenced again is moved back to its original key.
+type MediaGCService struct {
+	mediaRepo repositories.MediaRepository
+	storage   storage.ObjectStorage
+	cfg       config.MediaGCConfig
+	logger    *logging.Logger
+
+	// sweepMu serialises real sweeps; dry runs only read and don't take it.
+	sweepMu sync.Mutex
+}
+
+func NewMediaGCService(
+	mediaRepo repositories.MediaRepository,
+	storage storage.ObjectStorage,
+	cfg config.MediaGCConfig,
+	logger *logging.Logger,
+) *MediaGCService {
+	return &MediaGCService{
+		mediaRepo: mediaRepo,
+		storage:   storage,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+// Sweep runs one pass over the bucket. With dryRun set it only reports what
+// it would do. Per-object failures are recorded on the action and don't stop
+// the sweep; failing to build the reference set or list the bucket does,
+// since acting on an incomplete picture could delete live media.
+func (s *MediaGCService) Sweep(ctx context.Context, dryRun bool) (*dtos.MediaGCReport, error) {
+	if !dryRun {
+		s.sweepMu.Lock()
+		defer s.sweepMu.Unlock()
+	}
+
+	now := time.Now()
+	report := &dtos.MediaGCReport{
+		DryRun:    dryRun,
+		StartedAt: now.UTC().Format(time.RFC3339),
+		Actions:   []dtos.MediaGCAction{},
+	}
+
+	urls, err := s.mediaRepo.FindReferencedURLs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect referenced media: %w", err)
+	}
+	referenced := make(map[string]struct{}, len(urls))
+	for _, u := range urls {
+		if key, ok := s.storage.KeyFromURL(u); ok {
+			referenced[key] = struct{}{}
+		}
+	}
+	report.ReferencedURLs = len(referenced)
+
+	grace := time.Duration(s.cfg.GracePeriodHours) * time.Hour
+	quarantine := time.Duration(s.cfg.QuarantinePeriodHours) * time.Hour
+
+	uploads, err := s.storage.List(ctx, storage.UploadsPrefix)
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]struct{}, len(uploads))
+	for _, obj := range uploads {
+		live[obj.Key] = struct{}{}
+		report.ScannedObjects++
+		if _, ok := referenced[obj.Key]; ok {
+			continue
+		}
+		action := newGCAction(s.storage, obj, obj.Key)
+		if now.Sub(obj.LastModified) < grace {
+			action.Action = gcActionPending
+		} else {
+			action.Action = gcActionQuarantine
+			if !dryRun {
+				if err := s.storage.Move(ctx, obj.Key, storage.QuarantinePrefix+obj.Key); err != nil {
+					action.Error = err.Error()
+				}
+			}
+			if action.Error == "" {
+				report.QuarantinedBytes += obj.Size
+			}
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	quarantined, err := s.storage.List(ctx, storage.QuarantinePrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range quarantined {
+		report.ScannedObjects++
+		originalKey := strings.TrimPrefix(obj.Key, storage.QuarantinePrefix)
+		action := newGCAction(s.storage, obj, originalKey)
+
+		if _, ok := referenced[originalKey]; ok {
+			action.Action = gcActionRestore
+			if !dryRun {
+				if err := s.storage.Move(ctx, obj.Key, originalKey); err != nil {
+					action.Error = err.Error()
+				}
+			}
+			report.Actions = append(report.Actions, action)
+			continue
+		}
+
+		// The copy into quarantine stamps a fresh LastModified, so the
+		// quarantine clock starts when the object was moved, not uploaded.
+		if now.Sub(obj.LastModified) < quarantine {
+			continue
+		}
+		action.Action = gcActionDelete
+		if !dryRun {
+			if err := s.storage.Delete(ctx, obj.Key); err != nil {
+				action.Error = err.Error()
+			} else if _, reuploaded := live[originalKey]; !reuploaded {
+				// Keys are content-addressed, so the same bytes may have been
+				// uploaded again since quarantine. That object still owns the
+				// media row; only the stale quarantined copy goes.
+				if err := s.mediaRepo.DeleteByKey(originalKey); err != nil {
+					action.Error = err.Error()
+				}
+			}
+		}
+		if action.Error == "" {
+			report.DeletedBytes += obj.Size
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	// Streamed uploads sit under the temp prefix only until they are moved to
+	// their content-addressed key. Anything older than the grace period was
+	// left behind by a failed upload.
+	temps, err := s.storage.List(ctx, storage.TempPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range temps {
+		report.ScannedObjects++
+		if now.Sub(obj.LastModified) < grace {
+			continue
+		}
+		action := newGCAction(s.storage, obj, obj.Key)
+		action.Action = gcActionDelete
+		if !dryRun {
+			if err := s.storage.Delete(ctx, obj.Key); err != nil {
+				action.Error = err.Error()
+			}
+		}
+		if action.Error == "" {
+			report.DeletedBytes += obj.Size
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	if !dryRun {
+		s.logger.Info("Media GC sweep finished",
+			logging.F("scanned", report.ScannedObjects),
+			logging.F("actions", len(report.Actions)),
+			logging.F("quarantined_bytes", report.QuarantinedBytes),
+			logging.F("deleted_bytes", report.DeletedBytes),
+		)
+	}
+	return report, nil
+}
+
+// newGCAction describes obj in report form. URL is the object's original
+// public URL, which is what posts and characters would reference.
+func newGCAction(store storage.ObjectStorage, obj storage.ObjectInfo, originalKey string) dtos.MediaGCAction {
+	return dtos.MediaGCAction{
+		Key:          obj.Key,
+		URL:          store.PublicURL(originalKey),
+		Size:         obj.Size,
+		LastModified: obj.LastModified.UTC().Format(time.RFC3339),
+	}
+}
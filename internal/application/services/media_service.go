@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/application/mappers"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/storage"
+	"gorm.io/gorm"
+)
+
+// MediaInUseError is returned by DeleteMedia when posts, characters or arcs
+// still reference the object. The handler unwraps it with errors.As to list the
+// references in the 409 response.
+type MediaInUseError struct {
+	References models.MediaReferences
+}
+
+func (e *MediaInUseError) Error() string {
+	return fmt.Sprintf("media in use: %d post image(s), %d post content block(s), %d character portrait(s), %d arc image(s)",
+		len(e.References.PostImageIDs), len(e.References.PostContentIDs), len(e.References.CharacterIDs),
+		len(e.References.ArcIDs))
+}
+
+// MediaService handles the media library: listing what has been uploaded and
+// deleting objects nothing references any more.
+type MediaService struct {
+	repo    repositories.MediaRepository
+	storage storage.ObjectStorage
+	logger  *logging.Logger
+}
+
+// NewMediaService creates a new media service
+func NewMediaService(
+	repo repositories.MediaRepository,
+	storage storage.ObjectStorage,
+	logger *logging.Logger,
+) *MediaService {
+	return &MediaService{
+		repo:    repo,
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+func (s *MediaService) ListMedia(filters models.MediaFilters) (*dtos.MediaListResponse, error) {
+	rows, meta, err := s.repo.FindAll(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media: %w", err)
+	}
+	response := mappers.ToMediaListResponse(rows, meta)
+	return &response, nil
+}
+
+// GetStats reports storage use and how much deduplication has saved.
+func (s *MediaService) GetStats() (*dtos.MediaStatsResponse, error) {
+	stats, err := s.repo.Stats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media stats: %w", err)
+	}
+	response := mappers.ToMediaStatsResponse(stats)
+	return &response, nil
+}
+
+// DeleteMedia removes a media object from storage and from the library. It
+// refuses with *MediaInUseError while any post cover, Editor.js image or video
+// block, character portrait or arc cover points at the object's URL.
+//
+// The object is removed before the row: RemoveObject is idempotent, so if the
+// row delete fails the caller can simply retry, whereas the reverse order
+// would leave an object nothing tracks.
+func (s *MediaService) DeleteMedia(id string) error {
+	if !isValidUUID(id) {
+		return fmt.Errorf("invalid UUID format")
+	}
+
+	media, err := s.repo.FindByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch media: %w", err)
+	}
+	if media == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	refs, err := s.repo.FindReferences(media.URL)
+	if err != nil {
+		return fmt.Errorf("failed to check media references: %w", err)
+	}
+	if !refs.IsEmpty() {
+		return &MediaInUseError{References: *refs}
+	}
+
+	if err := s.storage.Delete(context.Background(), media.Key); err != nil {
+		return fmt.Errorf("failed to delete media object: %w", err)
+	}
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete media: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/database"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/storage"
 	"github.com/google/uuid"
 )
 
@@ -30,6 +32,35 @@ const errCastNotWhitenest = "whitenest invariant: cast requires Whitenest catego
 // and avoids partial states.
 const errWhitenestManualRenumber = "whitenest manual chapter renumber not allowed: use the reorder endpoint"
 
+// errWhitenestInsertExisting shares the WHITENEST_REORDER_REQUIRED prefix:
+// whitenest_insert_at only places a post joining Whitenest; chapters already
+// in the series are repositioned with the move endpoint.
+const errWhitenestInsertExisting = "whitenest manual chapter renumber not allowed: post is already a chapter, use the move endpoint"
+
+// errWhitenestInsertConflict rejects a request naming both an explicit chapter
+// number and an insert position.
+const errWhitenestInsertConflict = "whitenest invariant: whitenest_insert_at and whitenest_chapter_number are mutually exclusive"
+
+// errReleaseNotWhitenest and errWhitenestScheduleConflict share the
+// WHITENEST_INVARIANT_VIOLATION prefix: only Whitenest chapters are queued,
+// and a queued chapter's number follows from its release time.
+const (
+	errReleaseNotWhitenest       = "whitenest invariant: release_at requires Whitenest category"
+	errWhitenestScheduleConflict = "whitenest invariant: release_at can't be combined with whitenest_insert_at or whitenest_chapter_number"
+	errChapterReleased           = "whitenest invariant: chapter already released, release_at only applies to queued chapters"
+)
+
+// errInsertAfterQueue shares the INVALID_CHAPTER_POSITION prefix: a chapter
+// released right away can't be placed after a queued one.
+const errInsertAfterQueue = "invalid chapter position: released chapters must come before queued ones"
+
+// linkImageRehostTimeout bounds the background pass that copies a saved
+// post's link preview images.
+const linkImageRehostTimeout = 20 * time.Second
+
+// linkImageUploader is recorded as the uploader of re-hosted link images.
+const linkImageUploader = "link-preview"
+
 // PostService handles business logic for posts
 type PostService struct {
 	repo          repositories.PostRepository
@@ -37,6 +68,8 @@ type PostService struct {
 	tagRepo       repositories.TagRepository
 	characterRepo repositories.CharacterRepository
 	config        *config.Config
+	objects       storage.ObjectStorage
+	uploads       *UploadService
 	jobCh         chan<- jobs.GenerateCommentsJob
 	viewCh        chan<- jobs.IncrementPostViewsJob
 	logger        *logging.Logger
@@ -49,6 +82,8 @@ func NewPostService(
 	tagRepo repositories.TagRepository,
 	characterRepo repositories.CharacterRepository,
 	cfg *config.Config,
+	objects storage.ObjectStorage,
+	uploads *UploadService,
 	jobCh chan<- jobs.GenerateCommentsJob,
 	viewCh chan<- jobs.IncrementPostViewsJob,
 	logger *logging.Logger,
@@ -59,6 +94,8 @@ func NewPostService(
 		tagRepo:       tagRepo,
 		characterRepo: characterRepo,
 		config:        cfg,
+		objects:       objects,
+		uploads:       uploads,
 		jobCh:         jobCh,
 		viewCh:        viewCh,
 		logger:        logger,
@@ -97,13 +134,44 @@ func (s *PostService) CreatePost(req dtos.CreatePostRequest) (*dtos.PostResponse
 			errCastNotWhitenest, cat.Name)
 	}
 
+	if req.ReleaseAt != nil {
+		if !isWhitenestCategory {
+			return nil, fmt.Errorf("%s: provided on category=%q", errReleaseNotWhitenest, cat.Name)
+		}
+		if req.WhitenestChapterNumber != nil || req.WhitenestInsertAt != nil {
+			return nil, fmt.Errorf("%s", errWhitenestScheduleConflict)
+		}
+	}
+
+	// inserting opens a slot at the chapter's number, shifting later chapters
+	// up; guarded by insertVersion.
+	inserting := false
+	var insertVersion string
+	if req.WhitenestInsertAt != nil {
+		if !isWhitenestCategory {
+			return nil, fmt.Errorf("%s: provided insert_at=%d on category=%q",
+				errWhitenestMismatch, *req.WhitenestInsertAt, cat.Name)
+		}
+		if req.WhitenestChapterNumber != nil {
+			return nil, fmt.Errorf("%s", errWhitenestInsertConflict)
+		}
+		if err := s.checkInsertBeforeQueue(*req.WhitenestInsertAt); err != nil {
+			return nil, err
+		}
+		// The repo validates the position against the locked chapter set.
+		req.WhitenestChapterNumber = req.WhitenestInsertAt
+		inserting, insertVersion = true, chapterVersionFromETag(*req.WhitenestVersion)
+	}
+
 	if isWhitenestCategory && req.WhitenestChapterNumber == nil {
-		max, err := s.repo.MaxWhitenestChapterNumber()
+		number, version, err := s.placeChapter(req.ReleaseAt)
 		if err != nil {
-			return nil, fmt.Errorf("failed to assign next chapter number: %w", err)
+			return nil, err
+		}
+		req.WhitenestChapterNumber = &number
+		if version != "" {
+			inserting, insertVersion = true, version
 		}
-		next := max + 1
-		req.WhitenestChapterNumber = &next
 	}
 
 	// Convert DTO to domain model
@@ -124,7 +192,11 @@ func (s *PostService) CreatePost(req dtos.CreatePostRequest) (*dtos.PostResponse
 		post.Tags = tagSlice
 	}
 
-	if err := s.repo.Create(post); err != nil {
+	if inserting {
+		if err := s.repo.InsertWhitenestChapter(post, insertVersion); err != nil {
+			return nil, fmt.Errorf("failed to insert chapter: %w", err)
+		}
+	} else if err := s.repo.Create(post); err != nil {
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
@@ -134,6 +206,8 @@ func (s *PostService) CreatePost(req dtos.CreatePostRequest) (*dtos.PostResponse
 		}
 	}
 
+	s.rehostLinkImages(post.ID, req.Content)
+
 	// Re-fetch so Category is populated for the response.
 	saved, err := s.repo.FindByID(post.ID)
 	if err != nil || saved == nil {
@@ -170,7 +244,9 @@ func (s *PostService) dispatchAICommentJob(post *models.Post) {
 	}
 }
 
-func (s *PostService) GetPost(id string) (*dtos.PostResponse, error) {
+// GetPost returns the post with the given ID. A queued Whitenest chapter is
+// only returned as a preview, which doesn't count as a view.
+func (s *PostService) GetPost(id string, preview bool) (*dtos.PostResponse, error) {
 	if !isValidUUID(id) {
 		return nil, fmt.Errorf("invalid UUID format")
 	}
@@ -179,14 +255,14 @@ func (s *PostService) GetPost(id string) (*dtos.PostResponse, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch post: %w", err)
 	}
-	if post == nil {
+	if post == nil || (post.IsQueued() && !preview) {
 		return nil, nil
 	}
 
 	// Fire-and-forget: bump total_views asynchronously so the read path stays
 	// fast and stays decoupled from a write that can fail independently. If
 	// the buffer is full we drop the increment rather than blocking.
-	if s.viewCh != nil {
+	if s.viewCh != nil && !post.IsQueued() {
 		select {
 		case s.viewCh <- jobs.IncrementPostViewsJob{PostID: post.ID}:
 		default:
@@ -249,6 +325,15 @@ func (s *PostService) ListPosts(filters models.PostFilters) (*dtos.PostListRespo
 	}
 
 	response := mappers.ToPostListResponse(posts, meta)
+
+	// Facets are counted over the whole filtered set, not just this page.
+	if len(filters.Facets) > 0 {
+		facets, err := s.repo.Facets(filters, filters.Facets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute facets: %w", err)
+		}
+		response.Facets = mappers.ToFacetResponse(facets)
+	}
 	return &response, nil
 }
 
@@ -307,6 +392,24 @@ func (s *PostService) UpdatePost(id string, req dtos.UpdatePostRequest) (*dtos.P
 		}
 	}
 
+	// release_at queues a post joining Whitenest, or reschedules a chapter
+	// that is still queued; released chapters stay released.
+	rescheduling := false
+	if req.ReleaseAt != nil {
+		if !isWhitenestCategory {
+			return nil, fmt.Errorf("%s: provided on category=%q", errReleaseNotWhitenest, cat.Name)
+		}
+		if req.WhitenestInsertAt != nil {
+			return nil, fmt.Errorf("%s", errWhitenestScheduleConflict)
+		}
+		if post.WhitenestChapterNumber != nil {
+			if !post.IsQueued() {
+				return nil, fmt.Errorf("%s", errChapterReleased)
+			}
+			rescheduling = !req.ReleaseAt.Equal(*post.ReleaseAt)
+		}
+	}
+
 	// Demote: post was a Whitenest chapter, new category is not Whitenest.
 	// Clear the number and close the gap inside one transaction below.
 	demotingFromWhitenest := post.WhitenestChapterNumber != nil && !isWhitenestCategory
@@ -332,17 +435,53 @@ func (s *PostService) UpdatePost(id string, req dtos.UpdatePostRequest) (*dtos.P
 		req.CharacterIDs = nil
 	}
 
-	// Promote / fresh Whitenest write: auto-assign next available number when
-	// the post lands in Whitenest without one. Skipped if we're demoting (the
-	// post is leaving Whitenest, not joining it).
-	if isWhitenestCategory && post.WhitenestChapterNumber == nil {
-		max, err := s.repo.MaxWhitenestChapterNumber()
+	// whitenest_insert_at only applies to a post joining Whitenest.
+	promotingAt := req.WhitenestInsertAt != nil
+	var promoteVersion string
+	if promotingAt {
+		if !isWhitenestCategory {
+			return nil, fmt.Errorf("%s: provided insert_at=%d on category=%q",
+				errWhitenestMismatch, *req.WhitenestInsertAt, cat.Name)
+		}
+		if post.WhitenestChapterNumber != nil {
+			return nil, fmt.Errorf("%s: provided insert_at=%d", errWhitenestInsertExisting, *req.WhitenestInsertAt)
+		}
+		if err := s.checkInsertBeforeQueue(*req.WhitenestInsertAt); err != nil {
+			return nil, err
+		}
+		req.WhitenestChapterNumber = req.WhitenestInsertAt
+		promoteVersion = chapterVersionFromETag(*req.WhitenestVersion)
+	}
+
+	// Promote / fresh Whitenest write: assign the post its slot when it lands
+	// in Whitenest without a number — the end of the series, or ahead of the
+	// chapters queued after it. Skipped if we're demoting (the post is leaving
+	// Whitenest, not joining it).
+	if isWhitenestCategory && post.WhitenestChapterNumber == nil && !promotingAt {
+		number, version, err := s.placeChapter(req.ReleaseAt)
 		if err != nil {
-			return nil, fmt.Errorf("failed to assign next chapter number: %w", err)
+			return nil, err
 		}
-		next := max + 1
-		req.WhitenestChapterNumber = &next
+		req.WhitenestChapterNumber = &number
+		if version != "" {
+			promotingAt, promoteVersion = true, version
+		}
+	}
+
+	// A rescheduled chapter moves to its slot in release order, in the same
+	// transaction as the update.
+	var rescheduleFrom, rescheduleTo int
+	var rescheduleVersion string
+	if rescheduling {
+		chapters, err := s.repo.ListWhitenestChapters()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list chapters: %w", err)
+		}
+		rescheduleFrom = *post.WhitenestChapterNumber
+		rescheduleTo = scheduleSlot(chapters, req.ReleaseAt, rescheduleFrom)
+		rescheduleVersion = chapterVersion(chapters)
 	}
+	wasChapter := post.WhitenestChapterNumber != nil
 
 	// Apply updates
 	mappers.UpdatePostRequestToPost(post, req)
@@ -353,6 +492,12 @@ func (s *PostService) UpdatePost(id string, req dtos.UpdatePostRequest) (*dtos.P
 		// nilling here keeps the in-memory model consistent for callers that
 		// inspect `post` after this point.
 		post.WhitenestChapterNumber = nil
+		post.ReleaseAt = nil
+	} else if wasChapter && !rescheduling {
+		// Leave release_at out of the update (Updates skips nil pointers):
+		// the release scheduler may have released the chapter since it was
+		// read, and writing the old value back would queue it again.
+		post.ReleaseAt = nil
 	}
 
 	// Publishing a draft requires a featured image — it's the contract for any
@@ -373,11 +518,20 @@ func (s *PostService) UpdatePost(id string, req dtos.UpdatePostRequest) (*dtos.P
 	// the chapter number column and shifts later chapters down to close the gap;
 	// the regular Update path can't do either (Updates skips nil pointers, and
 	// the gap-close needs to share a transaction with the post update so a
-	// crash mid-flow can't leave numbers misaligned).
+	// crash mid-flow can't leave numbers misaligned). A promote at a position
+	// does the mirror image: it opens the slot in the same transaction.
 	if demotingFromWhitenest {
 		if err := s.repo.DemoteWhitenestChapter(id, post, demoteFromNumber); err != nil {
 			return nil, fmt.Errorf("failed to demote chapter: %w", err)
 		}
+	} else if promotingAt {
+		if err := s.repo.PromoteWhitenestChapter(id, post, promoteVersion); err != nil {
+			return nil, fmt.Errorf("failed to promote chapter: %w", err)
+		}
+	} else if rescheduling {
+		if err := s.repo.RescheduleWhitenestChapter(id, post, rescheduleFrom, rescheduleTo, rescheduleVersion); err != nil {
+			return nil, fmt.Errorf("failed to reschedule chapter: %w", err)
+		}
 	} else if err := s.repo.Update(id, post); err != nil {
 		return nil, fmt.Errorf("failed to update post: %w", err)
 	}
@@ -400,6 +554,8 @@ func (s *PostService) UpdatePost(id string, req dtos.UpdatePostRequest) (*dtos.P
 		}
 	}
 
+	s.rehostLinkImages(id, req.Content)
+
 	updatedPost, err := s.repo.FindByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch updated post: %w", err)
@@ -426,6 +582,37 @@ func (s *PostService) DeletePost(id string) error {
 	return nil
 }
 
+// placeChapter returns the number a post joining Whitenest without an
+// explicit position takes (see scheduleSlot), and the chapter set version
+// when later chapters have to shift up to make room; "" when it goes last.
+func (s *PostService) placeChapter(releaseAt *time.Time) (int, string, error) {
+	chapters, err := s.repo.ListWhitenestChapters()
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to assign next chapter number: %w", err)
+	}
+	at := scheduleSlot(chapters, releaseAt, 0)
+	if len(chapters) == 0 || at > *chapters[len(chapters)-1].WhitenestChapterNumber {
+		return at, "", nil
+	}
+	return at, chapterVersion(chapters), nil
+}
+
+// checkInsertBeforeQueue rejects an insert position after a queued chapter:
+// a chapter placed there would be released ahead of the ones before it.
+func (s *PostService) checkInsertBeforeQueue(at int) error {
+	chapters, err := s.repo.ListWhitenestChapters()
+	if err != nil {
+		return fmt.Errorf("failed to list chapters: %w", err)
+	}
+	for _, p := range chapters {
+		if p.IsQueued() && *p.WhitenestChapterNumber < at {
+			return fmt.Errorf("%s: insert_at %d is after queued chapter %d",
+				errInsertAfterQueue, at, *p.WhitenestChapterNumber)
+		}
+	}
+	return nil
+}
+
 // persistCast verifies every supplied character ID exists, then writes the
 // join rows in the supplied order. An empty slice clears the cast.
 func (s *PostService) persistCast(postID string, characterIDs []string) error {
@@ -444,11 +631,71 @@ func (s *PostService) persistCast(postID string, characterIDs []string) error {
 	return nil
 }
 
-// validateImageURL checks if the image URL is from the trusted storage domain
-func (s *PostService) validateImageURL(imageURL string) error {
-	trustedDomain := s.config.MinIO.PublicURL
+// rehostLinkImages copies the preview image of every Link Tool block that
+// still points at a third-party host into storage and rewrites the saved
+// post to the stored URLs, so cards survive the original image disappearing
+// or being hot-link blocked. Only runs when LINK_PREVIEW_REHOST_IMAGES is
+// set. The copies happen in the background after the save, so a slow host
+// never holds the save up; the post is patched only where a block still
+// carries the original URL. A failed copy keeps the original URL, and the
+// whole pass is bounded by linkImageRehostTimeout.
+func (s *PostService) rehostLinkImages(postID string, content *models.EditorJsContent) {
+	if content == nil || s.uploads == nil || !s.config.LinkPreview.RehostImages {
+		return
+	}
 
-	if !strings.HasPrefix(imageURL, trustedDomain) {
+	var originals []string
+	seen := map[string]bool{}
+	for i := range content.Blocks {
+		original := content.Blocks[i].LinkImageURL()
+		if original == "" || seen[original] || s.objects.IsTrustedURL(original) {
+			continue
+		}
+		seen[original] = true
+		originals = append(originals, original)
+	}
+	if len(originals) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), linkImageRehostTimeout)
+		defer cancel()
+
+		stored := make(map[string]string, len(originals))
+		for _, original := range originals {
+			if ctx.Err() != nil {
+				s.logger.Warn("Link image re-hosting timed out; keeping remaining originals",
+					logging.F("postId", postID),
+				)
+				break
+			}
+			url, err := s.uploads.RehostImage(ctx, original, linkImageUploader)
+			if err != nil {
+				s.logger.Warn("Failed to re-host link preview image",
+					logging.F("url", original),
+					logging.F("error", err.Error()),
+				)
+				continue
+			}
+			stored[original] = url
+		}
+		if len(stored) == 0 {
+			return
+		}
+		if err := s.repo.ReplaceLinkImageURLs(postID, stored); err != nil {
+			s.logger.Error("Failed to save re-hosted link preview images",
+				logging.F("postId", postID),
+				logging.F("error", err.Error()),
+			)
+		}
+	}()
+}
+
+// validateImageURL checks if the image URL is served by the active storage
+// backend
+func (s *PostService) validateImageURL(imageURL string) error {
+	if !s.objects.IsTrustedURL(imageURL) {
 		return fmt.Errorf("image must be uploaded via /api/upload endpoint")
 	}
 
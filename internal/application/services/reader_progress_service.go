@@ -0,0 +1,193 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/application/mappers"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+)
+
+// ReaderProgressService tracks how far anonymous readers got through
+// Whitenest. Readers are identified by an opaque ID resolved by the API layer;
+// an empty ID means an unknown reader and every method treats it as having no
+// progress.
+type ReaderProgressService struct {
+	postRepo     repositories.PostRepository
+	progressRepo repositories.ReaderProgressRepository
+	logger       *logging.Logger
+}
+
+func NewReaderProgressService(
+	postRepo repositories.PostRepository,
+	progressRepo repositories.ReaderProgressRepository,
+	logger *logging.Logger,
+) *ReaderProgressService {
+	return &ReaderProgressService{
+		postRepo:     postRepo,
+		progressRepo: progressRepo,
+		logger:       logger,
+	}
+}
+
+// RecordOpen notes that the reader opened a chapter. Failures are logged, not
+// returned: losing a progress update must not fail the chapter read.
+func (s *ReaderProgressService) RecordOpen(readerID, postID string) {
+	if readerID == "" {
+		return
+	}
+	if err := s.progressRepo.Touch(readerID, postID); err != nil {
+		s.logger.Warn("failed to record chapter open",
+			logging.F("readerId", readerID),
+			logging.F("postId", postID),
+			logging.F("error", err.Error()),
+		)
+	}
+}
+
+// MarkRead sets the read flag on every chapter summary in groups.
+func (s *ReaderProgressService) MarkRead(readerID string, groups []dtos.WhitenestArcGroup) error {
+	if readerID == "" {
+		return nil
+	}
+	rows, err := s.progressRepo.FindByReader(readerID)
+	if err != nil {
+		return err
+	}
+	read := make(map[string]bool, len(rows))
+	for _, r := range rows {
+		read[r.PostID] = r.ReadAt != nil
+	}
+	for i := range groups {
+		for j := range groups[i].Chapters {
+			isRead := read[groups[i].Chapters[j].ID]
+			groups[i].Chapters[j].Read = &isRead
+		}
+	}
+	return nil
+}
+
+// ReachedChapter returns the highest chapter the reader has opened, 0 when
+// they haven't opened any.
+func (s *ReaderProgressService) ReachedChapter(readerID string) (int, error) {
+	if readerID == "" {
+		return 0, nil
+	}
+	rows, err := s.progressRepo.FindByReader(readerID)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[len(rows)-1].ChapterNumber, nil
+}
+
+// SaveProgress records the reader's position in chapter number and returns
+// the stored progress. Returns (nil, nil) when no released chapter has that
+// number.
+func (s *ReaderProgressService) SaveProgress(readerID string, number int, req dtos.SaveProgressRequest) (*dtos.ChapterProgressResponse, error) {
+	post, err := s.postRepo.FindWhitenestChapterByNumber(number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chapter: %w", err)
+	}
+	if post == nil || post.IsQueued() {
+		return nil, nil
+	}
+
+	progress := &models.ReaderProgress{ReaderID: readerID, PostID: post.ID}
+	var columns []string
+	if req.BlockID != nil {
+		progress.BlockID = trimmedOrNil(req.BlockID)
+		columns = append(columns, "block_id")
+	}
+	if req.Read != nil {
+		if *req.Read {
+			now := time.Now()
+			progress.ReadAt = &now
+		}
+		columns = append(columns, "read_at")
+	}
+	if err := s.progressRepo.Save(progress, columns); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.progressRepo.FindByReader(readerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		if r.PostID == post.ID {
+			resp := mappers.ToChapterProgressResponse(r, post)
+			return &resp, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to reload reader progress for chapter %d", number)
+}
+
+// GetProgress returns the reader's progress across all chapters and where
+// they should continue from. A reader without progress starts at chapter 1.
+func (s *ReaderProgressService) GetProgress(readerID string) (*dtos.ReaderProgressResponse, error) {
+	posts, err := s.postRepo.ListWhitenestChapters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chapters: %w", err)
+	}
+	posts = releasedChapters(posts)
+	var rows []models.ChapterProgress
+	if readerID != "" {
+		if rows, err = s.progressRepo.FindByReader(readerID); err != nil {
+			return nil, err
+		}
+	}
+
+	byID := make(map[string]*models.Post, len(posts))
+	for _, p := range posts {
+		byID[p.ID] = p
+	}
+	resp := &dtos.ReaderProgressResponse{
+		TotalChapters: len(posts),
+		Chapters:      make([]dtos.ChapterProgressResponse, 0, len(rows)),
+	}
+	if readerID != "" {
+		resp.ReaderID = &readerID
+	}
+
+	var latest *models.ChapterProgress
+	for i, r := range rows {
+		post := byID[r.PostID]
+		if post == nil {
+			continue
+		}
+		resp.Chapters = append(resp.Chapters, mappers.ToChapterProgressResponse(r, post))
+		if r.ReadAt != nil {
+			resp.ReadCount++
+		}
+		if latest == nil || r.UpdatedAt.After(latest.UpdatedAt) {
+			latest = &rows[i]
+		}
+	}
+
+	switch {
+	case latest == nil:
+		if len(posts) > 0 {
+			resp.Continue = &dtos.ContinueReading{Chapter: *mappers.ToWhitenestChapterRef(posts[0])}
+		}
+	case latest.ReadAt == nil:
+		resp.Continue = &dtos.ContinueReading{
+			Chapter: *mappers.ToWhitenestChapterRef(byID[latest.PostID]),
+			BlockID: latest.BlockID,
+		}
+	default:
+		for _, p := range posts {
+			if *p.WhitenestChapterNumber > latest.ChapterNumber {
+				resp.Continue = &dtos.ContinueReading{Chapter: *mappers.ToWhitenestChapterRef(p)}
+				break
+			}
+		}
+		resp.CaughtUp = resp.Continue == nil
+	}
+	return resp, nil
+}
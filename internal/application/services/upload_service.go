@@ -1,69 +1,323 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"time"
 
+	"github.com/davidrdsilva/blog-api/config"
 	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/fetcher"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/storage"
 )
 
 // UploadService handles file upload operations
 type UploadService struct {
-	storage *storage.MinIOStorage
+	objects   storage.ObjectStorage
+	uploader  *storage.Uploader
+	mediaRepo repositories.MediaRepository
+	fetcher   *fetcher.Fetcher
+	logger    *logging.Logger
 }
 
-// NewUploadService creates a new upload service
-func NewUploadService(storage *storage.MinIOStorage) *UploadService {
+// NewUploadService creates a new upload service writing to objects under the
+// limits in uploadCfg. fetcher is used by UploadImageFromURL to download
+// remote files.
+func NewUploadService(
+	objects storage.ObjectStorage,
+	uploadCfg *config.UploadConfig,
+	mediaRepo repositories.MediaRepository,
+	fetcher *fetcher.Fetcher,
+	logger *logging.Logger,
+) *UploadService {
 	return &UploadService{
-		storage: storage,
+		objects:   objects,
+		uploader:  storage.NewUploader(objects, uploadCfg, logger),
+		mediaRepo: mediaRepo,
+		fetcher:   fetcher,
+		logger:    logger,
 	}
 }
 
-// UploadImage handles image upload and returns Editor.js compatible response
-func (s *UploadService) UploadImage(file io.Reader, filename string, contentType string) (*dtos.EditorJsUploadResponse, error) {
-	// Read file data
-	data, err := io.ReadAll(file)
+// UploadImage handles image upload and returns Editor.js compatible response.
+// contentType is the client's claim and is only used for logging; storage
+// sniffs the real type from the file. Every stored object is recorded in the media library; uploader is optional
+// and stored as supplied.
+func (s *UploadService) UploadImage(file io.Reader, filename string, contentType string, uploader string) (*dtos.EditorJsUploadResponse, error) {
+	return s.store(file, filename, contentType, uploader), nil
+}
+
+// UploadImageFromURL downloads a remote file and stores it exactly as if it
+// had been uploaded, for the Editor.js Image Tool's uploadByUrl. The fetch
+// refuses private and loopback destinations (on every redirect), and is
+// bounded in time, redirects and size.
+func (s *UploadService) UploadImageFromURL(ctx context.Context, rawURL string, uploader string) (*dtos.EditorJsUploadResponse, error) {
+	resp, err := s.fetcher.Get(ctx, rawURL)
 	if err != nil {
+		errCode := "URL_NOT_ACCESSIBLE"
+		message := "Unable to fetch the URL"
+		switch {
+		case errors.Is(err, fetcher.ErrInvalidURL):
+			errCode, message = "INVALID_URL", "URL is missing or is not an absolute http(s) URL"
+		case errors.Is(err, fetcher.ErrBlockedAddress):
+			errCode, message = "URL_NOT_ALLOWED", "URL points to a private or reserved address"
+		case errors.Is(err, fetcher.ErrBodyTooLarge):
+			errCode, message = "FILE_TOO_LARGE", "Remote file exceeds the maximum upload size"
+		case errors.Is(err, fetcher.ErrTooManyRedirects):
+			message = "URL redirected too many times"
+		case errors.Is(err, context.DeadlineExceeded) || isTimeout(err):
+			errCode, message = "REQUEST_TIMEOUT", "Timed out fetching the URL"
+		}
+		s.logger.Warn("Failed to fetch remote upload",
+			logging.F("url", rawURL),
+			logging.F("error", err.Error()),
+		)
+		return &dtos.EditorJsUploadResponse{
+			Success: 0,
+			Error:   &dtos.EditorJsErrorDetail{Code: errCode, Message: message},
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
 		return &dtos.EditorJsUploadResponse{
 			Success: 0,
 			Error: &dtos.EditorJsErrorDetail{
-				Code:    "READ_ERROR",
-				Message: "Failed to read file data",
+				Code:    "URL_NOT_ACCESSIBLE",
+				Message: fmt.Sprintf("URL returned status code: %d", resp.StatusCode),
 			},
 		}, nil
 	}
 
-	// Upload to storage
-	url, err := s.storage.UploadImage(data, filename, contentType)
+	filename := path.Base(resp.Request.URL.Path)
+	return s.store(resp.Body, filename, resp.Header.Get("Content-Type"), uploader), nil
+}
+
+// RehostImage copies a remote file into storage through the same path as
+// UploadImageFromURL and returns its public URL. Failures come back as an
+// error carrying the upload error code.
+func (s *UploadService) RehostImage(ctx context.Context, rawURL string, uploader string) (string, error) {
+	resp, err := s.UploadImageFromURL(ctx, rawURL, uploader)
 	if err != nil {
-		// Determine appropriate error code
-		errCode := "UPLOAD_FAILED"
-		if contains(err.Error(), "file size") {
-			errCode = "FILE_TOO_LARGE"
-		} else if contains(err.Error(), "invalid file type") {
-			errCode = "INVALID_FILE_TYPE"
-		} else if contains(err.Error(), "dimensions") {
-			errCode = "IMAGE_TOO_LARGE"
-		}
+		return "", err
+	}
+	if resp.Success != 1 {
+		return "", fmt.Errorf("%s: %s", resp.Error.Code, resp.Error.Message)
+	}
+	return resp.File.URL, nil
+}
+
+// isTimeout reports whether err is a network timeout (http.Client.Timeout
+// surfaces as one rather than as context.DeadlineExceeded).
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
 
+// store streams file into storage, records it in the media library and builds
+// the Editor.js response. Validation failures are reported in the response,
+// not as an error.
+func (s *UploadService) store(file io.Reader, filename string, contentType string, uploader string) *dtos.EditorJsUploadResponse {
+	// Stream to storage; it enforces the size limit while reading, so the
+	// upload is never buffered beyond what validation needs.
+	result, err := s.uploader.UploadImage(file, filename, contentType)
+	if err != nil {
+		return uploadFailure(err)
+	}
+	return s.register(result, uploader)
+}
+
+// uploadFailure maps a storage validation error to the Editor.js error
+// response.
+func uploadFailure(err error) *dtos.EditorJsUploadResponse {
+	errCode := "UPLOAD_FAILED"
+	if contains(err.Error(), "failed to read file") {
+		errCode = "READ_ERROR"
+	} else if contains(err.Error(), "file size") {
+		errCode = "FILE_TOO_LARGE"
+	} else if contains(err.Error(), "invalid file type") {
+		errCode = "INVALID_FILE_TYPE"
+	} else if contains(err.Error(), "dimensions") {
+		errCode = "IMAGE_TOO_LARGE"
+	} else if contains(err.Error(), "decode image") {
+		errCode = "INVALID_IMAGE"
+	} else if contains(err.Error(), "invalid video") {
+		errCode = "INVALID_VIDEO"
+	} else if contains(err.Error(), "invalid upload key") {
+		errCode = "INVALID_UPLOAD_KEY"
+	} else if contains(err.Error(), "upload not found") {
+		errCode = "UPLOAD_NOT_FOUND"
+	}
+
+	return &dtos.EditorJsUploadResponse{
+		Success: 0,
+		Error: &dtos.EditorJsErrorDetail{
+			Code:    errCode,
+			Message: err.Error(),
+		},
+	}
+}
+
+// register records a stored object in the media library and builds the
+// Editor.js success response.
+func (s *UploadService) register(result *storage.UploadResult, uploader string) *dtos.EditorJsUploadResponse {
+	media, err := s.recordUpload(result, uploader)
+	if err != nil {
+		s.logger.Error("Failed to record upload",
+			logging.F("key", result.Key),
+			logging.F("error", err.Error()),
+		)
 		return &dtos.EditorJsUploadResponse{
 			Success: 0,
 			Error: &dtos.EditorJsErrorDetail{
-				Code:    errCode,
-				Message: err.Error(),
+				Code:    "UPLOAD_FAILED",
+				Message: "Failed to record uploaded file",
 			},
-		}, nil
+		}
 	}
 
 	// Return success response
+	fileInfo := &dtos.EditorJsFileInfo{
+		MediaID: media.ID,
+		URL:     result.URL,
+		Width:   result.Width,
+		Height:  result.Height,
+	}
+	if result.CapturedAt != nil {
+		capturedAt := result.CapturedAt.Format(time.RFC3339)
+		fileInfo.CapturedAt = &capturedAt
+	}
+	if result.Duration != nil || result.VideoCodec != "" {
+		fileInfo.DurationSeconds = result.Duration
+		fileInfo.VideoCodec = result.VideoCodec
+		fileInfo.AudioCodec = result.AudioCodec
+	}
+	if result.Deduplicated {
+		fileInfo.Deduplicated = true
+		fileInfo.BytesSaved = result.Size
+	}
+
 	return &dtos.EditorJsUploadResponse{
 		Success: 1,
-		File: &dtos.EditorJsFileInfo{
-			URL: url,
-		},
+		File:    fileInfo,
+	}
+}
+
+// PresignUpload returns a signed form for uploading a video straight to the
+// bucket, so large files never pass through the API. The declared type and
+// size are validated up front; CompleteUpload re-checks the real file.
+func (s *UploadService) PresignUpload(ctx context.Context, req dtos.PresignUploadRequest) (*dtos.PresignUploadResponse, error) {
+	presigned, err := s.uploader.Presign(ctx, req.Filename, req.ContentType, req.Size)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.PresignUploadResponse{
+		Key:       presigned.Key,
+		URL:       presigned.URL,
+		Fields:    presigned.Fields,
+		MaxSize:   presigned.MaxSize,
+		ExpiresAt: presigned.ExpiresAt.UTC().Format(time.RFC3339),
 	}, nil
 }
 
+// CompleteUpload verifies a direct upload made with a PresignUpload form and
+// registers it in the media library, answering like a regular upload.
+func (s *UploadService) CompleteUpload(ctx context.Context, req dtos.CompleteUploadRequest) (*dtos.EditorJsUploadResponse, error) {
+	result, err := s.uploader.CompletePresigned(ctx, req.Key)
+	if err != nil {
+		return uploadFailure(err), nil
+	}
+	return s.register(result, req.Uploader), nil
+}
+
+// recordUpload finds or creates the media library row for a stored object.
+// Keys are content-addressed, so a row for the key means these bytes were
+// uploaded before; that upload is counted on the existing row instead.
+func (s *UploadService) recordUpload(result *storage.UploadResult, uploader string) (*models.Media, error) {
+	existing, err := s.mediaRepo.FindByKey(result.Key)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return s.countRepeatUpload(existing)
+	}
+
+	media := newMediaFromUpload(result, uploader)
+	if err := s.mediaRepo.Create(media); err != nil {
+		// A concurrent upload of the same bytes may have inserted the row
+		// between our lookup and the insert.
+		if existing, ferr := s.mediaRepo.FindByKey(result.Key); ferr == nil && existing != nil {
+			return s.countRepeatUpload(existing)
+		}
+		// Without the row nothing would ever know about this object, so undo
+		// the upload rather than leave an untracked file in the bucket. A
+		// reused object predates this request and is left alone.
+		if !result.Deduplicated {
+			if derr := s.objects.Delete(context.Background(), result.Key); derr != nil {
+				s.logger.Error("Failed to remove untracked upload",
+					logging.F("key", result.Key),
+					logging.F("error", derr.Error()),
+				)
+			}
+		}
+		return nil, err
+	}
+	return media, nil
+}
+
+func (s *UploadService) countRepeatUpload(media *models.Media) (*models.Media, error) {
+	if err := s.mediaRepo.IncrementUploadCount(media.ID); err != nil {
+		return nil, err
+	}
+	media.UploadCount++
+	return media, nil
+}
+
+// MaxUploadBytes is the largest file storage will accept, for callers that
+// want to cap the request body before it reaches the service.
+func (s *UploadService) MaxUploadBytes() int64 {
+	return s.uploader.MaxUploadBytes()
+}
+
+// newMediaFromUpload builds the media library row for a stored object.
+func newMediaFromUpload(result *storage.UploadResult, uploader string) *models.Media {
+	media := &models.Media{
+		Key:        result.Key,
+		URL:        result.URL,
+		MimeType:   result.ContentType,
+		Size:       result.Size,
+		SHA256:     result.SHA256,
+		CapturedAt: result.CapturedAt,
+	}
+	if result.Width > 0 && result.Height > 0 {
+		width, height := result.Width, result.Height
+		media.Width = &width
+		media.Height = &height
+	}
+	media.DurationSeconds = result.Duration
+	if result.VideoCodec != "" {
+		videoCodec := result.VideoCodec
+		media.VideoCodec = &videoCodec
+	}
+	if result.AudioCodec != "" {
+		audioCodec := result.AudioCodec
+		media.AudioCodec = &audioCodec
+	}
+	if trimmed := strings.TrimSpace(uploader); trimmed != "" {
+		media.Uploader = &trimmed
+	}
+	return media
+}
+
 // contains checks if a string contains a substring (case-insensitive)
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
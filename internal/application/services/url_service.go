@@ -2,158 +2,327 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/davidrdsilva/blog-api/config"
 	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/application/mappers"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/fetcher"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
 )
 
+// previewPurgeInterval is how often expired cache rows are cleared, piggy-
+// backed on cache writes.
+const previewPurgeInterval = time.Hour
+
 // URLService handles URL metadata fetching
-type URLService struct{}
+type URLService struct {
+	fetcher    *fetcher.Fetcher
+	cache      repositories.LinkPreviewRepository
+	cacheTTL   time.Duration
+	maxBytes   int64
+	embedHosts map[string]bool
+	logger     *logging.Logger
+
+	purgeMu   sync.Mutex
+	lastPurge time.Time
+}
 
-// NewURLService creates a new URL service
-func NewURLService() *URLService {
-	return &URLService{}
+// NewURLService creates a new URL service. fetcher must be the SSRF-safe
+// client; cache stores results for cfg.CacheTTLMinutes.
+func NewURLService(
+	fetcher *fetcher.Fetcher,
+	cache repositories.LinkPreviewRepository,
+	cfg config.LinkPreviewConfig,
+	logger *logging.Logger,
+) *URLService {
+	embedHosts := make(map[string]bool, len(cfg.EmbedHosts))
+	for _, host := range cfg.EmbedHosts {
+		embedHosts[strings.ToLower(strings.TrimSpace(host))] = true
+	}
+	return &URLService{
+		fetcher:    fetcher,
+		cache:      cache,
+		cacheTTL:   time.Duration(cfg.CacheTTLMinutes) * time.Minute,
+		maxBytes:   int64(cfg.MaxBodyKB) << 10,
+		embedHosts: embedHosts,
+		logger:     logger,
+	}
 }
 
-// FetchURLMetadata fetches metadata from a URL for Editor.js Link Tool
-func (s *URLService) FetchURLMetadata(targetURL string) (*dtos.EditorJsURLResponse, error) {
+// FetchURLMetadata fetches metadata from a URL for Editor.js Link Tool. A
+// fresh cached result is returned without contacting the remote host.
+func (s *URLService) FetchURLMetadata(ctx context.Context, targetURL string) (*dtos.EditorJsURLResponse, error) {
 	// Validate URL
 	parsedURL, err := url.Parse(targetURL)
-	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
-		return &dtos.EditorJsURLResponse{
-			Success: 0,
-			Error: &dtos.EditorJsErrorDetail{
-				Code:    "INVALID_URL",
-				Message: "URL parameter is missing or malformed",
-			},
-		}, nil
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return urlFailure("INVALID_URL", "URL parameter is missing or malformed"), nil
 	}
+	// The fragment never reaches the server, so it doesn't distinguish pages.
+	parsedURL.Fragment = ""
+	cacheKey := parsedURL.String()
 
-	// Create HTTP client with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, "GET", targetURL, nil)
-	if err != nil {
-		return &dtos.EditorJsURLResponse{
-			Success: 0,
-			Error: &dtos.EditorJsErrorDetail{
-				Code:    "URL_NOT_ACCESSIBLE",
-				Message: "Unable to fetch metadata from the provided URL",
-			},
-		}, nil
+	if s.cacheTTL > 0 {
+		cached, err := s.cache.FindFresh(cacheKey, time.Now())
+		if err != nil {
+			s.logger.Warn("Link preview cache lookup failed",
+				logging.F("url", cacheKey),
+				logging.F("error", err.Error()),
+			)
+		} else if cached != nil {
+			// Rows cached before the player allow-list may hold provider
+			// markup as-is.
+			s.sanitizeEmbed(cached.Metadata.Embed)
+			return &dtos.EditorJsURLResponse{
+				Success: 1,
+				Link:    targetURL,
+				Meta:    mappers.ToURLMetadata(cached.Metadata, targetURL),
+			}, nil
+		}
 	}
 
-	// Set user agent to avoid some anti-bot protections
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BlogAPI/1.0; +http://example.com/bot)")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.fetcher.Get(ctx, cacheKey)
 	if err != nil {
 		errCode := "URL_NOT_ACCESSIBLE"
-		if ctx.Err() == context.DeadlineExceeded {
-			errCode = "REQUEST_TIMEOUT"
+		message := fmt.Sprintf("Unable to fetch the URL: %v", err)
+		switch {
+		case errors.Is(err, fetcher.ErrInvalidURL):
+			errCode, message = "INVALID_URL", "URL parameter is missing or malformed"
+		case errors.Is(err, fetcher.ErrBlockedAddress):
+			errCode, message = "URL_NOT_ALLOWED", "URL points to a private or reserved address"
+		case errors.Is(err, fetcher.ErrTooManyRedirects):
+			message = "URL redirected too many times"
+		case errors.Is(err, context.DeadlineExceeded) || isTimeout(err):
+			errCode, message = "REQUEST_TIMEOUT", "Timed out fetching the URL"
 		}
-		return &dtos.EditorJsURLResponse{
-			Success: 0,
-			Error: &dtos.EditorJsErrorDetail{
-				Code:    errCode,
-				Message: fmt.Sprintf("Unable to fetch the URL: %v", err),
-			},
-		}, nil
+		return urlFailure(errCode, message), nil
 	}
 	defer resp.Body.Close()
 
 	// Check response status
 	if resp.StatusCode != http.StatusOK {
-		return &dtos.EditorJsURLResponse{
-			Success: 0,
-			Error: &dtos.EditorJsErrorDetail{
-				Code:    "URL_NOT_ACCESSIBLE",
-				Message: fmt.Sprintf("URL returned status code: %d", resp.StatusCode),
-			},
-		}, nil
+		return urlFailure("URL_NOT_ACCESSIBLE", fmt.Sprintf("URL returned status code: %d", resp.StatusCode)), nil
 	}
 
-	// Parse HTML to extract metadata
-	metadata, err := s.extractMetadata(resp.Body)
-	if err != nil {
-		return &dtos.EditorJsURLResponse{
-			Success: 0,
-			Error: &dtos.EditorJsErrorDetail{
-				Code:    "PARSE_ERROR",
-				Message: "Failed to parse URL metadata",
-			},
-		}, nil
+	// Non-HTML targets (PDFs, images, ...) have no metadata to extract; the
+	// link still previews, just without a card.
+	metadata := models.LinkMetadata{}
+	contentType := resp.Header.Get("Content-Type")
+	if isHTML(contentType) {
+		// Pages are read only up to the cap: everything the preview needs is
+		// in <head>, and a truncated document still parses.
+		body, err := charset.NewReader(io.LimitReader(resp.Body, s.maxBytes), contentType)
+		if err != nil {
+			return urlFailure("PARSE_ERROR", "Failed to parse URL metadata"), nil
+		}
+		// Relative URLs resolve against where the page actually came from,
+		// after redirects.
+		var oembedURL string
+		metadata, oembedURL, err = s.extractMetadata(body, resp.Request.URL)
+		if err != nil {
+			return urlFailure("PARSE_ERROR", "Failed to parse URL metadata"), nil
+		}
+
+		if oembedURL != "" {
+			// A failed oEmbed lookup only costs the embed; the card is still
+			// good.
+			if err := s.applyOEmbed(ctx, oembedURL, &metadata); err != nil {
+				s.logger.Warn("oEmbed lookup failed",
+					logging.F("url", oembedURL),
+					logging.F("error", err.Error()),
+				)
+			}
+		}
+	}
+
+	if s.cacheTTL > 0 {
+		s.savePreview(cacheKey, metadata)
 	}
 
 	return &dtos.EditorJsURLResponse{
 		Success: 1,
 		Link:    targetURL,
-		Meta:    metadata,
+		Meta:    mappers.ToURLMetadata(metadata, targetURL),
 	}, nil
 }
 
-// extractMetadata parses HTML and extracts Open Graph and meta tags
-func (s *URLService) extractMetadata(body io.Reader) (*dtos.URLMetadata, error) {
+// savePreview caches a successful fetch and, at most once per
+// previewPurgeInterval, clears out expired entries. Cache failures are logged
+// and otherwise ignored.
+func (s *URLService) savePreview(cacheKey string, metadata models.LinkMetadata) {
+	now := time.Now()
+	if err := s.cache.Save(&models.LinkPreview{
+		URL:       cacheKey,
+		Metadata:  metadata,
+		FetchedAt: now,
+		ExpiresAt: now.Add(s.cacheTTL),
+	}); err != nil {
+		s.logger.Warn("Failed to cache link preview",
+			logging.F("url", cacheKey),
+			logging.F("error", err.Error()),
+		)
+		return
+	}
+
+	s.purgeMu.Lock()
+	due := now.Sub(s.lastPurge) >= previewPurgeInterval
+	if due {
+		s.lastPurge = now
+	}
+	s.purgeMu.Unlock()
+	if !due {
+		return
+	}
+	if n, err := s.cache.DeleteExpired(now); err != nil {
+		s.logger.Warn("Failed to purge expired link previews", logging.F("error", err.Error()))
+	} else if n > 0 {
+		s.logger.Info("Purged expired link previews", logging.F("count", n))
+	}
+}
+
+// isHTML reports whether a Content-Type header names an HTML document. A
+// missing header is treated as HTML, as browsers would sniff it.
+func isHTML(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+func urlFailure(code, message string) *dtos.EditorJsURLResponse {
+	return &dtos.EditorJsURLResponse{
+		Success: 0,
+		Error: &dtos.EditorJsErrorDetail{
+			Code:    code,
+			Message: message,
+		},
+	}
+}
+
+// extractMetadata parses HTML and extracts Open Graph, Twitter and standard
+// meta/link tags. URLs are resolved against the document's <base href>, or
+// pageURL when there is none. The oEmbed endpoint advertised by the page, if
+// any, is returned separately for the caller to fetch.
+func (s *URLService) extractMetadata(body io.Reader, pageURL *url.URL) (models.LinkMetadata, string, error) {
 	doc, err := html.Parse(body)
 	if err != nil {
-		return nil, err
+		return models.LinkMetadata{}, "", err
 	}
 
-	metadata := &dtos.URLMetadata{}
-	var f func(*html.Node)
+	// Collect raw values first; <base> can appear after the tags it affects
+	// are seen, and lower-priority sources only apply if higher ones are
+	// missing.
+	var (
+		title, ogTitle                     string
+		ogDescription, description, twDesc string
+		ogImage, twImage                   string
+		ogSiteName, appName                string
+		iconHref, touchIconHref            string
+		canonicalHref, ogURL               string
+		author, articleAuthor              string
+		publishedTime, itemPublished       string
+		baseHref, oembedHref               string
+	)
+	setOnce := func(dst *string, v string) {
+		if *dst == "" {
+			*dst = strings.TrimSpace(v)
+		}
+	}
 
+	var f func(*html.Node)
 	f = func(n *html.Node) {
 		if n.Type == html.ElementNode {
 			switch n.Data {
 			case "title":
 				// Extract title text
-				if n.FirstChild != nil && metadata.Title == "" {
-					metadata.Title = n.FirstChild.Data
+				if n.FirstChild != nil {
+					setOnce(&title, n.FirstChild.Data)
 				}
+			case "base":
+				setOnce(&baseHref, attr(n, "href"))
 			case "meta":
-				// Extract meta tags
-				var property, name, content string
-				for _, attr := range n.Attr {
-					switch attr.Key {
-					case "property":
-						property = attr.Val
-					case "name":
-						name = attr.Val
-					case "content":
-						content = attr.Val
-					}
+				property := strings.ToLower(attr(n, "property"))
+				name := strings.ToLower(attr(n, "name"))
+				content := attr(n, "content")
+				if content == "" {
+					break
 				}
-
-				// Open Graph tags take priority
 				switch property {
 				case "og:title":
-					if content != "" {
-						metadata.Title = content
-					}
+					setOnce(&ogTitle, content)
 				case "og:description":
-					if content != "" {
-						metadata.Description = content
-					}
-				case "og:image":
-					if content != "" {
-						metadata.Image = &dtos.URLImageInfo{URL: content}
-					}
+					setOnce(&ogDescription, content)
+				case "og:image", "og:image:url", "og:image:secure_url":
+					setOnce(&ogImage, content)
+				case "og:site_name":
+					setOnce(&ogSiteName, content)
+				case "og:url":
+					setOnce(&ogURL, content)
+				case "article:author":
+					setOnce(&articleAuthor, content)
+				case "article:published_time":
+					setOnce(&publishedTime, content)
+				case "twitter:image":
+					setOnce(&twImage, content)
 				}
-
-				// Fallback to standard meta tags
-				if name == "description" && metadata.Description == "" && content != "" {
-					metadata.Description = content
+				switch name {
+				case "description":
+					setOnce(&description, content)
+				case "twitter:description":
+					setOnce(&twDesc, content)
+				case "twitter:image", "twitter:image:src":
+					setOnce(&twImage, content)
+				case "application-name":
+					setOnce(&appName, content)
+				case "author":
+					setOnce(&author, content)
 				}
-				if (name == "twitter:image" || property == "twitter:image") && metadata.Image == nil && content != "" {
-					metadata.Image = &dtos.URLImageInfo{URL: content}
+				if attr(n, "itemprop") == "datePublished" {
+					setOnce(&itemPublished, content)
+				}
+			case "link":
+				href := attr(n, "href")
+				if href == "" {
+					break
+				}
+				rels := strings.Fields(strings.ToLower(attr(n, "rel")))
+				for _, rel := range rels {
+					switch rel {
+					case "icon":
+						setOnce(&iconHref, href)
+					case "apple-touch-icon":
+						setOnce(&touchIconHref, href)
+					case "canonical":
+						setOnce(&canonicalHref, href)
+					case "alternate":
+						switch strings.ToLower(attr(n, "type")) {
+						case "application/json+oembed", "text/json+oembed":
+							setOnce(&oembedHref, href)
+						}
+					}
+				}
+			case "time":
+				if attr(n, "itemprop") == "datePublished" {
+					setOnce(&itemPublished, attr(n, "datetime"))
 				}
 			}
 		}
@@ -165,9 +334,230 @@ func (s *URLService) extractMetadata(body io.Reader) (*dtos.URLMetadata, error)
 
 	f(doc)
 
-	// Trim whitespace from extracted values
-	metadata.Title = strings.TrimSpace(metadata.Title)
-	metadata.Description = strings.TrimSpace(metadata.Description)
+	base := pageURL
+	if baseHref != "" {
+		if u, err := pageURL.Parse(baseHref); err == nil {
+			base = u
+		}
+	}
+
+	metadata := models.LinkMetadata{
+		Title:         firstNonEmpty(ogTitle, title),
+		Description:   firstNonEmpty(ogDescription, description, twDesc),
+		ImageURL:      resolveURL(base, firstNonEmpty(ogImage, twImage)),
+		SiteName:      firstNonEmpty(ogSiteName, appName),
+		FaviconURL:    resolveURL(base, firstNonEmpty(iconHref, touchIconHref, "/favicon.ico")),
+		CanonicalURL:  resolveURL(base, firstNonEmpty(canonicalHref, ogURL)),
+		Author:        firstNonEmpty(author, articleAuthor),
+		PublishedTime: normalizeTimestamp(firstNonEmpty(publishedTime, itemPublished)),
+	}
+	return metadata, resolveURL(base, oembedHref), nil
+}
+
+// oEmbedResponse is the subset of an oEmbed JSON response the preview uses.
+type oEmbedResponse struct {
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	AuthorName   string     `json:"author_name"`
+	ProviderName string     `json:"provider_name"`
+	ThumbnailURL string     `json:"thumbnail_url"`
+	HTML         string     `json:"html"`
+	Width        oEmbedSize `json:"width"`
+	Height       oEmbedSize `json:"height"`
+}
 
-	return metadata, nil
+// oEmbedSize accepts a width/height given as a number or, as some providers
+// do, a string. Anything else (including null) is zero.
+type oEmbedSize int
+
+func (v *oEmbedSize) UnmarshalJSON(b []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return nil
+	}
+	if f, err := n.Float64(); err == nil && f > 0 {
+		*v = oEmbedSize(f)
+	}
+	return nil
+}
+
+// applyOEmbed fetches the page's oEmbed endpoint (through the same guarded
+// fetcher) and fills metadata.Embed, plus any card fields the page's own tags
+// left empty.
+func (s *URLService) applyOEmbed(ctx context.Context, endpoint string, metadata *models.LinkMetadata) error {
+	resp, err := s.fetcher.Get(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oEmbed endpoint returned status code: %d", resp.StatusCode)
+	}
+
+	var oe oEmbedResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, s.maxBytes)).Decode(&oe); err != nil {
+		return fmt.Errorf("invalid oEmbed response: %w", err)
+	}
+	if oe.Type == "" {
+		return errors.New("invalid oEmbed response: missing type")
+	}
+
+	embed := &models.LinkEmbed{
+		Type:     oe.Type,
+		Provider: strings.TrimSpace(oe.ProviderName),
+		Width:    int(oe.Width),
+		Height:   int(oe.Height),
+	}
+	// Only video and rich responses carry markup; photo and link don't. The
+	// provider's markup is never passed on: only its iframe src is kept, and
+	// only for an allowed player.
+	if oe.Type == "video" || oe.Type == "rich" {
+		embed.URL = iframeSrc(oe.HTML, resp.Request.URL)
+	}
+	s.sanitizeEmbed(embed)
+	metadata.Embed = embed
+
+	if metadata.Title == "" {
+		metadata.Title = strings.TrimSpace(oe.Title)
+	}
+	if metadata.Author == "" {
+		metadata.Author = strings.TrimSpace(oe.AuthorName)
+	}
+	if metadata.SiteName == "" {
+		metadata.SiteName = embed.Provider
+	}
+	if metadata.ImageURL == "" {
+		metadata.ImageURL = resolveURL(resp.Request.URL, oe.ThumbnailURL)
+	}
+	return nil
+}
+
+// sanitizeEmbed keeps embed.URL only when it is an https player on an
+// allowed host, and rebuilds embed.HTML as a plain sandboxed iframe of it.
+// Anything else is dropped, leaving just the card.
+func (s *URLService) sanitizeEmbed(embed *models.LinkEmbed) {
+	if embed == nil {
+		return
+	}
+	embed.HTML = ""
+	u, err := url.Parse(embed.URL)
+	if err != nil || u.Scheme != "https" || u.User != nil || !s.embedHosts[strings.ToLower(u.Hostname())] {
+		embed.URL = ""
+		return
+	}
+	embed.URL = u.String()
+
+	iframe := &html.Node{
+		Type:     html.ElementNode,
+		Data:     "iframe",
+		DataAtom: atom.Iframe,
+		Attr: []html.Attribute{
+			{Key: "src", Val: embed.URL},
+			{Key: "sandbox", Val: "allow-scripts allow-same-origin allow-presentation allow-popups"},
+			{Key: "allow", Val: "encrypted-media; fullscreen; picture-in-picture"},
+			{Key: "allowfullscreen"},
+			{Key: "loading", Val: "lazy"},
+			{Key: "frameborder", Val: "0"},
+		},
+	}
+	if embed.Width > 0 && embed.Height > 0 {
+		iframe.Attr = append(iframe.Attr,
+			html.Attribute{Key: "width", Val: strconv.Itoa(embed.Width)},
+			html.Attribute{Key: "height", Val: strconv.Itoa(embed.Height)},
+		)
+	}
+	var buf strings.Builder
+	if err := html.Render(&buf, iframe); err != nil {
+		embed.URL = ""
+		return
+	}
+	embed.HTML = buf.String()
+}
+
+// iframeSrc returns the absolute src of the first <iframe> in an oEmbed HTML
+// snippet, or "" if there is none.
+func iframeSrc(snippet string, base *url.URL) string {
+	if snippet == "" {
+		return ""
+	}
+	nodes, err := html.ParseFragment(strings.NewReader(snippet), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return ""
+	}
+	var find func(*html.Node) string
+	find = func(n *html.Node) string {
+		if n.Type == html.ElementNode && n.Data == "iframe" {
+			return resolveURL(base, attr(n, "src"))
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if src := find(c); src != "" {
+				return src
+			}
+		}
+		return ""
+	}
+	for _, n := range nodes {
+		if src := find(n); src != "" {
+			return src
+		}
+	}
+	return ""
+}
+
+// attr returns the value of an element's attribute, or "".
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// resolveURL resolves ref against base and returns it only if the result is
+// an absolute http(s) URL.
+func resolveURL(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.String()
+}
+
+// publishedTimeLayouts are the formats seen in article:published_time and
+// datePublished beyond strict RFC 3339.
+var publishedTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// normalizeTimestamp rewrites a recognised timestamp as RFC 3339 in its own
+// offset; anything unrecognised is returned unchanged.
+func normalizeTimestamp(v string) string {
+	for _, layout := range publishedTimeLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return v
 }
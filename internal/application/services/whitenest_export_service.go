@@ -0,0 +1,705 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/config"
+	"github.com/davidrdsilva/blog-api/internal/application/dtos"
+	"github.com/davidrdsilva/blog-api/internal/application/jobs"
+	"github.com/davidrdsilva/blog-api/internal/application/mappers"
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/epub"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/storage"
+)
+
+// Matched as substrings by the whitenest handler: errInvalidExportSelection
+// maps to INVALID_EXPORT_SELECTION, errExportArcNotFound to ARC_NOT_FOUND,
+// errEmptyExport to EMPTY_EXPORT and errExportQueueFull to a 503.
+const (
+	errInvalidExportSelection = "invalid export selection"
+	errExportArcNotFound      = "export arc not found"
+	errEmptyExport            = "no chapters in export selection"
+	errExportQueueFull        = "export queue full"
+)
+
+// exportFormatVersion is part of every fingerprint. Bump it when the book
+// layout changes so existing files are rebuilt instead of reused.
+const exportFormatVersion = 1
+
+// maxExportImageBytes caps a single embedded image. Uploads are far smaller;
+// this only guards against an odd object in storage.
+const maxExportImageBytes = 32 << 20
+
+// exportImageTypes are the image formats EPUB readers must support, with the
+// extension used inside the book.
+var exportImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// WhitenestExportService builds EPUB books of Whitenest chapters in the
+// background. A request resolves the selection to a chapter range and either
+// returns an existing export of the same content or queues a new one for the
+// export worker; the finished file is stored under storage.ExportsPrefix and
+// downloaded from its public URL.
+type WhitenestExportService struct {
+	postRepo         repositories.PostRepository
+	arcRepo          repositories.WhitenestArcRepository
+	characterRepo    repositories.CharacterRepository
+	exportRepo       repositories.WhitenestExportRepository
+	characterService *CharacterService
+	objects          storage.ObjectStorage
+	cfg              config.ExportConfig
+	jobCh            chan<- jobs.BuildWhitenestExportJob
+	logger           *logging.Logger
+}
+
+func NewWhitenestExportService(
+	postRepo repositories.PostRepository,
+	arcRepo repositories.WhitenestArcRepository,
+	characterRepo repositories.CharacterRepository,
+	exportRepo repositories.WhitenestExportRepository,
+	characterService *CharacterService,
+	objects storage.ObjectStorage,
+	cfg config.ExportConfig,
+	jobCh chan<- jobs.BuildWhitenestExportJob,
+	logger *logging.Logger,
+) *WhitenestExportService {
+	return &WhitenestExportService{
+		postRepo:         postRepo,
+		arcRepo:          arcRepo,
+		characterRepo:    characterRepo,
+		exportRepo:       exportRepo,
+		characterService: characterService,
+		objects:          objects,
+		cfg:              cfg,
+		jobCh:            jobCh,
+		logger:           logger,
+	}
+}
+
+// exportSelection is a request resolved against the current chapters.
+type exportSelection struct {
+	arcID    *string
+	from, to int
+	// latest is the last released chapter.
+	latest   int
+	chapters []*models.Post
+	arcs     []*models.WhitenestArc
+}
+
+// RequestExport returns the export for the selected chapters, queueing a
+// build unless one with the same content is already ready or in progress.
+func (s *WhitenestExportService) RequestExport(req dtos.WhitenestExportRequest) (*dtos.WhitenestExportResponse, error) {
+	sel, err := s.resolveSelection(req)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := s.fingerprint(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.exportRepo.FindLiveByFingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		resp := s.toResponse(existing)
+		return &resp, nil
+	}
+
+	export := &models.WhitenestExport{
+		Status:       models.ExportStatusPending,
+		ArcID:        sel.arcID,
+		FromChapter:  sel.from,
+		ToChapter:    sel.to,
+		Fingerprint:  fingerprint,
+		ChapterCount: len(sel.chapters),
+	}
+	if err := s.exportRepo.Create(export); err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.jobCh <- jobs.BuildWhitenestExportJob{ExportID: export.ID}:
+	default:
+		s.fail(export, errExportQueueFull)
+		return nil, fmt.Errorf("%s: try again later", errExportQueueFull)
+	}
+
+	resp := s.toResponse(export)
+	return &resp, nil
+}
+
+// GetExport returns an export's state. Returns (nil, nil) when no export has
+// that ID.
+func (s *WhitenestExportService) GetExport(id string) (*dtos.WhitenestExportResponse, error) {
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("invalid UUID format")
+	}
+	export, err := s.exportRepo.FindByID(id)
+	if err != nil || export == nil {
+		return nil, err
+	}
+	resp := s.toResponse(export)
+	return &resp, nil
+}
+
+// FailInterrupted marks exports left pending or running by a previous
+// process as failed. The job queue lives in memory, so they would otherwise
+// never finish and block their fingerprint from being rebuilt.
+func (s *WhitenestExportService) FailInterrupted() {
+	n, err := s.exportRepo.FailUnfinished("interrupted by a server restart")
+	if err != nil {
+		s.logger.Error("Failed to clean up unfinished exports", logging.F("error", err.Error()))
+		return
+	}
+	if n > 0 {
+		s.logger.Warn("Marked interrupted exports as failed", logging.F("count", n))
+	}
+}
+
+func (s *WhitenestExportService) toResponse(e *models.WhitenestExport) dtos.WhitenestExportResponse {
+	var downloadURL string
+	if e.Status == models.ExportStatusReady && e.ObjectKey != nil {
+		downloadURL = s.objects.PublicURL(*e.ObjectKey)
+	}
+	return mappers.ToWhitenestExportResponse(e, downloadURL)
+}
+
+func (s *WhitenestExportService) resolveSelection(req dtos.WhitenestExportRequest) (*exportSelection, error) {
+	if req.ArcID != nil && (req.From != nil || req.To != nil) {
+		return nil, fmt.Errorf("%s: arc_id can't be combined with from/to", errInvalidExportSelection)
+	}
+	if req.From != nil && req.To != nil && *req.From > *req.To {
+		return nil, fmt.Errorf("%s: from is after to", errInvalidExportSelection)
+	}
+
+	posts, err := s.postRepo.ListWhitenestChapters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chapters: %w", err)
+	}
+	// Queued chapters aren't out yet; books hold released chapters only.
+	posts = releasedChapters(posts)
+	arcs, err := s.arcRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list arcs: %w", err)
+	}
+
+	sel := &exportSelection{arcs: arcs}
+	if len(posts) > 0 {
+		sel.latest = *posts[len(posts)-1].WhitenestChapterNumber
+	}
+	if req.ArcID != nil {
+		_, byArc := groupChaptersByArc(arcs, posts)
+		found := false
+		for i, arc := range arcs {
+			if arc.ID == *req.ArcID {
+				sel.arcID = &arc.ID
+				sel.chapters = byArc[i]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s: %s", errExportArcNotFound, *req.ArcID)
+		}
+	} else {
+		for _, p := range posts {
+			n := *p.WhitenestChapterNumber
+			if (req.From == nil || n >= *req.From) && (req.To == nil || n <= *req.To) {
+				sel.chapters = append(sel.chapters, p)
+			}
+		}
+	}
+
+	if len(sel.chapters) == 0 {
+		return nil, fmt.Errorf("%s", errEmptyExport)
+	}
+	sel.from = *sel.chapters[0].WhitenestChapterNumber
+	sel.to = *sel.chapters[len(sel.chapters)-1].WhitenestChapterNumber
+	return sel, nil
+}
+
+// fingerprint hashes everything that ends up in the book: the chapters and
+// arcs involved, the cast and their state, the book metadata and the layout
+// version. Any edit changes it, so a stale file is never handed out.
+func (s *WhitenestExportService) fingerprint(sel *exportSelection) (string, error) {
+	type chapterKey struct {
+		ID      string    `json:"id"`
+		Number  int       `json:"n"`
+		Updated time.Time `json:"u"`
+	}
+	type arcKey struct {
+		ID       string    `json:"id"`
+		Start    int       `json:"s"`
+		Updated  time.Time `json:"u"`
+		Selected bool      `json:"sel"`
+	}
+	type characterKey struct {
+		ID      string    `json:"id"`
+		Updated time.Time `json:"u"`
+	}
+
+	in := make(map[string]bool, len(sel.chapters))
+	key := struct {
+		Version     int                          `json:"v"`
+		Book        config.ExportConfig          `json:"book"`
+		ArcID       *string                      `json:"arc"`
+		Chapters    []chapterKey                 `json:"chapters"`
+		Arcs        []arcKey                     `json:"arcs"`
+		Characters  []characterKey               `json:"characters"`
+		Appearances []models.CharacterAppearance `json:"appearances"`
+	}{Version: exportFormatVersion, Book: s.cfg, ArcID: sel.arcID}
+
+	for _, p := range sel.chapters {
+		in[p.ID] = true
+		key.Chapters = append(key.Chapters, chapterKey{p.ID, *p.WhitenestChapterNumber, p.UpdatedAt})
+	}
+	for _, a := range sel.arcs {
+		key.Arcs = append(key.Arcs, arcKey{a.ID, a.StartChapter, a.UpdatedAt, sel.arcID != nil && *sel.arcID == a.ID})
+	}
+
+	appearances, err := s.characterRepo.FindAllAppearances()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch appearances: %w", err)
+	}
+	cast := map[string]bool{}
+	for _, a := range appearances {
+		if in[a.PostID] {
+			cast[a.CharacterID] = true
+		}
+	}
+	// Earlier chapters' state overrides shape the appendix too.
+	for _, a := range appearances {
+		if cast[a.CharacterID] && a.ChapterNumber <= sel.to {
+			key.Appearances = append(key.Appearances, a)
+		}
+	}
+	characters, err := s.characterRepo.FindAll(models.CharacterFilters{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list characters: %w", err)
+	}
+	for _, c := range characters {
+		if cast[c.ID] {
+			key.Characters = append(key.Characters, characterKey{c.ID, c.UpdatedAt})
+		}
+	}
+
+	raw, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint export: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Build generates the EPUB for a pending export and stores it. Exports that
+// are no longer pending are skipped. The selection is re-read, so chapters
+// edited while the job was queued are exported as they are now.
+func (s *WhitenestExportService) Build(ctx context.Context, id string) error {
+	export, err := s.exportRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if export == nil || export.Status != models.ExportStatusPending {
+		return nil
+	}
+	export.Status = models.ExportStatusRunning
+	if err := s.exportRepo.Save(export); err != nil {
+		return err
+	}
+
+	if err := s.build(ctx, export); err != nil {
+		s.fail(export, err.Error())
+		return err
+	}
+
+	s.removeSuperseded(ctx, export)
+	return nil
+}
+
+func (s *WhitenestExportService) build(ctx context.Context, export *models.WhitenestExport) error {
+	req := dtos.WhitenestExportRequest{ArcID: export.ArcID}
+	if export.ArcID == nil {
+		req.From, req.To = &export.FromChapter, &export.ToChapter
+	}
+	sel, err := s.resolveSelection(req)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := s.fingerprint(sel)
+	if err != nil {
+		return err
+	}
+
+	book, slug, err := s.assemble(ctx, export, sel)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%swhitenest/%s/%s.epub", storage.ExportsPrefix, export.ID, slug)
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(book.Write(pw))
+	}()
+	size, err := s.objects.Put(ctx, key, pr, -1, storage.PutOptions{ContentType: epub.MediaType})
+	pr.Close()
+	if err != nil {
+		return fmt.Errorf("failed to store epub: %w", err)
+	}
+
+	now := time.Now()
+	export.Status = models.ExportStatusReady
+	export.FromChapter, export.ToChapter = sel.from, sel.to
+	export.ChapterCount = len(sel.chapters)
+	export.Fingerprint = fingerprint
+	export.ObjectKey = &key
+	export.Size = size
+	export.CompletedAt = &now
+	return s.exportRepo.Save(export)
+}
+
+func (s *WhitenestExportService) fail(export *models.WhitenestExport, reason string) {
+	now := time.Now()
+	export.Status = models.ExportStatusFailed
+	export.Error = &reason
+	export.CompletedAt = &now
+	if err := s.exportRepo.Save(export); err != nil {
+		s.logger.Error("Failed to mark export as failed",
+			logging.F("exportId", export.ID),
+			logging.F("error", err.Error()),
+		)
+	}
+}
+
+// removeSuperseded deletes older ready exports of the same selection, whose
+// content has since changed, along with their files.
+func (s *WhitenestExportService) removeSuperseded(ctx context.Context, export *models.WhitenestExport) {
+	older, err := s.exportRepo.FindReadyBySelection(export.ArcID, export.FromChapter, export.ToChapter)
+	if err != nil {
+		s.logger.Warn("Failed to list superseded exports", logging.F("error", err.Error()))
+		return
+	}
+	for _, old := range older {
+		if old.ID == export.ID {
+			continue
+		}
+		if old.ObjectKey != nil {
+			if err := s.objects.Delete(ctx, *old.ObjectKey); err != nil {
+				s.logger.Warn("Failed to delete superseded export file",
+					logging.F("exportId", old.ID),
+					logging.F("error", err.Error()),
+				)
+				continue
+			}
+		}
+		if err := s.exportRepo.Delete(old.ID); err != nil {
+			s.logger.Warn("Failed to delete superseded export",
+				logging.F("exportId", old.ID),
+				logging.F("error", err.Error()),
+			)
+		}
+	}
+}
+
+// assemble lays out the book: cover, chapters (with a title page opening
+// each arc), and the cast appendix. It also returns the file name slug.
+func (s *WhitenestExportService) assemble(ctx context.Context, export *models.WhitenestExport, sel *exportSelection) (*epub.Book, string, error) {
+	images := &exportImages{ctx: ctx, objects: s.objects, byURL: map[string]string{}, logger: s.logger}
+
+	book := &epub.Book{
+		Identifier: "urn:uuid:" + export.ID,
+		Title:      s.cfg.Title,
+		Author:     s.cfg.Author,
+		Language:   s.cfg.Language,
+		Modified:   time.Now(),
+		Stylesheet: exportStylesheet,
+	}
+	slug := "whitenest"
+
+	ungrouped, byArc := groupChaptersByArc(sel.arcs, sel.chapters)
+	if sel.arcID != nil {
+		for i, arc := range sel.arcs {
+			if arc.ID == *sel.arcID {
+				book.Title = fmt.Sprintf("%s: %s", s.cfg.Title, arc.Title)
+				slug = fmt.Sprintf("whitenest-arc-%d", i+1)
+			}
+		}
+	} else if !s.isWholeSerial(sel) {
+		book.Title = fmt.Sprintf("%s: Chapters %d–%d", s.cfg.Title, sel.from, sel.to)
+		slug = fmt.Sprintf("whitenest-chapters-%d-%d", sel.from, sel.to)
+	}
+
+	if cover, ok := images.load(sel.chapters[0].Image, "images/cover"); ok {
+		cover.ID = "cover-image"
+		book.Cover = cover
+	}
+
+	for _, p := range ungrouped {
+		book.Documents = append(book.Documents, chapterDocument(p, images.resolve))
+		book.TOC = append(book.TOC, chapterNavPoint(p))
+	}
+	for i, arc := range sel.arcs {
+		if len(byArc[i]) == 0 {
+			continue
+		}
+		doc := arcDocument(arc, i+1, images.resolve)
+		point := epub.NavPoint{Title: arc.Title, Href: doc.Href}
+		book.Documents = append(book.Documents, doc)
+		for _, p := range byArc[i] {
+			book.Documents = append(book.Documents, chapterDocument(p, images.resolve))
+			point.Children = append(point.Children, chapterNavPoint(p))
+		}
+		book.TOC = append(book.TOC, point)
+	}
+
+	appendix, err := s.castAppendix(sel, images.resolve)
+	if err != nil {
+		return nil, "", err
+	}
+	if appendix != nil {
+		book.Documents = append(book.Documents, *appendix)
+		book.TOC = append(book.TOC, epub.NavPoint{Title: appendix.Title, Href: appendix.Href})
+	}
+
+	book.Images = images.list
+	return book, slug, nil
+}
+
+// isWholeSerial reports whether the range covers every released chapter.
+func (s *WhitenestExportService) isWholeSerial(sel *exportSelection) bool {
+	return sel.from == 1 && sel.to == sel.latest
+}
+
+func chapterDocument(p *models.Post, resolve epub.ImageResolver) epub.Document {
+	n := *p.WhitenestChapterNumber
+	var body strings.Builder
+	body.WriteString("<section class=\"chapter\">\n")
+	fmt.Fprintf(&body, "<p class=\"chapter-number\">%d</p>\n<h1>%s</h1>\n", n, epub.Escape(p.Title))
+	if p.Subtitle != nil && *p.Subtitle != "" {
+		fmt.Fprintf(&body, "<p class=\"subtitle\">%s</p>\n", epub.Escape(*p.Subtitle))
+	}
+	body.WriteString(epub.RenderBlocks(p.Content, resolve))
+	body.WriteString("</section>\n")
+	return epub.Document{
+		ID:    fmt.Sprintf("chapter-%04d", n),
+		Href:  fmt.Sprintf("chapter-%04d.xhtml", n),
+		Title: p.Title,
+		Type:  "chapter",
+		Body:  body.String(),
+	}
+}
+
+func chapterNavPoint(p *models.Post) epub.NavPoint {
+	return epub.NavPoint{
+		Title: fmt.Sprintf("%d. %s", *p.WhitenestChapterNumber, p.Title),
+		Href:  fmt.Sprintf("chapter-%04d.xhtml", *p.WhitenestChapterNumber),
+	}
+}
+
+// arcDocument is the title page opening an arc. number is the arc's position
+// in reading order.
+func arcDocument(arc *models.WhitenestArc, number int, resolve epub.ImageResolver) epub.Document {
+	var body strings.Builder
+	body.WriteString("<section class=\"arc\">\n")
+	fmt.Fprintf(&body, "<h1>%s</h1>\n", epub.Escape(arc.Title))
+	if arc.Image != nil {
+		if href, ok := resolve(*arc.Image); ok {
+			fmt.Fprintf(&body, "<figure>\n<img src=\"%s\" alt=\"%s\"/>\n</figure>\n", epub.Escape(href), epub.Escape(arc.Title))
+		}
+	}
+	if arc.Synopsis != nil {
+		body.WriteString(epub.Paragraphs(*arc.Synopsis))
+	}
+	body.WriteString("</section>\n")
+	return epub.Document{
+		ID:    fmt.Sprintf("arc-%02d", number),
+		Href:  fmt.Sprintf("arc-%02d.xhtml", number),
+		Title: arc.Title,
+		Type:  "part",
+		Body:  body.String(),
+	}
+}
+
+// castAppendix describes every character cast in the exported chapters as of
+// the last one, so the appendix carries no spoilers beyond the book. Returns
+// nil when the chapters have no cast.
+func (s *WhitenestExportService) castAppendix(sel *exportSelection, resolve epub.ImageResolver) (*epub.Document, error) {
+	in := make(map[string]bool, len(sel.chapters))
+	for _, p := range sel.chapters {
+		in[p.ID] = true
+	}
+	appearances, err := s.characterRepo.FindAllAppearances()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch appearances: %w", err)
+	}
+	chapters := map[string][]int{}
+	for _, a := range appearances {
+		if in[a.PostID] {
+			chapters[a.CharacterID] = append(chapters[a.CharacterID], a.ChapterNumber)
+		}
+	}
+	if len(chapters) == 0 {
+		return nil, nil
+	}
+
+	characters, err := s.characterService.ListCharacters("", sel.to)
+	if err != nil {
+		return nil, err
+	}
+
+	var body strings.Builder
+	body.WriteString("<section class=\"cast\">\n<h1>Cast</h1>\n")
+	for _, c := range characters {
+		numbers := chapters[c.ID]
+		if len(numbers) == 0 {
+			continue
+		}
+		body.WriteString("<section class=\"character\">\n")
+		fmt.Fprintf(&body, "<h2>%s</h2>\n", epub.Escape(c.ShortName))
+		if c.FullName != "" && c.FullName != c.ShortName {
+			fmt.Fprintf(&body, "<p class=\"full-name\">%s</p>\n", epub.Escape(c.FullName))
+		}
+		if href, ok := resolve(c.Portrait); ok {
+			fmt.Fprintf(&body, "<figure class=\"portrait\">\n<img src=\"%s\" alt=\"%s\"/>\n</figure>\n", epub.Escape(href), epub.Escape(c.ShortName))
+		}
+		body.WriteString("<dl>\n")
+		writeDefinition(&body, "Occupation", c.Occupation)
+		writeDefinition(&body, "Location", c.Location)
+		writeDefinition(&body, "Appears in", appearanceRange(numbers))
+		body.WriteString("</dl>\n")
+		body.WriteString(epub.Paragraphs(c.Description))
+		fmt.Fprintf(&body, "<table class=\"skills\">\n<tr><th>Melee</th><td>%d</td></tr>\n<tr><th>Guns</th><td>%d</td></tr>\n<tr><th>Stealth</th><td>%d</td></tr>\n<tr><th>Persuasion</th><td>%d</td></tr>\n<tr><th>Intellect</th><td>%d</td></tr>\n<tr><th>Endurance</th><td>%d</td></tr>\n</table>\n",
+			c.Skills.Melee, c.Skills.Guns, c.Skills.Stealth, c.Skills.Persuasion, c.Skills.Intellect, c.Skills.Endurance)
+		for _, section := range c.Sections {
+			fmt.Fprintf(&body, "<h3>%s</h3>\n", epub.Escape(section.Title))
+			body.WriteString(epub.Paragraphs(section.Body))
+		}
+		body.WriteString("</section>\n")
+	}
+	body.WriteString("</section>\n")
+
+	return &epub.Document{
+		ID:    "cast",
+		Href:  "cast.xhtml",
+		Title: "Cast",
+		Type:  "appendix",
+		Body:  body.String(),
+	}, nil
+}
+
+func writeDefinition(sb *strings.Builder, term, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(sb, "<dt>%s</dt><dd>%s</dd>\n", epub.Escape(term), epub.Escape(value))
+}
+
+// appearanceRange describes the chapters (ascending) a character is cast in.
+func appearanceRange(numbers []int) string {
+	if len(numbers) == 1 {
+		return fmt.Sprintf("Chapter %d", numbers[0])
+	}
+	return fmt.Sprintf("Chapters %d–%d (%d chapters)", numbers[0], numbers[len(numbers)-1], len(numbers))
+}
+
+// exportImages embeds images from object storage into a book, each URL once.
+// External and missing images aren't embedded: EPUB readers won't load
+// remote images, so the renderer falls back to the caption.
+type exportImages struct {
+	ctx     context.Context
+	objects storage.ObjectStorage
+	byURL   map[string]string
+	list    []epub.Image
+	logger  *logging.Logger
+}
+
+func (im *exportImages) resolve(url string) (string, bool) {
+	if href, ok := im.byURL[url]; ok {
+		return href, href != ""
+	}
+	img, ok := im.load(url, fmt.Sprintf("images/image-%03d", len(im.list)+1))
+	if !ok {
+		im.byURL[url] = ""
+		return "", false
+	}
+	img.ID = fmt.Sprintf("image-%03d", len(im.list)+1)
+	im.list = append(im.list, *img)
+	im.byURL[url] = img.Href
+	return img.Href, true
+}
+
+// load reads the stored image behind url. base is the href without
+// extension; the extension follows the sniffed format.
+func (im *exportImages) load(url, base string) (*epub.Image, bool) {
+	if url == "" || !im.objects.IsTrustedURL(url) {
+		return nil, false
+	}
+	key, ok := im.objects.KeyFromURL(url)
+	if !ok {
+		return nil, false
+	}
+	r, err := im.objects.Get(im.ctx, key)
+	if err != nil {
+		im.logger.Warn("Export: image not embedded",
+			logging.F("key", key),
+			logging.F("error", err.Error()),
+		)
+		return nil, false
+	}
+	defer r.Close()
+	data, err := io.ReadAll(io.LimitReader(r, maxExportImageBytes+1))
+	if err != nil || len(data) > maxExportImageBytes {
+		im.logger.Warn("Export: image not embedded", logging.F("key", key))
+		return nil, false
+	}
+	mediaType := http.DetectContentType(data)
+	ext, ok := exportImageTypes[mediaType]
+	if !ok {
+		return nil, false
+	}
+	return &epub.Image{Href: base + ext, MediaType: mediaType, Data: data}, true
+}
+
+// exportStylesheet keeps the book readable on e-readers and, since chapters
+// start on a new page and images never overflow it, ready for conversion to
+// PDF.
+const exportStylesheet = `body { font-family: serif; line-height: 1.5; margin: 0 5%; }
+h1, h2, h3 { font-family: sans-serif; line-height: 1.2; }
+section.chapter, section.arc, section.cast { page-break-before: always; break-before: page; }
+section.character { page-break-inside: avoid; break-inside: avoid; margin-bottom: 2em; }
+p { margin: 0 0 0.8em; text-align: justify; }
+.chapter-number { font-family: sans-serif; text-align: center; font-size: 2em; margin-top: 2em; }
+section.chapter > h1, section.arc > h1 { text-align: center; margin-bottom: 1.5em; }
+.subtitle { text-align: center; font-style: italic; }
+figure { margin: 1em 0; text-align: center; page-break-inside: avoid; break-inside: avoid; }
+img { max-width: 100%; max-height: 90vh; }
+figure.portrait img { max-width: 40%; }
+figcaption, .caption, .quote-caption { font-size: 0.9em; font-style: italic; text-align: center; }
+blockquote { margin: 1em 2em; }
+pre { white-space: pre-wrap; font-size: 0.85em; }
+hr { border: none; text-align: center; margin: 1.5em 0; }
+hr::after { content: "* * *"; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #999; padding: 0.2em 0.5em; text-align: left; }
+dt { font-weight: bold; }
+dd { margin: 0 0 0.4em 1.5em; }
+.cover { text-align: center; page-break-after: always; break-after: page; }
+.cover img { max-height: 100vh; }
+.warning { border-left: 3px solid #999; padding-left: 1em; margin: 1em 0; }
+.warning-title { font-weight: bold; }
+`
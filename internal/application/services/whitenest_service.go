@@ -1,7 +1,11 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/davidrdsilva/blog-api/internal/application/dtos"
 	"github.com/davidrdsilva/blog-api/internal/application/jobs"
@@ -9,6 +13,8 @@ import (
 	"github.com/davidrdsilva/blog-api/internal/domain/models"
 	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/storage"
+	"gorm.io/gorm"
 )
 
 // errChapterSetMismatch is matched as a substring by the whitenest handler to
@@ -17,26 +23,50 @@ import (
 // expected to re-fetch the chapter list and let the user redo the reorder.
 const errChapterSetMismatch = "chapter set mismatch"
 
+// errInvalidArcStart is matched as a substring by the whitenest handler to map
+// to INVALID_ARC_START.
+const errInvalidArcStart = "invalid arc start"
+
+// errNotInCast is matched as a substring by the whitenest handler to map to
+// CAST_MEMBER_NOT_FOUND.
+const errNotInCast = "character not in chapter cast"
+
+// errScheduleOrder is matched as a substring by the whitenest handler to map
+// to INVALID_SCHEDULE_ORDER: a reorder or move would put a released chapter
+// after a queued one.
+const errScheduleOrder = "schedule order violation"
+
 type WhitenestService struct {
-	postRepo repositories.PostRepository
-	viewCh   chan<- jobs.IncrementPostViewsJob
-	logger   *logging.Logger
+	postRepo      repositories.PostRepository
+	arcRepo       repositories.WhitenestArcRepository
+	characterRepo repositories.CharacterRepository
+	objects       storage.ObjectStorage
+	viewCh        chan<- jobs.IncrementPostViewsJob
+	logger        *logging.Logger
 }
 
 func NewWhitenestService(
 	postRepo repositories.PostRepository,
+	arcRepo repositories.WhitenestArcRepository,
+	characterRepo repositories.CharacterRepository,
+	objects storage.ObjectStorage,
 	viewCh chan<- jobs.IncrementPostViewsJob,
 	logger *logging.Logger,
 ) *WhitenestService {
 	return &WhitenestService{
-		postRepo: postRepo,
-		viewCh:   viewCh,
-		logger:   logger,
+		postRepo:      postRepo,
+		arcRepo:       arcRepo,
+		characterRepo: characterRepo,
+		objects:       objects,
+		viewCh:        viewCh,
+		logger:        logger,
 	}
 }
 
-// Returns (nil, nil) when no chapter has that number.
-func (s *WhitenestService) GetChapterByNumber(number int) (*dtos.WhitenestChapterResponse, error) {
+// Returns (nil, nil) when no chapter has that number. Queued chapters are
+// only returned as a preview, which doesn't count as a view and links queued
+// neighbours too.
+func (s *WhitenestService) GetChapterByNumber(number int, preview bool) (*dtos.WhitenestChapterResponse, error) {
 	if number < 1 {
 		return nil, fmt.Errorf("invalid chapter number: %d", number)
 	}
@@ -45,16 +75,16 @@ func (s *WhitenestService) GetChapterByNumber(number int) (*dtos.WhitenestChapte
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch chapter: %w", err)
 	}
-	if post == nil {
+	if post == nil || (post.IsQueued() && !preview) {
 		return nil, nil
 	}
 
-	previous, next, err := s.postRepo.FindAdjacentWhitenestChapters(number)
+	previous, next, err := s.postRepo.FindAdjacentWhitenestChapters(number, preview)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch adjacent chapters: %w", err)
 	}
 
-	if s.viewCh != nil {
+	if s.viewCh != nil && !preview {
 		select {
 		case s.viewCh <- jobs.IncrementPostViewsJob{PostID: post.ID}:
 		default:
@@ -64,21 +94,384 @@ func (s *WhitenestService) GetChapterByNumber(number int) (*dtos.WhitenestChapte
 		}
 	}
 
+	arcs, err := s.arcRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch arcs: %w", err)
+	}
+	var arcRef *dtos.WhitenestArcRef
+	if i := arcIndexFor(arcs, number); i >= 0 {
+		arcRef = &dtos.WhitenestArcRef{ID: arcs[i].ID, Number: i + 1, Title: arcs[i].Title}
+	}
+
+	cast, err := s.castAsOf(post.Characters, number, preview)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dtos.WhitenestChapterResponse{
 		Chapter:  mappers.ToPostResponse(post),
 		Previous: mappers.ToWhitenestChapterRef(previous),
 		Next:     mappers.ToWhitenestChapterRef(next),
-		Cast:     mappers.ToCharacterResponses(post.Characters),
+		Cast:     cast,
+		Arc:      arcRef,
 	}, nil
 }
 
-// ListChapters returns every Whitenest chapter ordered by chapter number ASC.
-func (s *WhitenestService) ListChapters() ([]dtos.WhitenestChapterSummary, error) {
+// castAsOf pairs each cast member with their state as of chapter number:
+// the character row with every override up to and including that chapter
+// applied in order. Overrides from queued chapters only count with
+// includeQueued.
+func (s *WhitenestService) castAsOf(characters []models.Character, number int, includeQueued bool) ([]dtos.CastMemberResponse, error) {
+	ids := make([]string, len(characters))
+	for i := range characters {
+		ids[i] = characters[i].ID
+	}
+	overrides, err := s.characterRepo.FindStateOverrides(ids, number, includeQueued)
+	if err != nil {
+		return nil, err
+	}
+	byCharacter := make(map[string][]models.CharacterAppearance, len(characters))
+	for _, o := range overrides {
+		byCharacter[o.CharacterID] = append(byCharacter[o.CharacterID], o)
+	}
+
+	out := make([]dtos.CastMemberResponse, len(characters))
+	for i := range characters {
+		c := &characters[i]
+		state := models.BaseState(c)
+		for _, o := range byCharacter[c.ID] {
+			state.Apply(o.ChapterNumber, o.CharacterStateOverride)
+		}
+		out[i] = mappers.ToCastMemberResponse(c, state, number)
+	}
+	return out, nil
+}
+
+// SetCastState replaces the state override of a cast member in chapter
+// number and returns their resulting state. Returns (nil, nil) when no chapter
+// has that number.
+func (s *WhitenestService) SetCastState(number int, characterID string, req dtos.CharacterStateRequest) (*dtos.CastMemberResponse, error) {
+	if !isValidUUID(characterID) {
+		return nil, fmt.Errorf("invalid UUID format")
+	}
+	var override models.CharacterStateOverride
+	if req.Skills != nil {
+		skills := req.Skills.ToModel()
+		if err := skills.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid skills: %w", err)
+		}
+		override.Skills = &skills
+	}
+	override.Occupation = trimmedOrNil(req.Occupation)
+	override.Location = trimmedOrNil(req.Location)
+
+	post, err := s.postRepo.FindWhitenestChapterByNumber(number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chapter: %w", err)
+	}
+	if post == nil {
+		return nil, nil
+	}
+	var member []models.Character
+	for _, c := range post.Characters {
+		if c.ID == characterID {
+			member = append(member, c)
+		}
+	}
+	if len(member) == 0 {
+		return nil, fmt.Errorf("%s: character %s in chapter %d", errNotInCast, characterID, number)
+	}
+
+	if err := s.characterRepo.UpdateCastState(post.ID, characterID, override); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: character %s in chapter %d", errNotInCast, characterID, number)
+		}
+		return nil, err
+	}
+
+	cast, err := s.castAsOf(member, number, post.IsQueued())
+	if err != nil {
+		return nil, err
+	}
+	return &cast[0], nil
+}
+
+// ListChapters returns every released Whitenest chapter ordered by chapter
+// number ASC, grouped by arc; a preview includes the queued ones. Empty arcs
+// are included so the reader sees what's coming. The version always covers
+// the whole chapter set, queued chapters included, as the move and insert
+// operations it guards do.
+func (s *WhitenestService) ListChapters(preview bool) ([]dtos.WhitenestArcGroup, string, error) {
+	all, err := s.postRepo.ListWhitenestChapters()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list chapters: %w", err)
+	}
+	posts := all
+	if !preview {
+		posts = releasedChapters(all)
+	}
+	arcs, err := s.arcRepo.FindAll()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list arcs: %w", err)
+	}
+
+	ungrouped, byArc := groupChaptersByArc(arcs, posts)
+	groups := make([]dtos.WhitenestArcGroup, 0, len(arcs)+1)
+	if len(ungrouped) > 0 {
+		groups = append(groups, dtos.WhitenestArcGroup{
+			Chapters: mappers.ToWhitenestChapterSummaries(ungrouped),
+		})
+	}
+	for i, arc := range arcs {
+		resp := mappers.ToWhitenestArcResponse(arc, i+1, byArc[i])
+		groups = append(groups, dtos.WhitenestArcGroup{
+			Arc:      &resp,
+			Chapters: mappers.ToWhitenestChapterSummaries(byArc[i]),
+		})
+	}
+	return groups, chapterVersion(all), nil
+}
+
+// releasedChapters drops the queued chapters from posts.
+func releasedChapters(posts []*models.Post) []*models.Post {
+	out := make([]*models.Post, 0, len(posts))
+	for _, p := range posts {
+		if !p.IsQueued() {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// chapterVersion is models.ChapterSequenceVersion of chapters listed in
+// number order.
+func chapterVersion(posts []*models.Post) string {
+	items := make([]models.ChapterOrderItem, 0, len(posts))
+	for _, p := range posts {
+		if p.WhitenestChapterNumber != nil {
+			items = append(items, models.ChapterOrderItem{PostID: p.ID, Number: *p.WhitenestChapterNumber})
+		}
+	}
+	return models.ChapterSequenceVersion(items)
+}
+
+// chapterVersionFromETag accepts a version either bare or exactly as sent in
+// the list's ETag header, quotes included.
+func chapterVersionFromETag(v string) string {
+	return strings.Trim(strings.TrimSpace(v), `"`)
+}
+
+// MoveChapter moves chapter `from` to req.To, shifting the chapters in
+// between. Returns (nil, nil) when no chapter has number `from`. A stale
+// req.Version fails with the repository's version mismatch error so the client
+// can refresh the list and retry.
+func (s *WhitenestService) MoveChapter(from int, req dtos.MoveChapterRequest) (*dtos.MoveChapterResponse, error) {
+	current, err := s.postRepo.ListWhitenestChapters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chapters: %w", err)
+	}
+	if err := checkScheduleOrder(movedOrder(current, from, req.To)); err != nil {
+		return nil, err
+	}
+
+	if err := s.postRepo.MoveWhitenestChapter(from, req.To, chapterVersionFromETag(req.Version)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to move chapter: %w", err)
+	}
+
 	posts, err := s.postRepo.ListWhitenestChapters()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list chapters: %w", err)
 	}
-	return mappers.ToWhitenestChapterSummaries(posts), nil
+	resp := &dtos.MoveChapterResponse{Version: chapterVersion(posts)}
+	for _, p := range posts {
+		if p.WhitenestChapterNumber != nil && *p.WhitenestChapterNumber == req.To {
+			resp.Chapter = *mappers.ToWhitenestChapterRef(p)
+			break
+		}
+	}
+	return resp, nil
+}
+
+// ListArcs returns every arc in reading order with the released chapters it
+// covers; a preview counts the queued ones too.
+func (s *WhitenestService) ListArcs(preview bool) ([]dtos.WhitenestArcResponse, error) {
+	arcs, err := s.arcRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list arcs: %w", err)
+	}
+	posts, err := s.postRepo.ListWhitenestChapters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chapters: %w", err)
+	}
+	if !preview {
+		posts = releasedChapters(posts)
+	}
+	_, byArc := groupChaptersByArc(arcs, posts)
+	out := make([]dtos.WhitenestArcResponse, len(arcs))
+	for i, arc := range arcs {
+		out[i] = mappers.ToWhitenestArcResponse(arc, i+1, byArc[i])
+	}
+	return out, nil
+}
+
+func (s *WhitenestService) CreateArc(req dtos.CreateWhitenestArcRequest) (*dtos.WhitenestArcResponse, error) {
+	if err := s.validateArcStart(req.StartChapter); err != nil {
+		return nil, err
+	}
+	if err := s.validateArcImage(req.Image); err != nil {
+		return nil, err
+	}
+	arc := &models.WhitenestArc{
+		Title:        strings.TrimSpace(req.Title),
+		Synopsis:     trimmedOrNil(req.Synopsis),
+		Image:        trimmedOrNil(req.Image),
+		StartChapter: req.StartChapter,
+	}
+	if err := s.arcRepo.Create(arc); err != nil {
+		return nil, fmt.Errorf("failed to create arc: %w", err)
+	}
+	return s.arcResponse(arc.ID)
+}
+
+// Returns (nil, nil) when no arc has that ID.
+func (s *WhitenestService) UpdateArc(id string, req dtos.UpdateWhitenestArcRequest) (*dtos.WhitenestArcResponse, error) {
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("invalid UUID format")
+	}
+	arc, err := s.arcRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch arc: %w", err)
+	}
+	if arc == nil {
+		return nil, nil
+	}
+
+	if req.Title != nil {
+		arc.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Synopsis != nil {
+		arc.Synopsis = trimmedOrNil(req.Synopsis)
+	}
+	if req.Image != nil {
+		if err := s.validateArcImage(req.Image); err != nil {
+			return nil, err
+		}
+		arc.Image = trimmedOrNil(req.Image)
+	}
+	if req.StartChapter != nil {
+		if err := s.validateArcStart(*req.StartChapter); err != nil {
+			return nil, err
+		}
+		arc.StartChapter = *req.StartChapter
+	}
+
+	if err := s.arcRepo.Update(id, arc); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update arc: %w", err)
+	}
+	return s.arcResponse(id)
+}
+
+// validateArcImage accepts an empty image (no cover) or one served by the
+// active storage backend, like a post's cover image.
+func (s *WhitenestService) validateArcImage(image *string) error {
+	url := trimmedOrNil(image)
+	if url == nil || s.objects.IsTrustedURL(*url) {
+		return nil
+	}
+	return fmt.Errorf("invalid image URL: image must be uploaded via /api/upload endpoint")
+}
+
+// DeleteArc removes the arc; its chapters join the preceding arc, or become
+// ungrouped if it was the first.
+func (s *WhitenestService) DeleteArc(id string) error {
+	if !isValidUUID(id) {
+		return fmt.Errorf("invalid UUID format")
+	}
+	if err := s.arcRepo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete arc: %w", err)
+	}
+	return nil
+}
+
+// arcResponse reloads the arc list so the response carries the arc's current
+// number and chapter range.
+func (s *WhitenestService) arcResponse(id string) (*dtos.WhitenestArcResponse, error) {
+	arcs, err := s.ListArcs(true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range arcs {
+		if arcs[i].ID == id {
+			return &arcs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// validateArcStart allows any existing chapter number plus the next one, so
+// an arc can be created ahead of its first chapter.
+func (s *WhitenestService) validateArcStart(start int) error {
+	max, err := s.postRepo.MaxWhitenestChapterNumber()
+	if err != nil {
+		return fmt.Errorf("failed to fetch max chapter number: %w", err)
+	}
+	if start < 1 || start > max+1 {
+		return fmt.Errorf("%s: start_chapter must be between 1 and %d, got %d", errInvalidArcStart, max+1, start)
+	}
+	return nil
+}
+
+// arcIndexFor returns the index of the arc containing chapter number, or -1
+// when the chapter comes before the first arc. arcs must be in reading order;
+// among arcs sharing a start, the last one holds the chapters.
+func arcIndexFor(arcs []*models.WhitenestArc, number int) int {
+	idx := -1
+	for i, arc := range arcs {
+		if arc.StartChapter > number {
+			break
+		}
+		idx = i
+	}
+	return idx
+}
+
+// groupChaptersByArc splits chapters (ordered by number) into the ones before
+// the first arc and one slice per arc, indexed like arcs.
+func groupChaptersByArc(arcs []*models.WhitenestArc, posts []*models.Post) ([]*models.Post, [][]*models.Post) {
+	var ungrouped []*models.Post
+	byArc := make([][]*models.Post, len(arcs))
+	for _, p := range posts {
+		if p.WhitenestChapterNumber == nil {
+			continue
+		}
+		if i := arcIndexFor(arcs, *p.WhitenestChapterNumber); i >= 0 {
+			byArc[i] = append(byArc[i], p)
+		} else {
+			ungrouped = append(ungrouped, p)
+		}
+	}
+	return ungrouped, byArc
+}
+
+// trimmedOrNil trims an optional text field, treating blank as unset.
+func trimmedOrNil(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*v)
+	if t == "" {
+		return nil
+	}
+	return &t
 }
 
 // ReorderChapters validates the request against the current chapter set and,
@@ -101,14 +494,14 @@ func (s *WhitenestService) ReorderChapters(req dtos.ReorderChaptersRequest) erro
 			errChapterSetMismatch, len(current), len(req.Order))
 	}
 
-	currentIDs := make(map[string]struct{}, len(current))
+	byID := make(map[string]*models.Post, len(current))
 	for _, p := range current {
-		currentIDs[p.ID] = struct{}{}
+		byID[p.ID] = p
 	}
 	seen := make(map[string]struct{}, len(req.Order))
 	items := make([]models.ChapterOrderItem, len(req.Order))
 	for i, item := range req.Order {
-		if _, ok := currentIDs[item.PostID]; !ok {
+		if _, ok := byID[item.PostID]; !ok {
 			return fmt.Errorf("%s: post %s is not a Whitenest chapter",
 				errChapterSetMismatch, item.PostID)
 		}
@@ -119,12 +512,43 @@ func (s *WhitenestService) ReorderChapters(req dtos.ReorderChaptersRequest) erro
 		items[i] = models.ChapterOrderItem{PostID: item.PostID, Number: item.Number}
 	}
 
-	if err := s.postRepo.ReorderWhitenestChapters(items); err != nil {
+	reordered := make([]*models.Post, len(items))
+	for _, item := range items {
+		reordered[item.Number-1] = byID[item.PostID]
+	}
+	if err := checkScheduleOrder(reordered); err != nil {
+		return err
+	}
+
+	boundaries, err := validateArcBoundaries(req.Arcs, len(req.Order))
+	if err != nil {
+		return err
+	}
+
+	if err := s.postRepo.ReorderWhitenestChapters(items, boundaries); err != nil {
 		return fmt.Errorf("failed to reorder chapters: %w", err)
 	}
 	return nil
 }
 
+// validateArcBoundaries checks the optional arc moves of a reorder against
+// the chapter count n. Whether the arcs exist is checked by the repository.
+func validateArcBoundaries(arcs []dtos.ArcBoundaryItem, n int) ([]models.ArcBoundary, error) {
+	out := make([]models.ArcBoundary, len(arcs))
+	seen := make(map[string]struct{}, len(arcs))
+	for i, a := range arcs {
+		if _, dup := seen[a.ArcID]; dup {
+			return nil, fmt.Errorf("duplicate arc_id in arcs: %s", a.ArcID)
+		}
+		seen[a.ArcID] = struct{}{}
+		if a.StartChapter < 1 || a.StartChapter > n+1 {
+			return nil, fmt.Errorf("%s: start_chapter must be between 1 and %d, got %d", errInvalidArcStart, n+1, a.StartChapter)
+		}
+		out[i] = models.ArcBoundary{ArcID: a.ArcID, StartChapter: a.StartChapter}
+	}
+	return out, nil
+}
+
 // validateReorder ensures the supplied list covers numbers 1..N exactly once.
 // We require contiguous numbering (rather than allowing gaps) so the resulting
 // state matches what the rest of the system already assumes — chapters are a
@@ -147,3 +571,119 @@ func validateReorder(order []dtos.ChapterOrderItem) error {
 	}
 	return nil
 }
+
+// GetSchedule returns the queued chapters in release order, each with the arc
+// it will fall in.
+func (s *WhitenestService) GetSchedule() ([]dtos.ScheduledChapterResponse, error) {
+	posts, err := s.postRepo.ListWhitenestChapters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chapters: %w", err)
+	}
+	arcs, err := s.arcRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list arcs: %w", err)
+	}
+
+	out := []dtos.ScheduledChapterResponse{}
+	for _, p := range posts {
+		if !p.IsQueued() {
+			continue
+		}
+		var arcRef *dtos.WhitenestArcRef
+		if i := arcIndexFor(arcs, *p.WhitenestChapterNumber); i >= 0 {
+			arcRef = &dtos.WhitenestArcRef{ID: arcs[i].ID, Number: i + 1, Title: arcs[i].Title}
+		}
+		out = append(out, mappers.ToScheduledChapterResponse(p, arcRef))
+	}
+	return out, nil
+}
+
+// ReleaseDue releases the queued chapters that are due and returns how many
+// went live. Called by the release scheduler.
+func (s *WhitenestService) ReleaseDue() (int, error) {
+	released, err := s.postRepo.ReleaseDueWhitenestChapters(time.Now())
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range released {
+		s.logger.Info("Released Whitenest chapter",
+			logging.F("postId", p.ID),
+			logging.F("number", *p.WhitenestChapterNumber),
+		)
+	}
+	return len(released), nil
+}
+
+// releasesBy reports whether chapter p is released, or queued for no later
+// than at. A nil at stands for a chapter released right away.
+func releasesBy(p *models.Post, at *time.Time) bool {
+	return p.ReleaseAt == nil || (at != nil && !p.ReleaseAt.After(*at))
+}
+
+// scheduleSlot returns the chapter number for a chapter releasing at
+// releaseAt (nil: right away): right after the last chapter that is released
+// or queued for no later, so the queue stays in release order. chapters are
+// ordered by number. from is the chapter's current number when it is being
+// rescheduled, 0 for a chapter joining the series.
+func scheduleSlot(chapters []*models.Post, releaseAt *time.Time, from int) int {
+	at := 1
+	for _, p := range chapters {
+		n := *p.WhitenestChapterNumber
+		if n == from || !releasesBy(p, releaseAt) {
+			continue
+		}
+		if from > 0 && n > from {
+			// Taking the chapter out first shifts this one down.
+			at = n
+		} else {
+			at = n + 1
+		}
+	}
+	return at
+}
+
+// movedOrder returns chapters (ordered by number) in the order
+// MoveWhitenestChapter would leave them after moving chapter from to number
+// to. chapters is returned unchanged when there is no chapter from.
+func movedOrder(chapters []*models.Post, from, to int) []*models.Post {
+	var moved *models.Post
+	rest := make([]*models.Post, 0, len(chapters))
+	for _, p := range chapters {
+		if *p.WhitenestChapterNumber == from {
+			moved = p
+		} else {
+			rest = append(rest, p)
+		}
+	}
+	if moved == nil {
+		return chapters
+	}
+	// Moving down, the chapters up to `to` shift below it; moving up, the
+	// ones from `to` on shift above it.
+	idx := 0
+	for _, p := range rest {
+		if n := *p.WhitenestChapterNumber; n < to || (to > from && n == to) {
+			idx++
+		}
+	}
+	return slices.Insert(rest, idx, moved)
+}
+
+// checkScheduleOrder rejects an ordering that puts a released chapter after a
+// queued one: readers would find a gap where the queued chapter sits.
+func checkScheduleOrder(ordered []*models.Post) error {
+	var queued *models.Post
+	for _, p := range ordered {
+		if p.IsQueued() {
+			if queued == nil {
+				queued = p
+			}
+			continue
+		}
+		if queued != nil {
+			return fmt.Errorf("%s: released chapter %q can't come after queued chapter %q",
+				errScheduleOrder, p.Title, queued.Title)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/services"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+)
+
+// ChapterReleaseWorker releases queued Whitenest chapters as they fall due,
+// checking once per interval.
+type ChapterReleaseWorker struct {
+	service  *services.WhitenestService
+	interval time.Duration
+	logger   *logging.Logger
+}
+
+func NewChapterReleaseWorker(
+	service *services.WhitenestService,
+	interval time.Duration,
+	logger *logging.Logger,
+) *ChapterReleaseWorker {
+	return &ChapterReleaseWorker{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Start launches the worker goroutine. It checks right away, so chapters that
+// fell due while the server was down go out on startup, then once per
+// interval until the parent context is cancelled.
+func (w *ChapterReleaseWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			if _, err := w.service.ReleaseDue(); err != nil {
+				w.logger.Error("Chapter release failed", logging.F("error", err.Error()))
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				w.logger.Info("Chapter release worker: context cancelled, exiting")
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,59 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/jobs"
+	"github.com/davidrdsilva/blog-api/internal/application/services"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+)
+
+const exportJobTimeout = 10 * time.Minute
+
+// ExportWorker reads BuildWhitenestExportJobs from a channel and builds one
+// EPUB at a time, so a burst of export requests can't saturate the server.
+type ExportWorker struct {
+	jobs    <-chan jobs.BuildWhitenestExportJob
+	service *services.WhitenestExportService
+	logger  *logging.Logger
+}
+
+func NewExportWorker(
+	jobCh <-chan jobs.BuildWhitenestExportJob,
+	service *services.WhitenestExportService,
+	logger *logging.Logger,
+) *ExportWorker {
+	return &ExportWorker{
+		jobs:    jobCh,
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Start launches the worker goroutine. It drains remaining jobs until the channel
+// is closed or the parent context is cancelled.
+func (w *ExportWorker) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case job, ok := <-w.jobs:
+				if !ok {
+					w.logger.Info("Export worker: channel closed, exiting")
+					return
+				}
+				jobCtx, cancel := context.WithTimeout(ctx, exportJobTimeout)
+				if err := w.service.Build(jobCtx, job.ExportID); err != nil {
+					w.logger.Error("Whitenest export failed",
+						logging.F("exportId", job.ExportID),
+						logging.F("error", err.Error()),
+					)
+				}
+				cancel()
+			case <-ctx.Done():
+				w.logger.Info("Export worker: context cancelled, exiting")
+				return
+			}
+		}
+	}()
+}
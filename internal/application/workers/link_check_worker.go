@@ -0,0 +1,53 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/services"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+)
+
+const linkCheckSweepTimeout = 30 * time.Minute
+
+// LinkCheckWorker runs the outbound link check on a fixed interval, one full
+// sweep per tick, like MediaGCWorker.
+type LinkCheckWorker struct {
+	service  *services.LinkCheckService
+	interval time.Duration
+	logger   *logging.Logger
+}
+
+func NewLinkCheckWorker(
+	service *services.LinkCheckService,
+	interval time.Duration,
+	logger *logging.Logger,
+) *LinkCheckWorker {
+	return &LinkCheckWorker{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Start launches the worker goroutine. It sweeps once per interval until the
+// parent context is cancelled.
+func (w *LinkCheckWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				sweepCtx, cancel := context.WithTimeout(ctx, linkCheckSweepTimeout)
+				if _, err := w.service.Sweep(sweepCtx); err != nil {
+					w.logger.Error("Link check sweep failed", logging.F("error", err.Error()))
+				}
+				cancel()
+			case <-ctx.Done():
+				w.logger.Info("Link check worker: context cancelled, exiting")
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,53 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/application/services"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+)
+
+const mediaGCSweepTimeout = 10 * time.Minute
+
+// MediaGCWorker runs the orphaned-media sweep on a fixed interval. Unlike the
+// channel-fed workers there is no job queue: each tick is one full sweep.
+type MediaGCWorker struct {
+	service  *services.MediaGCService
+	interval time.Duration
+	logger   *logging.Logger
+}
+
+func NewMediaGCWorker(
+	service *services.MediaGCService,
+	interval time.Duration,
+	logger *logging.Logger,
+) *MediaGCWorker {
+	return &MediaGCWorker{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Start launches the worker goroutine. It sweeps once per interval until the
+// parent context is cancelled.
+func (w *MediaGCWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				sweepCtx, cancel := context.WithTimeout(ctx, mediaGCSweepTimeout)
+				if _, err := w.service.Sweep(sweepCtx, false); err != nil {
+					w.logger.Error("Media GC sweep failed", logging.F("error", err.Error()))
+				}
+				cancel()
+			case <-ctx.Done():
+				w.logger.Info("Media GC worker: context cancelled, exiting")
+				return
+			}
+		}
+	}()
+}
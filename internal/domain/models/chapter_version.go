@@ -0,0 +1,20 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// ChapterSequenceVersion fingerprints the Whitenest chapter sequence: which
+// posts are chapters and in what order. items must be ordered by number.
+// Single-chapter moves and positional inserts require the version the client
+// last saw, so any concurrent publish, demote, delete or reorder in between is
+// detected without echoing the whole set back.
+func ChapterSequenceVersion(items []ChapterOrderItem) string {
+	h := sha256.New()
+	for _, item := range items {
+		fmt.Fprintf(h, "%s:%d;", item.PostID, item.Number)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
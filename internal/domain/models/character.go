@@ -14,17 +14,19 @@ import (
 // Character represents a recurring person in the Whitenest serial fiction.
 // Characters are reused across multiple chapters via a many-to-many relation
 // on the posts table. Skills follow a fixed canonical schema (see CharacterSkills).
+// Sections extend Description with text revealed as the story progresses.
 type Character struct {
-	ID          string          `gorm:"type:uuid;primaryKey" json:"id"`
-	FullName    string          `gorm:"type:varchar(120);not null" json:"full_name"`
-	ShortName   string          `gorm:"type:varchar(60);not null" json:"short_name"`
-	Description string          `gorm:"type:text;not null" json:"description"`
-	Occupation  string          `gorm:"type:varchar(120);not null" json:"occupation"`
-	Location    string          `gorm:"type:varchar(160);not null" json:"location"`
-	Portrait    string          `gorm:"type:varchar(2048);not null" json:"portrait"`
-	Skills      CharacterSkills `gorm:"type:jsonb;not null" json:"skills"`
-	CreatedAt   time.Time       `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"createdAt"`
-	UpdatedAt   time.Time       `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"updatedAt"`
+	ID          string            `gorm:"type:uuid;primaryKey" json:"id"`
+	FullName    string            `gorm:"type:varchar(120);not null" json:"full_name"`
+	ShortName   string            `gorm:"type:varchar(60);not null" json:"short_name"`
+	Description string            `gorm:"type:text;not null" json:"description"`
+	Occupation  string            `gorm:"type:varchar(120);not null" json:"occupation"`
+	Location    string            `gorm:"type:varchar(160);not null" json:"location"`
+	Portrait    string            `gorm:"type:varchar(2048);not null" json:"portrait"`
+	Skills      CharacterSkills   `gorm:"type:jsonb;not null" json:"skills"`
+	Sections    CharacterSections `gorm:"type:jsonb;not null;default:'[]'" json:"sections"`
+	CreatedAt   time.Time         `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"createdAt"`
+	UpdatedAt   time.Time         `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"updatedAt"`
 }
 
 func (Character) TableName() string {
@@ -88,6 +90,54 @@ func (s CharacterSkills) Validate() error {
 	return nil
 }
 
+// CharacterSection is a part of a character's description that would spoil
+// the story before chapter RevealedAt. Description itself is always shown.
+type CharacterSection struct {
+	Title      string `json:"title,omitempty"`
+	Body       string `json:"body"`
+	RevealedAt int    `json:"revealed_at"`
+}
+
+// CharacterSections is stored as JSONB on the characters table, in the
+// author's order.
+type CharacterSections []CharacterSection
+
+// Value implements driver.Valuer for JSONB persistence.
+func (s CharacterSections) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]CharacterSection(s))
+}
+
+// Scan implements sql.Scanner for JSONB retrieval.
+func (s *CharacterSections) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to unmarshal CharacterSections: invalid type")
+	}
+	return json.Unmarshal(bytes, (*[]CharacterSection)(s))
+}
+
+// RevealedBy returns the sections a reader who has reached chapter asOf may
+// see. asOf 0 means no gating.
+func (s CharacterSections) RevealedBy(asOf int) CharacterSections {
+	if asOf == 0 {
+		return s
+	}
+	out := make(CharacterSections, 0, len(s))
+	for _, section := range s {
+		if section.RevealedAt <= asOf {
+			out = append(out, section)
+		}
+	}
+	return out
+}
+
 // CharacterFilters holds filtering options for querying characters.
 type CharacterFilters struct {
 	Search string
@@ -102,6 +152,8 @@ type PostsCharacter struct {
 	PostID      string `gorm:"type:uuid;not null;index" json:"post_id"`
 	CharacterID string `gorm:"type:uuid;not null;index" json:"character_id"`
 	Position    int    `gorm:"not null;default:0" json:"position"`
+	// Per-chapter state changes; see CharacterStateOverride.
+	CharacterStateOverride `gorm:"embedded"`
 }
 
 // TableName specifies the table name for GORM
@@ -116,3 +168,111 @@ func (pc *PostsCharacter) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// CharacterAppearance is one chapter a character is cast in, read from the
+// character's side of posts_characters.
+type CharacterAppearance struct {
+	CharacterID   string
+	PostID        string
+	Title         string
+	ChapterNumber int
+	// Position is the character's place in that chapter's cast order.
+	Position int
+	CharacterStateOverride
+}
+
+// CharacterAppearanceStats summarizes a character's appearances across the
+// serial. FirstChapter and LastChapter are 0 when Count is 0.
+type CharacterAppearanceStats struct {
+	CharacterID  string
+	Count        int
+	FirstChapter int
+	LastChapter  int
+}
+
+// CharacterStateOverride records how a character changed in one chapter. Each
+// field is optional: nil keeps whatever the character had before. Overrides
+// carry forward, so a character who loses a hand in chapter 5 still has the
+// lower melee score in chapter 9 unless a later chapter changes it again.
+type CharacterStateOverride struct {
+	Skills     *CharacterSkills `gorm:"type:jsonb" json:"skills,omitempty"`
+	Occupation *string          `gorm:"type:varchar(120)" json:"occupation,omitempty"`
+	Location   *string          `gorm:"type:varchar(160)" json:"location,omitempty"`
+}
+
+// Or returns o with each unset field taken from other.
+func (o CharacterStateOverride) Or(other CharacterStateOverride) CharacterStateOverride {
+	if o.Skills == nil {
+		o.Skills = other.Skills
+	}
+	if o.Occupation == nil {
+		o.Occupation = other.Occupation
+	}
+	if o.Location == nil {
+		o.Location = other.Location
+	}
+	return o
+}
+
+// IsZero reports whether the override changes nothing.
+func (o CharacterStateOverride) IsZero() bool {
+	return o.Skills == nil && o.Occupation == nil && o.Location == nil
+}
+
+// CharacterState is a character as of some chapter: the character row's
+// values with every earlier override applied. The *Since fields are the
+// chapter that last set each value, 0 when it is still the base value.
+type CharacterState struct {
+	Skills          CharacterSkills
+	Occupation      string
+	Location        string
+	SkillsSince     int
+	OccupationSince int
+	LocationSince   int
+}
+
+// BaseState is the character before any chapter override.
+func BaseState(c *Character) CharacterState {
+	return CharacterState{
+		Skills:     c.Skills,
+		Occupation: c.Occupation,
+		Location:   c.Location,
+	}
+}
+
+// Apply layers the override set in chapter on top of the state. Overrides
+// must be applied in chapter order.
+func (s *CharacterState) Apply(chapter int, o CharacterStateOverride) {
+	if o.Skills != nil {
+		s.Skills = *o.Skills
+		s.SkillsSince = chapter
+	}
+	if o.Occupation != nil {
+		s.Occupation = *o.Occupation
+		s.OccupationSince = chapter
+	}
+	if o.Location != nil {
+		s.Location = *o.Location
+		s.LocationSince = chapter
+	}
+}
+
+// CastEntry is a post whose cast includes a character. ChapterNumber is nil
+// for posts that are not (or no longer) Whitenest chapters; they keep their
+// cast rows.
+type CastEntry struct {
+	PostID        string
+	Title         string
+	ChapterNumber *int
+	Position      int
+}
+
+// CharacterMergeResult counts what merging a duplicate character into a
+// canonical one changed. CastMerged are the posts where both were cast and
+// the duplicate's entry was folded into the canonical one's.
+type CharacterMergeResult struct {
+	CastMoved            int
+	CastMerged           int
+	RelationshipsMoved   int
+	RelationshipsDropped int
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Relationship types accepted for CharacterRelationship.Type.
+const (
+	RelationshipFamily   = "family"
+	RelationshipSibling  = "sibling"
+	RelationshipParent   = "parent"
+	RelationshipPartner  = "partner"
+	RelationshipFriend   = "friend"
+	RelationshipAlly     = "ally"
+	RelationshipRival    = "rival"
+	RelationshipEnemy    = "enemy"
+	RelationshipMentor   = "mentor"
+	RelationshipEmployer = "employer"
+	RelationshipOther    = "other"
+)
+
+// RelationshipTypes lists the accepted relationship types.
+var RelationshipTypes = []string{
+	RelationshipFamily, RelationshipSibling, RelationshipParent, RelationshipPartner,
+	RelationshipFriend, RelationshipAlly, RelationshipRival, RelationshipEnemy,
+	RelationshipMentor, RelationshipEmployer, RelationshipOther,
+}
+
+// CharacterRelationship is a typed edge between two characters. A directed
+// relationship reads "source is <type> of target" (a parent, mentor or
+// employer); an undirected one is symmetric and is stored with the smaller ID
+// as source so each pair has one row per type. SinceChapter is the chapter
+// where the relationship is established; nil means it holds from the start.
+// Deleting either character deletes the relationship.
+type CharacterRelationship struct {
+	ID           string     `gorm:"type:uuid;primaryKey" json:"id"`
+	SourceID     string     `gorm:"type:uuid;not null;uniqueIndex:idx_character_relationships_pair" json:"source_id"`
+	TargetID     string     `gorm:"type:uuid;not null;index;uniqueIndex:idx_character_relationships_pair" json:"target_id"`
+	Type         string     `gorm:"type:varchar(40);not null;uniqueIndex:idx_character_relationships_pair" json:"type"`
+	Directed     bool       `gorm:"not null;default:false" json:"directed"`
+	Description  *string    `gorm:"type:text" json:"description"`
+	SinceChapter *int       `json:"since_chapter"`
+	Source       *Character `gorm:"foreignKey:SourceID;constraint:OnDelete:CASCADE" json:"-"`
+	Target       *Character `gorm:"foreignKey:TargetID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt    time.Time  `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"createdAt"`
+	UpdatedAt    time.Time  `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"updatedAt"`
+}
+
+func (CharacterRelationship) TableName() string {
+	return "character_relationships"
+}
+
+func (r *CharacterRelationship) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// VisibleAt reports whether the relationship is established by chapter asOf.
+// asOf 0 means no gating.
+func (r *CharacterRelationship) VisibleAt(asOf int) bool {
+	return asOf == 0 || r.SinceChapter == nil || *r.SinceChapter <= asOf
+}
+
+// RelationshipFilters holds filtering options for querying relationships.
+type RelationshipFilters struct {
+	// CharacterID matches relationships on either end.
+	CharacterID string
+}
@@ -36,4 +36,9 @@ type CommentFilters struct {
 	SortOrder string
 	Page      int
 	Limit     int
+	// Cursor is a nextCursor/prevCursor from a previous page and takes
+	// precedence over Page.
+	Cursor string
+	// SkipCount leaves out the COUNT(*) for callers that only page forward.
+	SkipCount bool
 }
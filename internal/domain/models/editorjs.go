@@ -4,6 +4,9 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"html"
+	"regexp"
+	"strings"
 )
 
 // EditorJsContent represents the content structure from Editor.js
@@ -43,3 +46,132 @@ func (c *EditorJsContent) Scan(value interface{}) error {
 
 	return json.Unmarshal(bytes, c)
 }
+
+// MediaURLs returns the file URLs referenced by image and video blocks, and
+// the preview images of Link Tool blocks, in block order. Both the nested
+// data.file.url shape (Image Tool and the video tool built on it) and the flat
+// data.url shape (Simple Image) are read.
+func (c *EditorJsContent) MediaURLs() []string {
+	if c == nil {
+		return nil
+	}
+	var urls []string
+	for i := range c.Blocks {
+		block := &c.Blocks[i]
+		if url := block.LinkImageURL(); url != "" {
+			urls = append(urls, url)
+			continue
+		}
+		if block.Type != "image" && block.Type != "video" {
+			continue
+		}
+		if file, ok := block.Data["file"].(map[string]interface{}); ok {
+			if url, ok := file["url"].(string); ok && url != "" {
+				urls = append(urls, url)
+			}
+		}
+		if url, ok := block.Data["url"].(string); ok && url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// anchorHrefPattern matches the href of the <a> tags Editor.js's inline link
+// tool writes into block text.
+var anchorHrefPattern = regexp.MustCompile(`(?i)<a\s[^>]*?href\s*=\s*["']([^"']+)["']`)
+
+// OutboundLinks returns the distinct absolute http(s) links in the content:
+// Link Tool targets (data.link), Embed sources (data.source) and inline
+// anchors in any block's text, in block order. A URL found more than once is
+// reported with the source it was first seen in.
+func (c *EditorJsContent) OutboundLinks() []OutboundLink {
+	if c == nil {
+		return nil
+	}
+	var links []OutboundLink
+	seen := map[string]bool{}
+	add := func(raw, source string) {
+		u := strings.TrimSpace(html.UnescapeString(raw))
+		lower := strings.ToLower(u)
+		if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+			return
+		}
+		if seen[u] {
+			return
+		}
+		seen[u] = true
+		links = append(links, OutboundLink{URL: u, Source: source})
+	}
+
+	for _, block := range c.Blocks {
+		switch block.Type {
+		case "linkTool":
+			if link, ok := block.Data["link"].(string); ok {
+				add(link, LinkSourceLinkTool)
+			}
+		case "embed":
+			if source, ok := block.Data["source"].(string); ok {
+				add(source, LinkSourceEmbed)
+			}
+		}
+		// Inline links can sit in any string: paragraph text, list items,
+		// table cells, captions.
+		walkStrings(block.Data, func(text string) {
+			if !strings.Contains(text, "<a") && !strings.Contains(text, "<A") {
+				return
+			}
+			for _, m := range anchorHrefPattern.FindAllStringSubmatch(text, -1) {
+				add(m[1], LinkSourceAnchor)
+			}
+		})
+	}
+	return links
+}
+
+// walkStrings calls fn for every string nested anywhere in v.
+func walkStrings(v interface{}, fn func(string)) {
+	switch t := v.(type) {
+	case string:
+		fn(t)
+	case map[string]interface{}:
+		for _, child := range t {
+			walkStrings(child, fn)
+		}
+	case []interface{}:
+		for _, child := range t {
+			walkStrings(child, fn)
+		}
+	}
+}
+
+// LinkImageURL returns the preview image of a Link Tool block
+// (data.meta.image.url), or "" for any other block.
+func (b *EditorJsBlock) LinkImageURL() string {
+	image := b.linkImage()
+	if image == nil {
+		return ""
+	}
+	url, _ := image["url"].(string)
+	return url
+}
+
+// SetLinkImageURL replaces a Link Tool block's preview image URL. It is a
+// no-op on blocks without one.
+func (b *EditorJsBlock) SetLinkImageURL(url string) {
+	if image := b.linkImage(); image != nil {
+		image["url"] = url
+	}
+}
+
+func (b *EditorJsBlock) linkImage() map[string]interface{} {
+	if b.Type != "linkTool" {
+		return nil
+	}
+	meta, ok := b.Data["meta"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	image, _ := meta["image"].(map[string]interface{})
+	return image
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// Where an outbound link was found inside a post's Editor.js content.
+const (
+	LinkSourceLinkTool = "linkTool"
+	LinkSourceEmbed    = "embed"
+	LinkSourceAnchor   = "anchor"
+)
+
+// LinkCheck is the latest result of checking one outbound link of one post.
+// The link checker replaces the whole table on every sweep, so rows for links
+// that were edited out disappear with the next sweep.
+type LinkCheck struct {
+	PostID string `gorm:"type:uuid;primaryKey" json:"post_id"`
+	URL    string `gorm:"type:varchar(2048);primaryKey" json:"url"`
+	Source string `gorm:"type:varchar(20);not null" json:"source"`
+	// StatusCode is nil when no response was received (Error says why).
+	StatusCode *int    `json:"status_code,omitempty"`
+	FinalURL   *string `gorm:"type:varchar(2048)" json:"final_url,omitempty"`
+	Redirects  int     `gorm:"not null;default:0" json:"redirects"`
+	Error      *string `gorm:"type:text" json:"error,omitempty"`
+	// Broken is set for request failures and 4xx/5xx statuses other than 429.
+	Broken    bool      `gorm:"not null;default:false;index" json:"broken"`
+	CheckedAt time.Time `gorm:"type:timestamp with time zone;not null" json:"checked_at"`
+}
+
+// TableName specifies the table name for GORM
+func (LinkCheck) TableName() string {
+	return "link_checks"
+}
+
+// OutboundLink is an external URL referenced by post content.
+type OutboundLink struct {
+	URL    string
+	Source string
+}
+
+// PostLinks groups the outbound links of one post.
+type PostLinks struct {
+	PostID string
+	Links  []OutboundLink
+}
+
+// PostBrokenLinks is one post's entry in the broken-link report.
+type PostBrokenLinks struct {
+	PostID string
+	Title  string
+	Links  []LinkCheck
+}
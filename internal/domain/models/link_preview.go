@@ -0,0 +1,70 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// LinkPreview is a cached result of fetching a URL for the Editor.js Link
+// Tool. Rows are keyed by the requested URL (without fragment) and are only
+// served while ExpiresAt is in the future; an expired row is overwritten by
+// the next fetch of the same URL.
+type LinkPreview struct {
+	URL       string       `gorm:"type:varchar(2048);primaryKey" json:"url"`
+	Metadata  LinkMetadata `gorm:"type:jsonb;not null" json:"metadata"`
+	FetchedAt time.Time    `gorm:"type:timestamp with time zone;not null" json:"fetched_at"`
+	ExpiresAt time.Time    `gorm:"type:timestamp with time zone;not null;index" json:"expires_at"`
+}
+
+// TableName specifies the table name for GORM
+func (LinkPreview) TableName() string {
+	return "link_previews"
+}
+
+// LinkMetadata is what was extracted from the page. Stored as JSONB so new
+// fields don't need a migration. URLs are absolute, resolved against the
+// page's base URL.
+type LinkMetadata struct {
+	Title         string `json:"title,omitempty"`
+	Description   string `json:"description,omitempty"`
+	ImageURL      string `json:"image_url,omitempty"`
+	SiteName      string `json:"site_name,omitempty"`
+	FaviconURL    string `json:"favicon_url,omitempty"`
+	CanonicalURL  string `json:"canonical_url,omitempty"`
+	Author        string `json:"author,omitempty"`
+	PublishedTime string `json:"published_time,omitempty"`
+	// Embed is set when the page advertises an oEmbed endpoint.
+	Embed *LinkEmbed `json:"embed,omitempty"`
+}
+
+// LinkEmbed is the useful part of an oEmbed response. URL is the iframe src
+// from a "video" or "rich" response, kept only for allowed players; it is what
+// the Editor.js Embed tool renders. HTML is an iframe of URL rebuilt by the
+// API, never the provider's own markup.
+type LinkEmbed struct {
+	Type     string `json:"type"`
+	Provider string `json:"provider,omitempty"`
+	HTML     string `json:"html,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+}
+
+// Value implements driver.Valuer for JSONB persistence.
+func (m LinkMetadata) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+// Scan implements sql.Scanner for JSONB retrieval.
+func (m *LinkMetadata) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to unmarshal LinkMetadata: invalid type")
+	}
+	return json.Unmarshal(bytes, m)
+}
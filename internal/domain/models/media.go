@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Media is the record of one object written to storage by the upload
+// endpoint. The object itself lives in the bucket under Key; this row is what
+// lets the API list uploads and tell whether anything still points at them.
+//
+// Keys are content-addressed, so there is one row per distinct file however
+// often it is uploaded; UploadCount records how many uploads resolved to it.
+type Media struct {
+	ID       string  `gorm:"type:uuid;primaryKey" json:"id"`
+	Key      string  `gorm:"type:varchar(512);not null;uniqueIndex" json:"key"`
+	URL      string  `gorm:"type:varchar(2048);not null" json:"url"`
+	MimeType string  `gorm:"type:varchar(100);not null;index" json:"mime_type"`
+	Size     int64   `gorm:"not null" json:"size"`
+	Width    *int    `json:"width,omitempty"`
+	Height   *int    `json:"height,omitempty"`
+	SHA256   string  `gorm:"column:sha256;type:char(64);not null;index" json:"sha256"`
+	Uploader *string `gorm:"type:varchar(100)" json:"uploader,omitempty"`
+	// UploadCount starts at 1 and grows by one for each deduplicated upload.
+	UploadCount int        `gorm:"not null;default:1" json:"upload_count"`
+	CapturedAt  *time.Time `gorm:"type:timestamp with time zone" json:"captured_at,omitempty"`
+	// Video container metadata; Width/Height above hold the video resolution.
+	DurationSeconds *float64  `json:"duration_seconds,omitempty"`
+	VideoCodec      *string   `gorm:"type:varchar(50)" json:"video_codec,omitempty"`
+	AudioCodec      *string   `gorm:"type:varchar(50)" json:"audio_codec,omitempty"`
+	CreatedAt       time.Time `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM
+func (Media) TableName() string {
+	return "media"
+}
+
+// BeforeCreate generates a UUID for new media rows.
+func (m *Media) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// MediaFilters holds filtering options for querying the media library.
+type MediaFilters struct {
+	// Kind is the MIME top-level type ("image" or "video"); empty means any.
+	Kind      string
+	MimeType  string
+	Uploader  string
+	Search    string
+	SortOrder string
+	Page      int
+	Limit     int
+}
+
+// MediaReferences lists everything that still points at a media object's URL.
+// A media row may only be deleted when all of them are empty.
+type MediaReferences struct {
+	// Posts using the URL as their cover image.
+	PostImageIDs []string
+	// Posts with an Editor.js image or video block (or link preview image)
+	// pointing at the URL.
+	PostContentIDs []string
+	// Characters using the URL as their portrait.
+	CharacterIDs []string
+	// Whitenest arcs using the URL as their cover image.
+	ArcIDs []string
+}
+
+// IsEmpty reports whether nothing references the media object.
+func (r MediaReferences) IsEmpty() bool {
+	return len(r.PostImageIDs) == 0 && len(r.PostContentIDs) == 0 &&
+		len(r.CharacterIDs) == 0 && len(r.ArcIDs) == 0
+}
+
+// MediaStats summarises storage use across the media library. BytesSaved is
+// what deduplicated uploads would have written had each been stored again.
+type MediaStats struct {
+	Objects    int64
+	TotalBytes int64
+	Uploads    int64
+	DedupHits  int64
+	BytesSaved int64
+}
@@ -30,6 +30,10 @@ type Post struct {
 	// can swap numbers across rows in one transaction without tripping the check
 	// mid-statement.
 	WhitenestChapterNumber *int             `gorm:"unique" json:"whitenest_chapter_number,omitempty"`
+	// ReleaseAt is set while a Whitenest chapter is queued for release and
+	// cleared by the scheduler once it goes live; queued chapters are hidden
+	// from readers.
+	ReleaseAt              *time.Time       `gorm:"type:timestamp with time zone;index" json:"release_at,omitempty"`
 	Comments               []Comment        `gorm:"foreignKey:PostID;references:ID;constraint:OnDelete:CASCADE" json:"comments,omitempty"`
 	CreatedAt              time.Time        `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"createdAt"`
 	UpdatedAt              time.Time        `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"updatedAt"`
@@ -52,18 +56,37 @@ func (p *Post) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// IsQueued reports whether the post is a Whitenest chapter still waiting for
+// its release.
+func (p *Post) IsQueued() bool {
+	return p.ReleaseAt != nil
+}
+
 // PostFilters holds filtering options for querying posts
 type PostFilters struct {
-	Search     string
-	Author     string
-	CategoryID *int
-	TagNames   []string
+	Search string
+	Author string
+	// CategoryIDs matches posts in any of the listed categories.
+	CategoryIDs []int
+	TagNames    []string
+	// TagMatchAll requires every tag in TagNames instead of any of them.
+	TagMatchAll bool
+	// DateFrom is inclusive, DateTo exclusive; either may be nil.
+	DateFrom *time.Time
+	DateTo   *time.Time
+	// Facets names the facets to compute alongside the page.
+	Facets []string
 	// nil = no filter, true = only chapters, false = only non-chapters.
 	IsWhitenestChapter *bool
 	SortBy             string
 	SortOrder          string
 	Page               int
 	Limit              int
+	// Cursor is a nextCursor/prevCursor from a previous page and takes
+	// precedence over Page.
+	Cursor string
+	// SkipCount leaves out the COUNT(*) for callers that only page forward.
+	SkipCount bool
 	// Posts in categories flagged is_internal (e.g. "Drafts") are excluded from
 	// public listings by default. OnlyInternalCategories returns *only* those
 	// posts (used by the drafts endpoint); IncludeInternalCategories disables
@@ -78,11 +101,16 @@ type ChapterOrderItem struct {
 	Number int
 }
 
-// PaginationMeta holds pagination metadata
+// PaginationMeta holds pagination metadata. Total and TotalPages are -1 when
+// the caller asked to skip the count.
 type PaginationMeta struct {
 	Total      int64 `json:"total"`
 	Page       int   `json:"page"`
 	Limit      int   `json:"limit"`
 	TotalPages int   `json:"totalPages"`
 	HasMore    bool  `json:"hasMore"`
+	// NextCursor and PrevCursor are set by listings that support keyset
+	// paging when there are rows after / before the page.
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
 }
@@ -0,0 +1,24 @@
+package models
+
+// Facet names accepted by the post listing's facets parameter.
+const (
+	FacetCategory = "category"
+	FacetTag      = "tag"
+	FacetAuthor   = "author"
+	FacetYear     = "year"
+)
+
+// PostFacetNames lists the supported facets in response order.
+var PostFacetNames = []string{FacetCategory, FacetTag, FacetAuthor, FacetYear}
+
+// FacetBucket is one value of a facet and the number of matching posts.
+// Value is what the corresponding filter parameter takes (category ID, tag
+// name, author, year); Label is the display name where it differs.
+type FacetBucket struct {
+	Value string
+	Label string
+	Count int64
+}
+
+// PostFacets holds the buckets of each requested facet, keyed by facet name.
+type PostFacets map[string][]FacetBucket
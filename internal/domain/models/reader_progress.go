@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ReaderProgress is one reader's progress through one Whitenest chapter.
+// Readers are anonymous: ReaderID comes from a cookie or a frontend-supplied
+// header, so there is no readers table. A row exists once the reader opened
+// the chapter; ReadAt marks it as finished and BlockID is the Editor.js block
+// they were last at.
+type ReaderProgress struct {
+	ReaderID  string     `gorm:"type:uuid;primaryKey;index:idx_reader_progress_recent,priority:1" json:"reader_id"`
+	PostID    string     `gorm:"type:uuid;primaryKey;index" json:"post_id"`
+	BlockID   *string    `gorm:"type:varchar(64)" json:"block_id"`
+	ReadAt    *time.Time `gorm:"type:timestamp with time zone" json:"read_at"`
+	CreatedAt time.Time  `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"createdAt"`
+	UpdatedAt time.Time  `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP;index:idx_reader_progress_recent,priority:2,sort:desc" json:"updatedAt"`
+	Post      *Post      `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (ReaderProgress) TableName() string {
+	return "reader_progress"
+}
+
+// ChapterProgress is a ReaderProgress row joined with the chapter's current
+// number. Progress on posts that are no longer chapters is kept but not
+// listed, so it comes back if the post is promoted again.
+type ChapterProgress struct {
+	PostID        string
+	ChapterNumber int
+	BlockID       *string
+	ReadAt        *time.Time
+	UpdatedAt     time.Time
+}
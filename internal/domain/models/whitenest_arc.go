@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WhitenestArc groups a contiguous run of Whitenest chapters (an arc or a
+// volume). Only the first chapter is stored: an arc runs from StartChapter up
+// to the chapter before the next arc's start, and the last arc is open-ended
+// so newly published chapters join it. Arcs are ordered by StartChapter, so
+// their ordering always follows the chapters'.
+//
+// Two arcs may share a start; the older one is then empty. That happens when
+// an arc's only chapter is demoted, and lets an author set up the next volume
+// (StartChapter = latest chapter + 1) before its first chapter is published.
+type WhitenestArc struct {
+	ID           string    `gorm:"type:uuid;primaryKey" json:"id"`
+	Title        string    `gorm:"type:varchar(200);not null" json:"title"`
+	Synopsis     *string   `gorm:"type:text" json:"synopsis"`
+	Image        *string   `gorm:"type:varchar(2048)" json:"image"`
+	StartChapter int       `gorm:"not null;index" json:"start_chapter"`
+	CreatedAt    time.Time `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"createdAt"`
+	UpdatedAt    time.Time `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"updatedAt"`
+}
+
+func (WhitenestArc) TableName() string {
+	return "whitenest_arcs"
+}
+
+func (a *WhitenestArc) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// ArcBoundary moves an arc's first chapter as part of a chapter reorder.
+type ArcBoundary struct {
+	ArcID        string
+	StartChapter int
+}
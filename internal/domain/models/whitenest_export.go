@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Whitenest export statuses. An export is created pending, picked up by the
+// export worker (running) and ends ready or failed.
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusReady   = "ready"
+	ExportStatusFailed  = "failed"
+)
+
+// WhitenestExport is one EPUB build of a range of Whitenest chapters. The
+// selection is stored resolved to chapter numbers; ArcID records that it was
+// requested as an arc, for the book title. Fingerprint identifies the
+// content the book was built from, so an unchanged selection reuses the
+// existing file instead of building a new one.
+type WhitenestExport struct {
+	ID           string     `gorm:"type:uuid;primaryKey" json:"id"`
+	Status       string     `gorm:"type:varchar(16);not null;index" json:"status"`
+	ArcID        *string    `gorm:"type:uuid" json:"arc_id"`
+	FromChapter  int        `gorm:"not null" json:"from_chapter"`
+	ToChapter    int        `gorm:"not null" json:"to_chapter"`
+	Fingerprint  string     `gorm:"type:varchar(64);not null;index" json:"fingerprint"`
+	ChapterCount int        `gorm:"not null" json:"chapter_count"`
+	ObjectKey    *string    `gorm:"type:varchar(512)" json:"object_key"`
+	Size         int64      `gorm:"not null;default:0" json:"size"`
+	Error        *string    `gorm:"type:text" json:"error"`
+	CreatedAt    time.Time  `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"createdAt"`
+	UpdatedAt    time.Time  `gorm:"type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"updatedAt"`
+	CompletedAt  *time.Time `gorm:"type:timestamp with time zone" json:"completedAt"`
+}
+
+func (WhitenestExport) TableName() string {
+	return "whitenest_exports"
+}
+
+func (e *WhitenestExport) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
+
+// CharacterRelationshipRepository defines the interface for character
+// relationship data access.
+type CharacterRelationshipRepository interface {
+	Create(rel *models.CharacterRelationship) error
+	Update(id string, rel *models.CharacterRelationship) error
+	Delete(id string) error
+
+	// Returns (nil, nil) when no relationship has that ID.
+	FindByID(id string) (*models.CharacterRelationship, error)
+
+	// FindAll returns relationships ordered by creation time.
+	FindAll(filters models.RelationshipFilters) ([]*models.CharacterRelationship, error)
+}
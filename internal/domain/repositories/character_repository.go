@@ -13,4 +13,43 @@ type CharacterRepository interface {
 	FindAll(filters models.CharacterFilters) ([]*models.Character, error)
 	FindByIDs(ids []string) ([]*models.Character, error)
 	Exists(id string) (bool, error)
+
+	// FindAppearances returns the Whitenest chapters the character is cast
+	// in, ordered by chapter number. Posts that left Whitenest keep their cast
+	// rows but are not chapters, so they are excluded.
+	FindAppearances(characterID string) ([]models.CharacterAppearance, error)
+
+	// FindAllAppearances returns every (character, chapter) cast entry,
+	// ordered by chapter number then cast position. Backs the cast matrix.
+	FindAllAppearances() ([]models.CharacterAppearance, error)
+
+	// AppearanceStats counts chapter appearances per character, keyed by
+	// character ID. Characters that appear in no chapter are absent. An empty
+	// ids slice means every character; upTo > 0 only counts chapters up to
+	// and including that number.
+	AppearanceStats(ids []string, upTo int) (map[string]models.CharacterAppearanceStats, error)
+
+	// FindStateOverrides returns the appearances of the given characters in
+	// chapters up to and including upTo that carry a state override, ordered
+	// by chapter number so they can be applied in turn. Queued chapters are
+	// skipped unless includeQueued is set.
+	FindStateOverrides(characterIDs []string, upTo int, includeQueued bool) ([]models.CharacterAppearance, error)
+
+	// UpdateCastState replaces the state override of a character in one
+	// post's cast; nil fields clear that part of the override. Returns
+	// gorm.ErrRecordNotFound when the character is not in the post's cast.
+	UpdateCastState(postID, characterID string, override models.CharacterStateOverride) error
+
+	// FindCastEntries returns every post whose cast includes the character,
+	// chapters first in reading order, then other posts by title.
+	FindCastEntries(characterID string) ([]models.CastEntry, error)
+
+	// MergeInto moves everything that references the duplicate character to
+	// the canonical one, then deletes the duplicate, in one transaction. A
+	// cast entry keeps its position; where both are cast, the entries are
+	// folded into one at the earlier position. Relationships are repointed,
+	// except ones between the two characters or ones the canonical character
+	// already has, which are dropped. Returns gorm.ErrRecordNotFound when
+	// either character doesn't exist.
+	MergeInto(duplicateID, canonicalID string) (*models.CharacterMergeResult, error)
 }
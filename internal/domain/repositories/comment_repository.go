@@ -6,7 +6,7 @@ type CommentRepository interface {
 	Create(comment *models.Comment) error
 	CreateBatch(comments []*models.Comment) error
 	FindByID(id string) (*models.Comment, error)
-	FindAll(filters models.CommentFilters) ([]*models.Comment, error)
+	FindAll(filters models.CommentFilters) ([]*models.Comment, *models.PaginationMeta, error)
 	Update(id string, comment *models.Comment) error
 	Delete(id string) error
 	FindAllByPostID(postID string) ([]*models.Comment, error)
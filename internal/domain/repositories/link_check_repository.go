@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
+
+// LinkCheckRepository defines the interface for outbound link check results
+type LinkCheckRepository interface {
+	// FindPostLinks returns the outbound links of every post that has any.
+	FindPostLinks() ([]models.PostLinks, error)
+
+	// ReplaceAll swaps the stored results for checks in one transaction.
+	ReplaceAll(checks []models.LinkCheck) error
+
+	// FindBroken returns the posts with at least one broken link, most
+	// recent posts first, each with only its broken links.
+	FindBroken() ([]models.PostBrokenLinks, error)
+}
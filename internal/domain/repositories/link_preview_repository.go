@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
+
+// LinkPreviewRepository defines the interface for the link preview cache
+type LinkPreviewRepository interface {
+	// FindFresh returns the cached preview for url if it has not expired at
+	// now. Returns (nil, nil) when there is no usable entry.
+	FindFresh(url string, now time.Time) (*models.LinkPreview, error)
+
+	// Save inserts the preview or replaces the existing entry for its URL.
+	Save(preview *models.LinkPreview) error
+
+	// DeleteExpired removes entries that expired before the given time and
+	// returns how many were removed.
+	DeleteExpired(before time.Time) (int64, error)
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
+
+// MediaRepository defines the interface for media library data access
+type MediaRepository interface {
+	Create(media *models.Media) error
+
+	// Returns (nil, nil) when no row matches.
+	FindByID(id string) (*models.Media, error)
+
+	// Returns (nil, nil) when no row matches.
+	FindByKey(key string) (*models.Media, error)
+
+	// IncrementUploadCount records one more upload that resolved to an
+	// existing object.
+	IncrementUploadCount(id string) error
+
+	// Stats aggregates object count, stored bytes and deduplication savings.
+	Stats() (*models.MediaStats, error)
+
+	// FindAll lists media rows with filtering and pagination, newest first
+	// unless the filters ask for ascending order.
+	FindAll(filters models.MediaFilters) ([]*models.Media, *models.PaginationMeta, error)
+
+	Delete(id string) error
+
+	// DeleteByKey removes the row for an object key. A missing row is not an
+	// error: objects uploaded before the media table existed have none.
+	DeleteByKey(key string) error
+
+	// FindReferences returns the posts (cover image, Editor.js image block or
+	// link preview image) and characters (portrait) that reference the given
+	// public URL.
+	FindReferences(url string) (*models.MediaReferences, error)
+
+	// FindReferencedURLs returns every URL that posts (cover image plus image,
+	// video and link preview images in content) and characters (portrait)
+	// currently point at. Duplicates are possible; callers build a set.
+	FindReferencedURLs() ([]string, error)
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/davidrdsilva/blog-api/internal/domain/models"
 )
 
@@ -16,6 +18,11 @@ type PostRepository interface {
 	// FindAll retrieves posts with filtering, pagination, and sorting
 	FindAll(filters models.PostFilters) ([]*models.Post, *models.PaginationMeta, error)
 
+	// Facets counts the posts matching filters for each value of the named
+	// facets (see models.PostFacetNames). A facet's own filter is ignored
+	// when counting it, except an all-tags filter for the tag facet.
+	Facets(filters models.PostFilters, names []string) (models.PostFacets, error)
+
 	// Update modifies an existing post
 	Update(id string, post *models.Post) error
 
@@ -31,9 +38,16 @@ type PostRepository interface {
 
 	// ReplaceCharacters fully replaces the cast assigned to a post,
 	// preserving the supplied order via the join table's `position` column.
-	// Used by Whitenest chapter create/update flows.
+	// Characters that remain in the cast keep their per-chapter state
+	// override. Used by Whitenest chapter create/update flows.
 	ReplaceCharacters(postID string, characterIDs []string) error
 
+	// ReplaceLinkImageURLs rewrites the preview image of every Link Tool
+	// block whose URL is a key of urls to the mapped value, under a row lock
+	// so a concurrent save isn't overwritten. updated_at is left alone. A
+	// missing post is not an error.
+	ReplaceLinkImageURLs(id string, urls map[string]string) error
+
 	// IncrementViews adds 1 to total_views atomically. Called from the view
 	// counter worker, decoupled from the read path.
 	IncrementViews(id string) error
@@ -51,25 +65,58 @@ type PostRepository interface {
 	// Returns (nil, nil) when no chapter has that number.
 	FindWhitenestChapterByNumber(number int) (*models.Post, error)
 
-	// Either side may be nil at the extremes of the series.
-	FindAdjacentWhitenestChapters(number int) (previous, next *models.Post, err error)
+	// Either side may be nil at the extremes of the series. Queued chapters
+	// are skipped unless includeQueued is set.
+	FindAdjacentWhitenestChapters(number int, includeQueued bool) (previous, next *models.Post, err error)
 
 	// Returns 0 when no chapters exist yet.
 	MaxWhitenestChapterNumber() (int, error)
 
-	// Returns every Whitenest chapter ordered by chapter number ASC. Tags are
-	// preloaded for the sidebar list view; category is omitted.
+	// Returns every Whitenest chapter, queued ones included, ordered by
+	// chapter number ASC. Tags are preloaded for the sidebar list view;
+	// category is omitted.
 	ListWhitenestChapters() ([]*models.Post, error)
 
+	// ReleaseDueWhitenestChapters releases the queued chapters whose release
+	// time is at or before now: release_at is cleared and the date stamped.
+	// A due chapter waits while a chapter numbered before it is still
+	// queued for later, so the released chapters stay a prefix of the
+	// series. Returns the released chapters in number order.
+	ReleaseDueWhitenestChapters(now time.Time) ([]*models.Post, error)
+
 	// DemoteWhitenestChapter applies the regular post update, clears the post's
-	// chapter number to NULL, and shifts subsequent chapters down by one — all
-	// in a single transaction. Caller is responsible for setting business-level
+	// chapter number (and release time) to NULL, and shifts subsequent chapters (and the arcs
+	// starting after it) down by one — all in a single transaction. Caller is responsible for setting business-level
 	// fields on `post`; this method handles the chapter-number bookkeeping.
 	DemoteWhitenestChapter(id string, post *models.Post, oldNumber int) error
 
 	// ReorderWhitenestChapters atomically rewrites chapter numbers for the
 	// supplied (post_id, number) pairs in a single transaction. Caller must
 	// validate that the pairs cover the current Whitenest set exactly and that
-	// numbers are contiguous 1..N before calling.
-	ReorderWhitenestChapters(order []models.ChapterOrderItem) error
+	// numbers are contiguous 1..N before calling. arcs, which may be empty,
+	// moves arc boundaries in the same transaction.
+	ReorderWhitenestChapters(order []models.ChapterOrderItem, arcs []models.ArcBoundary) error
+
+	// InsertWhitenestChapter creates post as a chapter at the number it
+	// carries, shifting that chapter and every later one up by one in the same
+	// transaction. version must match models.ChapterSequenceVersion of the
+	// current set; the number may be at most one past the last chapter.
+	InsertWhitenestChapter(post *models.Post, version string) error
+
+	// PromoteWhitenestChapter is InsertWhitenestChapter for an existing post:
+	// it applies the regular post update after making room at the number the
+	// post carries.
+	PromoteWhitenestChapter(id string, post *models.Post, version string) error
+
+	// RescheduleWhitenestChapter applies the regular post update to a queued
+	// chapter and moves it from `from` to `to` (its new slot in release
+	// order; equal to skip the move) in a single transaction guarded by
+	// version. The update only applies while the chapter is still queued, so
+	// one the release scheduler has just released isn't queued again.
+	RescheduleWhitenestChapter(id string, post *models.Post, from, to int, version string) error
+
+	// MoveWhitenestChapter moves chapter `from` to number `to`, shifting the
+	// chapters in between by one, in a single transaction guarded by version.
+	// Returns gorm.ErrRecordNotFound when no chapter is numbered `from`.
+	MoveWhitenestChapter(from, to int, version string) error
 }
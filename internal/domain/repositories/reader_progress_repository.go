@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
+
+// ReaderProgressRepository defines the interface for Whitenest reader
+// progress data access.
+type ReaderProgressRepository interface {
+	// Touch records that the reader opened the post, creating the row if
+	// needed and bumping UpdatedAt otherwise.
+	Touch(readerID, postID string) error
+
+	// Save upserts progress, writing only the given columns ("block_id",
+	// "read_at") on an existing row. UpdatedAt is always bumped.
+	Save(progress *models.ReaderProgress, columns []string) error
+
+	// FindByReader returns the reader's progress on current chapters, in
+	// chapter order.
+	FindByReader(readerID string) ([]models.ChapterProgress, error)
+}
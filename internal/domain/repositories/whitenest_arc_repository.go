@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
+
+// WhitenestArcRepository defines the interface for Whitenest arc data access.
+// Boundary shifts caused by chapter demotes and reorders are applied by
+// PostRepository inside the same transaction as the chapter renumbering.
+type WhitenestArcRepository interface {
+	Create(arc *models.WhitenestArc) error
+	Update(id string, arc *models.WhitenestArc) error
+	Delete(id string) error
+
+	// Returns (nil, nil) when no arc has that ID.
+	FindByID(id string) (*models.WhitenestArc, error)
+
+	// FindAll returns every arc in reading order (start chapter, then
+	// creation time for arcs sharing a start).
+	FindAll() ([]*models.WhitenestArc, error)
+}
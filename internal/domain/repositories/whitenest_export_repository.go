@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
+
+// WhitenestExportRepository defines the interface for Whitenest EPUB export
+// job data access.
+type WhitenestExportRepository interface {
+	Create(export *models.WhitenestExport) error
+	// Save writes every column of an existing export.
+	Save(export *models.WhitenestExport) error
+
+	// Returns (nil, nil) when no export has that ID.
+	FindByID(id string) (*models.WhitenestExport, error)
+
+	// FindLiveByFingerprint returns the newest export with that fingerprint
+	// that is pending, running or ready. Returns (nil, nil) when there is
+	// none.
+	FindLiveByFingerprint(fingerprint string) (*models.WhitenestExport, error)
+
+	// FindReadyBySelection returns the ready exports of a chapter range
+	// requested the same way (arcID nil for a plain range).
+	FindReadyBySelection(arcID *string, from, to int) ([]*models.WhitenestExport, error)
+
+	Delete(id string) error
+
+	// FailUnfinished marks every pending or running export as failed with
+	// reason and returns how many there were. Used at startup: the job
+	// queue is in memory, so nothing will pick them up again.
+	FailUnfinished(reason string) (int64, error)
+}
@@ -79,6 +79,30 @@ func RunMigrations(db *gorm.DB, log *logging.Logger) error {
 		return fmt.Errorf("failed to migrate posts/comments: %w", err)
 	}
 
+	if err := db.AutoMigrate(&models.WhitenestArc{}); err != nil {
+		return fmt.Errorf("failed to migrate whitenest arcs: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.CharacterRelationship{}); err != nil {
+		return fmt.Errorf("failed to migrate character relationships: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.ReaderProgress{}); err != nil {
+		return fmt.Errorf("failed to migrate reader progress: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.WhitenestExport{}); err != nil {
+		return fmt.Errorf("failed to migrate whitenest exports: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.Media{}); err != nil {
+		return fmt.Errorf("failed to migrate media: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.LinkPreview{}, &models.LinkCheck{}); err != nil {
+		return fmt.Errorf("failed to migrate link previews/checks: %w", err)
+	}
+
 	if err := seedWhitenestCategory(db, log); err != nil {
 		return err
 	}
@@ -305,6 +329,11 @@ func createIndexes(db *gorm.DB, log *logging.Logger) error {
 		return fmt.Errorf("failed to create date index: %w", err)
 	}
 
+	// Keyset pages on the default sort compare (date, id) as a row.
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_posts_date_id ON posts(date DESC, id DESC)").Error; err != nil {
+		return fmt.Errorf("failed to create date/id index: %w", err)
+	}
+
 	// Index on author for filtering
 	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_posts_author ON posts(author)").Error; err != nil {
 		return fmt.Errorf("failed to create author index: %w", err)
@@ -322,6 +351,12 @@ func createIndexes(db *gorm.DB, log *logging.Logger) error {
 		return fmt.Errorf("failed to create post_id index: %w", err)
 	}
 
+	// Serves the default comment listing (a post's thread, newest first) and
+	// its keyset cursor without a sort step.
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_comments_post_created ON comments(post_id, created_at DESC, id DESC)").Error; err != nil {
+		return fmt.Errorf("failed to create comments post/created_at index: %w", err)
+	}
+
 	// Full-text search index on searchable fields
 	// Create a computed column for full-text search
 	if err := db.Exec(`
@@ -383,6 +418,30 @@ func createIndexes(db *gorm.DB, log *logging.Logger) error {
 		return fmt.Errorf("failed to create posts_characters unique index: %w", err)
 	}
 
+	// Link check rows belong to their post.
+	if err := db.Exec(`
+		ALTER TABLE link_checks DROP CONSTRAINT IF EXISTS fk_link_checks_post;
+		ALTER TABLE link_checks ADD CONSTRAINT fk_link_checks_post
+			FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		return fmt.Errorf("failed to set cascade on link_checks: %w", err)
+	}
+
+	// Index on created_at for the media library listing (newest first).
+	if err := db.Exec(
+		"CREATE INDEX IF NOT EXISTS idx_media_created_at ON media(created_at DESC)",
+	).Error; err != nil {
+		return fmt.Errorf("failed to create media created_at index: %w", err)
+	}
+
+	// The media delete guard looks up image blocks with `content @> ...`;
+	// jsonb_path_ops keeps the index small and supports exactly that operator.
+	if err := db.Exec(
+		"CREATE INDEX IF NOT EXISTS idx_posts_content_path ON posts USING GIN(content jsonb_path_ops)",
+	).Error; err != nil {
+		return fmt.Errorf("failed to create posts content index: %w", err)
+	}
+
 	// Speed up category-name and tag-name lookups (case-insensitive search).
 	if err := db.Exec(
 		`CREATE INDEX IF NOT EXISTS idx_categories_name_lower ON categories(LOWER(name))`,
@@ -0,0 +1,218 @@
+package epub
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+)
+
+// ImageResolver maps an image URL from post content to the href of the
+// embedded copy. ok=false means the image couldn't be embedded (an external
+// URL, a missing object, an unsupported format).
+type ImageResolver func(url string) (href string, ok bool)
+
+// RenderBlocks converts Editor.js content into XHTML. Images go through
+// resolve; ones that can't be embedded are replaced by their caption. Blocks
+// that have no offline form (raw HTML) are dropped, and videos and embeds
+// become links.
+func RenderBlocks(content *models.EditorJsContent, resolve ImageResolver) string {
+	if content == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for i := range content.Blocks {
+		renderBlock(&sb, &content.Blocks[i], resolve)
+	}
+	return sb.String()
+}
+
+func renderBlock(sb *strings.Builder, block *models.EditorJsBlock, resolve ImageResolver) {
+	data := block.Data
+	switch block.Type {
+	case "paragraph":
+		writeElement(sb, "p", "", Inline(str(data, "text")))
+	case "header":
+		level := 2
+		if l, ok := data["level"].(float64); ok && l >= 2 && l <= 6 {
+			level = int(l)
+		}
+		writeElement(sb, fmt.Sprintf("h%d", level), "", Inline(str(data, "text")))
+	case "list":
+		tag := "ul"
+		if str(data, "style") == "ordered" {
+			tag = "ol"
+		}
+		items, _ := data["items"].([]interface{})
+		writeList(sb, tag, items)
+	case "checklist":
+		items, _ := data["items"].([]interface{})
+		if len(items) == 0 {
+			return
+		}
+		sb.WriteString("<ul class=\"checklist\">\n")
+		for _, it := range items {
+			item, _ := it.(map[string]interface{})
+			mark := "☐"
+			if checked, _ := item["checked"].(bool); checked {
+				mark = "☑"
+			}
+			sb.WriteString("<li>" + mark + " " + Inline(str(item, "text")) + "</li>\n")
+		}
+		sb.WriteString("</ul>\n")
+	case "quote":
+		text := Inline(str(data, "text"))
+		if text == "" {
+			return
+		}
+		sb.WriteString("<blockquote>\n<p>" + text + "</p>\n")
+		if caption := Inline(str(data, "caption")); caption != "" {
+			sb.WriteString("<p class=\"quote-caption\">" + caption + "</p>\n")
+		}
+		sb.WriteString("</blockquote>\n")
+	case "code":
+		if code := str(data, "code"); code != "" {
+			sb.WriteString("<pre><code>" + Escape(code) + "</code></pre>\n")
+		}
+	case "delimiter":
+		sb.WriteString("<hr/>\n")
+	case "image":
+		renderImage(sb, block, resolve)
+	case "table":
+		renderTable(sb, data)
+	case "warning":
+		title, message := Inline(str(data, "title")), Inline(str(data, "message"))
+		if title == "" && message == "" {
+			return
+		}
+		sb.WriteString("<aside class=\"warning\">\n")
+		writeElement(sb, "p", "warning-title", title)
+		writeElement(sb, "p", "", message)
+		sb.WriteString("</aside>\n")
+	case "linkTool":
+		link := str(data, "link")
+		title := link
+		if meta, ok := data["meta"].(map[string]interface{}); ok && str(meta, "title") != "" {
+			title = str(meta, "title")
+		}
+		writeLink(sb, link, title)
+	case "embed":
+		label := PlainText(str(data, "caption"))
+		if label == "" {
+			label = str(data, "source")
+		}
+		writeLink(sb, str(data, "source"), label)
+	case "video":
+		url := str(data, "url")
+		if file, ok := data["file"].(map[string]interface{}); ok && str(file, "url") != "" {
+			url = str(file, "url")
+		}
+		label := PlainText(str(data, "caption"))
+		if label == "" {
+			label = "Video"
+		}
+		writeLink(sb, url, label)
+	case "raw":
+		// Arbitrary HTML can't be trusted to be valid XHTML.
+	default:
+		writeElement(sb, "p", "", Inline(str(data, "text")))
+	}
+}
+
+func renderImage(sb *strings.Builder, block *models.EditorJsBlock, resolve ImageResolver) {
+	data := block.Data
+	url := str(data, "url")
+	if file, ok := data["file"].(map[string]interface{}); ok && str(file, "url") != "" {
+		url = str(file, "url")
+	}
+	caption := Inline(str(data, "caption"))
+	href, ok := "", false
+	if url != "" {
+		href, ok = resolve(url)
+	}
+	if !ok {
+		writeElement(sb, "p", "caption", caption)
+		return
+	}
+	sb.WriteString("<figure>\n")
+	fmt.Fprintf(sb, "<img src=\"%s\" alt=\"%s\"/>\n", Escape(href), Escape(PlainText(str(data, "caption"))))
+	if caption != "" {
+		sb.WriteString("<figcaption>" + caption + "</figcaption>\n")
+	}
+	sb.WriteString("</figure>\n")
+}
+
+func renderTable(sb *strings.Builder, data map[string]interface{}) {
+	rows, _ := data["content"].([]interface{})
+	if len(rows) == 0 {
+		return
+	}
+	headings, _ := data["withHeadings"].(bool)
+	sb.WriteString("<table>\n")
+	for i, r := range rows {
+		cells, _ := r.([]interface{})
+		cell := "td"
+		if headings && i == 0 {
+			cell = "th"
+		}
+		sb.WriteString("<tr>")
+		for _, c := range cells {
+			text, _ := c.(string)
+			sb.WriteString("<" + cell + ">" + Inline(text) + "</" + cell + ">")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</table>\n")
+}
+
+// writeList writes a list whose items are either strings or, for nested
+// lists, objects with content and items.
+func writeList(sb *strings.Builder, tag string, items []interface{}) {
+	if len(items) == 0 {
+		return
+	}
+	sb.WriteString("<" + tag + ">\n")
+	for _, it := range items {
+		switch item := it.(type) {
+		case string:
+			sb.WriteString("<li>" + Inline(item) + "</li>\n")
+		case map[string]interface{}:
+			sb.WriteString("<li>" + Inline(str(item, "content")))
+			if children, _ := item["items"].([]interface{}); len(children) > 0 {
+				sb.WriteString("\n")
+				writeList(sb, tag, children)
+			}
+			sb.WriteString("</li>\n")
+		}
+	}
+	sb.WriteString("</" + tag + ">\n")
+}
+
+// writeLink writes a paragraph linking to url, or just the label when url
+// isn't an absolute http(s) URL.
+func writeLink(sb *strings.Builder, url, label string) {
+	lower := strings.ToLower(url)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		writeElement(sb, "p", "link", Escape(label))
+		return
+	}
+	fmt.Fprintf(sb, "<p class=\"link\"><a href=\"%s\">%s</a></p>\n", Escape(url), Escape(label))
+}
+
+// writeElement writes <tag class="class">inner</tag>, or nothing when inner
+// is empty. inner must already be XHTML.
+func writeElement(sb *strings.Builder, tag, class, inner string) {
+	if inner == "" {
+		return
+	}
+	if class != "" {
+		fmt.Fprintf(sb, "<%s class=\"%s\">%s</%s>\n", tag, class, inner, tag)
+		return
+	}
+	fmt.Fprintf(sb, "<%s>%s</%s>\n", tag, inner, tag)
+}
+
+func str(data map[string]interface{}, key string) string {
+	s, _ := data[key].(string)
+	return s
+}
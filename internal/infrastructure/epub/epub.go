@@ -0,0 +1,231 @@
+// Package epub writes EPUB 3 books: the OCF container, the package document,
+// the navigation document and whatever XHTML documents and images the caller
+// supplies. It knows nothing about where the content comes from.
+package epub
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// MediaType is the MIME type of an EPUB file.
+const MediaType = "application/epub+zip"
+
+// contentDir holds everything but the container files. Hrefs in Document,
+// Image and NavPoint are relative to it.
+const contentDir = "OEBPS/"
+
+// Book is everything that goes into one EPUB file. Documents are the spine,
+// in reading order; the cover page, when Cover is set, is added in front of
+// them.
+type Book struct {
+	// Identifier is the book's unique id, e.g. "urn:uuid:…".
+	Identifier string
+	Title      string
+	Author     string
+	Language   string
+	Modified   time.Time
+
+	Cover      *Image
+	Documents  []Document
+	Images     []Image
+	TOC        []NavPoint
+	Stylesheet string
+}
+
+// Document is one XHTML content document. Body is the markup that goes
+// inside <body>, already well-formed XHTML. Type is its epub:type
+// ("chapter", "appendix", …), set on the body.
+type Document struct {
+	ID    string
+	Href  string
+	Title string
+	Type  string
+	Body  string
+}
+
+// Image is an image resource referenced by the documents.
+type Image struct {
+	ID        string
+	Href      string
+	MediaType string
+	Data      []byte
+}
+
+// NavPoint is an entry of the table of contents. An entry without Href is a
+// heading for its children.
+type NavPoint struct {
+	Title    string
+	Href     string
+	Children []NavPoint
+}
+
+const (
+	navHref        = "nav.xhtml"
+	coverHref      = "cover.xhtml"
+	stylesheetHref = "style.css"
+)
+
+// Write writes the book as an EPUB (zip) stream to w.
+func (b *Book) Write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	// The mimetype entry must come first and be stored uncompressed so
+	// readers can identify the file by its leading bytes.
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("failed to write mimetype: %w", err)
+	}
+	if _, err := io.WriteString(mw, MediaType); err != nil {
+		return fmt.Errorf("failed to write mimetype: %w", err)
+	}
+
+	type entry struct{ name, data string }
+	files := []entry{
+		{"META-INF/container.xml", containerXML},
+		{contentDir + "content.opf", b.packageDocument()},
+		{contentDir + navHref, b.navDocument()},
+		{contentDir + stylesheetHref, b.Stylesheet},
+	}
+	if b.Cover != nil {
+		files = append(files, entry{contentDir + coverHref, b.coverDocument()})
+	}
+	for _, d := range b.Documents {
+		files = append(files, entry{contentDir + d.Href, b.document(d.Title, d.Type, d.Body)})
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+		if _, err := io.WriteString(fw, f.data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	images := b.Images
+	if b.Cover != nil {
+		images = append([]Image{*b.Cover}, images...)
+	}
+	for _, img := range images {
+		// Images are already compressed; deflating them again only costs time.
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: contentDir + img.Href, Method: zip.Store})
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", img.Href, err)
+		}
+		if _, err := fw.Write(img.Data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", img.Href, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish epub: %w", err)
+	}
+	return nil
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="` + contentDir + `content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+func (b *Book) packageDocument() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" xml:lang="` + Escape(b.Language) + `">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+`)
+	fmt.Fprintf(&sb, "    <dc:identifier id=\"book-id\">%s</dc:identifier>\n", Escape(b.Identifier))
+	fmt.Fprintf(&sb, "    <dc:title>%s</dc:title>\n", Escape(b.Title))
+	fmt.Fprintf(&sb, "    <dc:language>%s</dc:language>\n", Escape(b.Language))
+	if b.Author != "" {
+		fmt.Fprintf(&sb, "    <dc:creator>%s</dc:creator>\n", Escape(b.Author))
+	}
+	fmt.Fprintf(&sb, "    <meta property=\"dcterms:modified\">%s</meta>\n", b.Modified.UTC().Format("2006-01-02T15:04:05Z"))
+	sb.WriteString("  </metadata>\n  <manifest>\n")
+	fmt.Fprintf(&sb, "    <item id=\"nav\" href=\"%s\" media-type=\"application/xhtml+xml\" properties=\"nav\"/>\n", navHref)
+	fmt.Fprintf(&sb, "    <item id=\"style\" href=\"%s\" media-type=\"text/css\"/>\n", stylesheetHref)
+	if b.Cover != nil {
+		fmt.Fprintf(&sb, "    <item id=\"cover\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", coverHref)
+		fmt.Fprintf(&sb, "    <item id=\"%s\" href=\"%s\" media-type=\"%s\" properties=\"cover-image\"/>\n",
+			Escape(b.Cover.ID), Escape(b.Cover.Href), Escape(b.Cover.MediaType))
+	}
+	for _, d := range b.Documents {
+		fmt.Fprintf(&sb, "    <item id=\"%s\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", Escape(d.ID), Escape(d.Href))
+	}
+	for _, img := range b.Images {
+		fmt.Fprintf(&sb, "    <item id=\"%s\" href=\"%s\" media-type=\"%s\"/>\n", Escape(img.ID), Escape(img.Href), Escape(img.MediaType))
+	}
+	sb.WriteString("  </manifest>\n  <spine>\n")
+	if b.Cover != nil {
+		sb.WriteString("    <itemref idref=\"cover\" linear=\"no\"/>\n")
+	}
+	for _, d := range b.Documents {
+		fmt.Fprintf(&sb, "    <itemref idref=\"%s\"/>\n", Escape(d.ID))
+	}
+	sb.WriteString("  </spine>\n</package>\n")
+	return sb.String()
+}
+
+func (b *Book) navDocument() string {
+	var sb strings.Builder
+	sb.WriteString("<nav epub:type=\"toc\" id=\"toc\">\n<h1>")
+	sb.WriteString(Escape(b.Title))
+	sb.WriteString("</h1>\n")
+	writeNavList(&sb, b.TOC)
+	sb.WriteString("</nav>\n")
+	if b.Cover != nil {
+		sb.WriteString("<nav epub:type=\"landmarks\" hidden=\"hidden\">\n<ol>\n")
+		fmt.Fprintf(&sb, "<li><a epub:type=\"cover\" href=\"%s\">Cover</a></li>\n", coverHref)
+		sb.WriteString("</ol>\n</nav>\n")
+	}
+	return b.document(b.Title, "", sb.String())
+}
+
+func writeNavList(sb *strings.Builder, points []NavPoint) {
+	sb.WriteString("<ol>\n")
+	for _, p := range points {
+		sb.WriteString("<li>")
+		if p.Href != "" {
+			fmt.Fprintf(sb, "<a href=\"%s\">%s</a>", Escape(p.Href), Escape(p.Title))
+		} else {
+			fmt.Fprintf(sb, "<span>%s</span>", Escape(p.Title))
+		}
+		if len(p.Children) > 0 {
+			sb.WriteString("\n")
+			writeNavList(sb, p.Children)
+		}
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ol>\n")
+}
+
+func (b *Book) coverDocument() string {
+	body := fmt.Sprintf("<section epub:type=\"cover\" class=\"cover\">\n<img src=\"%s\" alt=\"%s\"/>\n</section>\n",
+		Escape(b.Cover.Href), Escape(b.Title))
+	return b.document(b.Title, "", body)
+}
+
+// document wraps body in an XHTML content document.
+func (b *Book) document(title, epubType, body string) string {
+	bodyOpen := "<body>"
+	if epubType != "" {
+		bodyOpen = fmt.Sprintf("<body epub:type=\"%s\">", Escape(epubType))
+	}
+	lang := Escape(b.Language)
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="` + lang + `" lang="` + lang + `">
+<head>
+<meta charset="UTF-8"/>
+<title>` + Escape(title) + `</title>
+<link rel="stylesheet" type="text/css" href="` + stylesheetHref + `"/>
+</head>
+` + bodyOpen + "\n" + body + "</body>\n</html>\n"
+}
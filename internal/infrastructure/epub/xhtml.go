@@ -0,0 +1,151 @@
+package epub
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var escaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&#39;",
+)
+
+// Escape escapes s for use as XML text or attribute value, dropping
+// characters XML doesn't allow at all.
+func Escape(s string) string {
+	return escaper.Replace(strings.Map(xmlChar, s))
+}
+
+// xmlChar drops the control characters and non-characters XML 1.0 forbids.
+func xmlChar(r rune) rune {
+	switch {
+	case r == '\t' || r == '\n' || r == '\r':
+		return r
+	case r < 0x20, r == 0xFFFE, r == 0xFFFF:
+		return -1
+	}
+	return r
+}
+
+// inlineTags are the inline elements kept from rich text; any other element
+// is dropped and its text kept.
+var inlineTags = map[atom.Atom]bool{
+	atom.B: true, atom.Strong: true, atom.I: true, atom.Em: true,
+	atom.U: true, atom.S: true, atom.Del: true, atom.Code: true,
+	atom.Mark: true, atom.Sub: true, atom.Sup: true, atom.Small: true,
+}
+
+// Inline converts an HTML fragment as stored by Editor.js inline tools
+// (bold, italic, links, markers, &nbsp;, <br>) into well-formed XHTML. Only
+// a small set of inline elements survives, without attributes; links keep
+// an http(s) or mailto href.
+func Inline(fragment string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return Escape(fragment)
+	}
+	var sb strings.Builder
+	for _, n := range nodes {
+		writeInline(&sb, n)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func writeInline(sb *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		sb.WriteString(Escape(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch {
+	case n.DataAtom == atom.Br:
+		sb.WriteString("<br/>")
+		return
+	case n.DataAtom == atom.A:
+		if href := linkHref(n); href != "" {
+			sb.WriteString(`<a href="` + Escape(href) + `">`)
+			writeChildren(sb, n)
+			sb.WriteString("</a>")
+			return
+		}
+	case inlineTags[n.DataAtom]:
+		sb.WriteString("<" + n.Data + ">")
+		writeChildren(sb, n)
+		sb.WriteString("</" + n.Data + ">")
+		return
+	case n.DataAtom == atom.Script || n.DataAtom == atom.Style:
+		return
+	}
+	writeChildren(sb, n)
+}
+
+func writeChildren(sb *strings.Builder, n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeInline(sb, c)
+	}
+}
+
+func linkHref(n *html.Node) string {
+	for _, a := range n.Attr {
+		if a.Key != "href" {
+			continue
+		}
+		href := strings.TrimSpace(a.Val)
+		lower := strings.ToLower(href)
+		if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "mailto:") {
+			return href
+		}
+	}
+	return ""
+}
+
+// PlainText returns the text of an HTML fragment with all markup removed,
+// unescaped (escape it again to embed it).
+func PlainText(fragment string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return fragment
+	}
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// Paragraphs renders plain text as XHTML paragraphs, one per non-blank line.
+func Paragraphs(text string) string {
+	var sb strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			sb.WriteString("<p>" + Escape(line) + "</p>\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,203 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// Errors returned (wrapped) by Fetcher. Callers match them with errors.Is.
+var (
+	ErrInvalidURL       = errors.New("URL must be absolute http or https")
+	ErrBlockedAddress   = errors.New("destination address is not allowed")
+	ErrTooManyRedirects = errors.New("too many redirects")
+	ErrBodyTooLarge     = errors.New("response body exceeds size limit")
+)
+
+// userAgent identifies the API to remote hosts; some reject Go's default.
+const userAgent = "Mozilla/5.0 (compatible; BlogAPI/1.0; +http://example.com/bot)"
+
+// Options bounds what a single fetch may do.
+type Options struct {
+	// Timeout covers the whole request including redirects and reading the
+	// body.
+	Timeout time.Duration
+	// MaxRedirects is how many redirects are followed before giving up.
+	MaxRedirects int
+	// MaxBytes caps the response body; reading past it fails with
+	// ErrBodyTooLarge. Zero means no cap.
+	MaxBytes int64
+}
+
+// Fetcher makes outbound HTTP requests to user-supplied URLs without letting
+// them reach the API's own network. The destination IP is checked when the
+// connection is dialed — after DNS resolution, and again for every redirect
+// hop — so neither a hostname resolving to 127.0.0.1 nor a redirect to
+// http://169.254.169.254/ gets through.
+type Fetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+// New creates a Fetcher. Proxies from the environment are deliberately not
+// used: the proxy's address would be checked instead of the destination's.
+func New(opts Options) *Fetcher {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || IsBlockedIP(ip) {
+				return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	maxRedirects := opts.MaxRedirects
+	return &Fetcher{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   opts.Timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > maxRedirects {
+					return ErrTooManyRedirects
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return ErrInvalidURL
+				}
+				return nil
+			},
+		},
+		maxBytes: opts.MaxBytes,
+	}
+}
+
+// Get fetches rawURL. A non-2xx status is not an error; callers check
+// StatusCode. The caller must close the returned body, which enforces the
+// configured MaxBytes. resp.Request.URL is the final URL after redirects.
+func (f *Fetcher) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	return f.do(ctx, http.MethodGet, rawURL)
+}
+
+// Head is Get without a response body, for checking that a URL resolves.
+// Redirects are followed the same way.
+func (f *Fetcher) Head(ctx context.Context, rawURL string) (*http.Response, error) {
+	return f.do(ctx, http.MethodHead, rawURL)
+}
+
+// Redirects returns how many redirects were followed to produce resp.
+func Redirects(resp *http.Response) int {
+	n := 0
+	for req := resp.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		n++
+	}
+	return n
+}
+
+func (f *Fetcher) do(ctx context.Context, method, rawURL string) (*http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.maxBytes > 0 {
+		if resp.ContentLength > f.maxBytes {
+			resp.Body.Close()
+			return nil, ErrBodyTooLarge
+		}
+		resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: f.maxBytes}
+	}
+	return resp, nil
+}
+
+// limitedBody fails with ErrBodyTooLarge once more than the cap is read,
+// rather than silently truncating like io.LimitReader.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrBodyTooLarge
+	}
+	// Allow one byte past the cap to tell "exactly the cap" from "more".
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n, ErrBodyTooLarge
+	}
+	return n, err
+}
+
+// blockedNets are ranges not covered by the net.IP helpers that still must
+// not be reachable: shared address space (CGNAT), the IETF protocol block,
+// benchmarking, and the reserved 240/4.
+var blockedNets = func() []*net.IPNet {
+	cidrs := []string{
+		"0.0.0.0/8",
+		"100.64.0.0/10",
+		"192.0.0.0/24",
+		"198.18.0.0/15",
+		"240.0.0.0/4",
+	}
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, _ := net.ParseCIDR(c)
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// IsBlockedIP reports whether ip is loopback, private, link-local, multicast,
+// unspecified or otherwise reserved — anything a user-supplied URL should not
+// be able to reach from the server.
+func IsBlockedIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	for _, n := range blockedNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
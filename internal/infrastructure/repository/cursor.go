@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// errInvalidCursor is matched as a substring by handlers to map to
+// INVALID_CURSOR.
+const errInvalidCursor = "invalid cursor"
+
+// cursorPrev marks a cursor that walks back towards the start of the listing.
+const cursorPrev = "prev"
+
+// keysetCursor is a position in a sorted listing: the value of the sort
+// column and the id that breaks ties. A next cursor points at the last row of
+// a page, a prev cursor at the first. Sort is the field the cursor was issued
+// for, so a cursor can't be replayed against a different ordering.
+type keysetCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+	Dir   string `json:"d,omitempty"`
+}
+
+// encodeCursor returns the opaque form handed to clients.
+func encodeCursor(c keysetCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor parses a cursor issued for sortBy.
+func decodeCursor(s, sortBy string) (*keysetCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New(errInvalidCursor)
+	}
+	var c keysetCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, errors.New(errInvalidCursor)
+	}
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return nil, errors.New(errInvalidCursor)
+	}
+	if c.Dir != "" && c.Dir != cursorPrev {
+		return nil, errors.New(errInvalidCursor)
+	}
+	if c.Sort != sortBy {
+		return nil, errors.New(errInvalidCursor + ": issued for a different sort")
+	}
+	return &c, nil
+}
+
+// backward reports whether the page is read in reverse from the cursor.
+func (c *keysetCursor) backward() bool {
+	return c != nil && c.Dir == cursorPrev
+}
+
+// applyKeyset orders query by column then idColumn and, given a cursor,
+// starts after its row. value is the cursor's sort value in the column's type.
+// A prev cursor flips the order so the rows nearest the cursor come first;
+// trimKeysetPage puts them back.
+func applyKeyset(query *gorm.DB, column, idColumn, sortOrder string, cursor *keysetCursor, value interface{}) *gorm.DB {
+	order := sortOrder
+	if cursor.backward() {
+		order = "asc"
+		if sortOrder == "asc" {
+			order = "desc"
+		}
+	}
+	if cursor != nil {
+		op := "<"
+		if order == "asc" {
+			op = ">"
+		}
+		query = query.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", column, idColumn, op), value, cursor.ID)
+	}
+	return query.Order(fmt.Sprintf("%s %s, %s %s", column, order, idColumn, order))
+}
+
+// trimKeysetPage drops the look-ahead row (queries fetch limit+1) and restores
+// display order. extra reports whether the look-ahead row was there, i.e.
+// whether more rows lie beyond the page in the direction it was read.
+func trimKeysetPage[T any](rows []T, limit int, cursor *keysetCursor) (page []T, extra bool) {
+	extra = len(rows) > limit
+	if extra {
+		rows = rows[:limit]
+	}
+	if cursor.backward() {
+		slices.Reverse(rows)
+	}
+	return rows, extra
+}
+
+// keysetMeta builds the pagination envelope for a keyset-capable listing.
+// total < 0 means the count was skipped. first and last are the cursors of
+// the page's first and last rows (nil for an empty page); offset is the
+// number of rows skipped in page mode.
+func keysetMeta(total int64, page, limit, offset int, cursor *keysetCursor, extra bool, first, last *keysetCursor) *models.PaginationMeta {
+	hasNext, hasPrev := extra, cursor != nil || offset > 0
+	if cursor.backward() {
+		// We came from the page after this one.
+		hasNext, hasPrev = true, extra
+	}
+
+	meta := &models.PaginationMeta{
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: -1,
+		HasMore:    hasNext,
+	}
+	if total >= 0 {
+		meta.TotalPages = int(math.Ceil(float64(total) / float64(limit)))
+	}
+	if hasNext && last != nil {
+		meta.NextCursor = encodeCursor(*last)
+	}
+	if hasPrev && first != nil {
+		prev := *first
+		prev.Dir = cursorPrev
+		meta.PrevCursor = encodeCursor(prev)
+	}
+	return meta
+}
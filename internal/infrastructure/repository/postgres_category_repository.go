@@ -64,10 +64,11 @@ func (r *PostgresCategoryRepository) Exists(id int) (bool, error) {
 func (r *PostgresCategoryRepository) CountPostsByCategory() ([]models.CategoryWithCount, error) {
 	var rows []models.CategoryWithCount
 	// LEFT JOIN so categories with zero posts still appear in the breakdown.
-	// Internal categories (e.g. Drafts) never appear in the public count.
+	// Internal categories (e.g. Drafts) never appear in the public count, nor
+	// do queued Whitenest chapters.
 	err := r.db.Table("categories AS c").
 		Select("c.id AS id, c.name AS name, COUNT(p.id) AS total_posts").
-		Joins("LEFT JOIN posts AS p ON p.category_id = c.id").
+		Joins("LEFT JOIN posts AS p ON p.category_id = c.id AND p.release_at IS NULL").
 		Where("c.is_internal = ?", false).
 		Group("c.id, c.name").
 		Order("c.name ASC").
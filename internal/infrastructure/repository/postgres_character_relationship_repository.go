@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type PostgresCharacterRelationshipRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresCharacterRelationshipRepository(db *gorm.DB) repositories.CharacterRelationshipRepository {
+	return &PostgresCharacterRelationshipRepository{db: db}
+}
+
+func (r *PostgresCharacterRelationshipRepository) Create(rel *models.CharacterRelationship) error {
+	if err := r.db.Omit("Source", "Target").Create(rel).Error; err != nil {
+		return fmt.Errorf("failed to create relationship: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresCharacterRelationshipRepository) Update(id string, rel *models.CharacterRelationship) error {
+	res := r.db.Model(&models.CharacterRelationship{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"source_id":     rel.SourceID,
+		"target_id":     rel.TargetID,
+		"type":          rel.Type,
+		"directed":      rel.Directed,
+		"description":   rel.Description,
+		"since_chapter": rel.SinceChapter,
+	})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update relationship: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCharacterRelationshipRepository) Delete(id string) error {
+	res := r.db.Delete(&models.CharacterRelationship{}, "id = ?", id)
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete relationship: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCharacterRelationshipRepository) FindByID(id string) (*models.CharacterRelationship, error) {
+	var rel models.CharacterRelationship
+	err := r.db.Where("id = ?", id).First(&rel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch relationship: %w", err)
+	}
+	return &rel, nil
+}
+
+func (r *PostgresCharacterRelationshipRepository) FindAll(filters models.RelationshipFilters) ([]*models.CharacterRelationship, error) {
+	query := r.db.Model(&models.CharacterRelationship{})
+	if filters.CharacterID != "" {
+		query = query.Where("source_id = ? OR target_id = ?", filters.CharacterID, filters.CharacterID)
+	}
+	var rels []*models.CharacterRelationship
+	if err := query.Order("created_at ASC, id ASC").Find(&rels).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch relationships: %w", err)
+	}
+	return rels, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/davidrdsilva/blog-api/internal/domain/models"
 	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresCharacterRepository struct {
@@ -33,6 +34,7 @@ func (r *PostgresCharacterRepository) Update(id string, character *models.Charac
 		"location":    character.Location,
 		"portrait":    character.Portrait,
 		"skills":      character.Skills,
+		"sections":    character.Sections,
 	})
 	if res.Error != nil {
 		return fmt.Errorf("failed to update character: %w", res.Error)
@@ -112,3 +114,270 @@ func (r *PostgresCharacterRepository) Exists(id string) (bool, error) {
 	}
 	return count > 0, nil
 }
+
+// appearanceColumns selects into models.CharacterAppearance.
+const appearanceColumns = `pc.character_id, p.id AS post_id, p.title,
+	p.whitenest_chapter_number AS chapter_number, pc.position,
+	pc.skills, pc.occupation, pc.location`
+
+// castQuery joins cast rows to the posts that are currently Whitenest
+// chapters, queued ones included.
+func (r *PostgresCharacterRepository) castQuery() *gorm.DB {
+	return r.db.Table("posts_characters pc").
+		Joins("JOIN posts p ON p.id = pc.post_id").
+		Where("p.whitenest_chapter_number IS NOT NULL")
+}
+
+// appearancesQuery is castQuery limited to released chapters, so appearances
+// never give away a queued chapter.
+func (r *PostgresCharacterRepository) appearancesQuery() *gorm.DB {
+	return r.castQuery().Where("p.release_at IS NULL")
+}
+
+func (r *PostgresCharacterRepository) FindAppearances(characterID string) ([]models.CharacterAppearance, error) {
+	var rows []models.CharacterAppearance
+	err := r.appearancesQuery().
+		Select(appearanceColumns).
+		Where("pc.character_id = ?", characterID).
+		Order("p.whitenest_chapter_number ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch character appearances: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *PostgresCharacterRepository) FindAllAppearances() ([]models.CharacterAppearance, error) {
+	var rows []models.CharacterAppearance
+	err := r.appearancesQuery().
+		Select(appearanceColumns).
+		Order("p.whitenest_chapter_number ASC, pc.position ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cast entries: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *PostgresCharacterRepository) AppearanceStats(ids []string, upTo int) (map[string]models.CharacterAppearanceStats, error) {
+	query := r.appearancesQuery().
+		Select(`pc.character_id,
+			COUNT(DISTINCT p.id) AS count,
+			MIN(p.whitenest_chapter_number) AS first_chapter,
+			MAX(p.whitenest_chapter_number) AS last_chapter`).
+		Group("pc.character_id")
+	if len(ids) > 0 {
+		query = query.Where("pc.character_id IN ?", ids)
+	}
+	if upTo > 0 {
+		query = query.Where("p.whitenest_chapter_number <= ?", upTo)
+	}
+
+	var rows []models.CharacterAppearanceStats
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count character appearances: %w", err)
+	}
+	out := make(map[string]models.CharacterAppearanceStats, len(rows))
+	for _, row := range rows {
+		out[row.CharacterID] = row
+	}
+	return out, nil
+}
+
+func (r *PostgresCharacterRepository) FindStateOverrides(characterIDs []string, upTo int, includeQueued bool) ([]models.CharacterAppearance, error) {
+	if len(characterIDs) == 0 {
+		return nil, nil
+	}
+	query := r.appearancesQuery()
+	if includeQueued {
+		query = r.castQuery()
+	}
+	var rows []models.CharacterAppearance
+	err := query.
+		Select(appearanceColumns).
+		Where("pc.character_id IN ?", characterIDs).
+		Where("p.whitenest_chapter_number <= ?", upTo).
+		Where("pc.skills IS NOT NULL OR pc.occupation IS NOT NULL OR pc.location IS NOT NULL").
+		Order("p.whitenest_chapter_number ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch character state overrides: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *PostgresCharacterRepository) UpdateCastState(postID, characterID string, override models.CharacterStateOverride) error {
+	res := r.db.Model(&models.PostsCharacter{}).
+		Where("post_id = ? AND character_id = ?", postID, characterID).
+		Select("skills", "occupation", "location").
+		Updates(map[string]interface{}{
+			"skills":     override.Skills,
+			"occupation": override.Occupation,
+			"location":   override.Location,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update cast state: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCharacterRepository) FindCastEntries(characterID string) ([]models.CastEntry, error) {
+	var rows []models.CastEntry
+	err := r.db.Table("posts_characters pc").
+		Select("pc.post_id, p.title, p.whitenest_chapter_number AS chapter_number, pc.position").
+		Joins("JOIN posts p ON p.id = pc.post_id").
+		Where("pc.character_id = ?", characterID).
+		Order("p.whitenest_chapter_number ASC NULLS LAST, p.title ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cast entries: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *PostgresCharacterRepository) MergeInto(duplicateID, canonicalID string) (*models.CharacterMergeResult, error) {
+	result := &models.CharacterMergeResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock both rows so a concurrent edit or delete can't interleave.
+		var locked []string
+		if err := tx.Model(&models.Character{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []string{duplicateID, canonicalID}).
+			Pluck("id", &locked).Error; err != nil {
+			return fmt.Errorf("failed to lock characters: %w", err)
+		}
+		if len(locked) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := mergeCast(tx, duplicateID, canonicalID, result); err != nil {
+			return err
+		}
+		if err := mergeRelationships(tx, duplicateID, canonicalID, result); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.Character{}, "id = ?", duplicateID).Error; err != nil {
+			return fmt.Errorf("failed to delete merged character: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mergeCast repoints the duplicate's cast rows to the canonical character.
+// In posts where both are cast, the canonical row takes the earlier of the
+// two positions and any override field it lacks, the duplicate's row is
+// deleted, and the post's positions are renumbered to close the gap.
+func mergeCast(tx *gorm.DB, duplicateID, canonicalID string, result *models.CharacterMergeResult) error {
+	var dupRows, canonRows []models.PostsCharacter
+	if err := tx.Where("character_id = ?", duplicateID).Find(&dupRows).Error; err != nil {
+		return fmt.Errorf("failed to fetch cast rows: %w", err)
+	}
+	if err := tx.Where("character_id = ? AND post_id IN (?)", canonicalID,
+		tx.Model(&models.PostsCharacter{}).Select("post_id").Where("character_id = ?", duplicateID),
+	).Find(&canonRows).Error; err != nil {
+		return fmt.Errorf("failed to fetch cast rows: %w", err)
+	}
+	canonByPost := make(map[string]models.PostsCharacter, len(canonRows))
+	for _, row := range canonRows {
+		canonByPost[row.PostID] = row
+	}
+
+	var folded []string
+	for _, dup := range dupRows {
+		canon, both := canonByPost[dup.PostID]
+		if !both {
+			if err := tx.Model(&models.PostsCharacter{}).Where("id = ?", dup.ID).
+				Update("character_id", canonicalID).Error; err != nil {
+				return fmt.Errorf("failed to move cast row: %w", err)
+			}
+			result.CastMoved++
+			continue
+		}
+
+		override := canon.CharacterStateOverride.Or(dup.CharacterStateOverride)
+		if err := tx.Delete(&models.PostsCharacter{}, "id = ?", dup.ID).Error; err != nil {
+			return fmt.Errorf("failed to delete duplicate cast row: %w", err)
+		}
+		if err := tx.Model(&models.PostsCharacter{}).Where("id = ?", canon.ID).
+			Select("position", "skills", "occupation", "location").
+			Updates(map[string]interface{}{
+				"position":   min(canon.Position, dup.Position),
+				"skills":     override.Skills,
+				"occupation": override.Occupation,
+				"location":   override.Location,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to merge cast row: %w", err)
+		}
+		folded = append(folded, dup.PostID)
+		result.CastMerged++
+	}
+
+	if len(folded) > 0 {
+		if err := tx.Exec(`
+			UPDATE posts_characters pc SET position = ranked.rn - 1
+			FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY post_id ORDER BY position, id) AS rn
+				FROM posts_characters WHERE post_id IN ?
+			) ranked
+			WHERE pc.id = ranked.id`, folded).Error; err != nil {
+			return fmt.Errorf("failed to renumber cast positions: %w", err)
+		}
+	}
+	return nil
+}
+
+// mergeRelationships repoints the duplicate's relationships to the canonical
+// character, keeping undirected pairs normalized (smaller ID as source).
+// Relationships between the two, and ones that would repeat an existing
+// (source, target, type), are dropped.
+func mergeRelationships(tx *gorm.DB, duplicateID, canonicalID string, result *models.CharacterMergeResult) error {
+	var rels []models.CharacterRelationship
+	if err := tx.Where("source_id = ? OR target_id = ?", duplicateID, duplicateID).Find(&rels).Error; err != nil {
+		return fmt.Errorf("failed to fetch relationships: %w", err)
+	}
+	for _, rel := range rels {
+		source, target := rel.SourceID, rel.TargetID
+		if source == duplicateID {
+			source = canonicalID
+		}
+		if target == duplicateID {
+			target = canonicalID
+		}
+		if !rel.Directed && source > target {
+			source, target = target, source
+		}
+
+		drop := source == target
+		if !drop {
+			var existing int64
+			if err := tx.Model(&models.CharacterRelationship{}).
+				Where("source_id = ? AND target_id = ? AND type = ? AND id <> ?", source, target, rel.Type, rel.ID).
+				Count(&existing).Error; err != nil {
+				return fmt.Errorf("failed to check relationships: %w", err)
+			}
+			drop = existing > 0
+		}
+		if drop {
+			if err := tx.Delete(&models.CharacterRelationship{}, "id = ?", rel.ID).Error; err != nil {
+				return fmt.Errorf("failed to drop relationship: %w", err)
+			}
+			result.RelationshipsDropped++
+			continue
+		}
+
+		if err := tx.Model(&models.CharacterRelationship{}).Where("id = ?", rel.ID).
+			Updates(map[string]interface{}{"source_id": source, "target_id": target}).Error; err != nil {
+			return fmt.Errorf("failed to move relationship: %w", err)
+		}
+		result.RelationshipsMoved++
+	}
+	return nil
+}
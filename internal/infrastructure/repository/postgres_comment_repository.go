@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/davidrdsilva/blog-api/internal/domain/models"
 	"gorm.io/gorm"
@@ -43,8 +46,14 @@ func (r *PostgresCommentRepository) FindAllByPostID(postID string) ([]*models.Co
 	return comments, nil
 }
 
-func (r *PostgresCommentRepository) FindAll(filters models.CommentFilters) ([]*models.Comment, error) {
+// FindAll lists comments with filtering, sorting and pagination. Only the
+// fields in commentSortColumns can be sorted on; anything else falls back to
+// createdAt. With filters.Cursor set the page starts next to the cursor's row
+// (keyset paging) instead of at an offset, so comments added while a reader
+// loads more don't shift the next page.
+func (r *PostgresCommentRepository) FindAll(filters models.CommentFilters) ([]*models.Comment, *models.PaginationMeta, error) {
 	var comments []*models.Comment
+	total := int64(-1)
 
 	query := r.db.Model(&models.Comment{})
 
@@ -54,18 +63,95 @@ func (r *PostgresCommentRepository) FindAll(filters models.CommentFilters) ([]*m
 	if filters.Author != "" {
 		query = query.Where("author = ?", filters.Author)
 	}
-	if filters.SortBy != "" {
-		query = query.Order(filters.SortBy)
+
+	if !filters.SkipCount {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, nil, fmt.Errorf("failed to count comments: %w", err)
+		}
+	}
+
+	sortBy := filters.SortBy
+	column, ok := commentSortColumns[sortBy]
+	if !ok {
+		sortBy = "createdAt"
+		column = commentSortColumns[sortBy]
+	}
+	sortOrder := "desc"
+	if strings.ToLower(filters.SortOrder) == "asc" {
+		sortOrder = "asc"
+	}
+
+	page := filters.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := filters.Limit
+	if limit < 1 {
+		limit = 20
 	}
-	if filters.SortOrder != "" {
-		query = query.Order(filters.SortOrder)
+	if limit > 100 {
+		limit = 100
 	}
 
-	if err := query.Find(&comments).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch comments: %w", err)
+	var cursor *keysetCursor
+	var cursorValue interface{}
+	offset := 0
+	if filters.Cursor != "" {
+		var err error
+		if cursor, err = decodeCursor(filters.Cursor, sortBy); err != nil {
+			return nil, nil, err
+		}
+		if cursorValue, err = commentCursorValue(sortBy, cursor.Value); err != nil {
+			return nil, nil, err
+		}
+		// The page number means nothing once paging by cursor.
+		page = 0
+	} else {
+		offset = (page - 1) * limit
+		query = query.Offset(offset)
 	}
 
-	return comments, nil
+	// id breaks ties so the keyset position is unique. One extra row tells
+	// us whether there is another page.
+	query = applyKeyset(query, column, "id", sortOrder, cursor, cursorValue)
+	if err := query.Limit(limit + 1).Find(&comments).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+
+	comments, extra := trimKeysetPage(comments, limit, cursor)
+	var first, last *keysetCursor
+	if len(comments) > 0 {
+		first = commentCursor(sortBy, comments[0])
+		last = commentCursor(sortBy, comments[len(comments)-1])
+	}
+	return comments, keysetMeta(total, page, limit, offset, cursor, extra, first, last), nil
+}
+
+// commentSortColumns maps the sortBy values clients may send to columns.
+var commentSortColumns = map[string]string{
+	"createdAt": "created_at",
+	"author":    "author",
+}
+
+// commentCursor is the keyset position of c.
+func commentCursor(sortBy string, c *models.Comment) *keysetCursor {
+	value := c.CreatedAt.UTC().Format(time.RFC3339Nano)
+	if sortBy == "author" {
+		value = c.Author
+	}
+	return &keysetCursor{Sort: sortBy, Value: value, ID: c.ID}
+}
+
+// commentCursorValue parses the sort column value back out of a cursor.
+func commentCursorValue(sortBy, value string) (interface{}, error) {
+	if sortBy == "author" {
+		return value, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, errors.New(errInvalidCursor)
+	}
+	return t, nil
 }
 
 func (r *PostgresCommentRepository) Update(id string, comment *models.Comment) error {
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresLinkCheckRepository implements LinkCheckRepository using PostgreSQL
+type PostgresLinkCheckRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresLinkCheckRepository creates a new PostgreSQL link check repository
+func NewPostgresLinkCheckRepository(db *gorm.DB) repositories.LinkCheckRepository {
+	return &PostgresLinkCheckRepository{db: db}
+}
+
+// FindPostLinks walks posts in batches, like FindReferencedURLs, so the
+// content of every post is never held at once.
+func (r *PostgresLinkCheckRepository) FindPostLinks() ([]models.PostLinks, error) {
+	var out []models.PostLinks
+	var batch []*models.Post
+	err := r.db.Model(&models.Post{}).
+		Select("id", "content").
+		FindInBatches(&batch, 100, func(tx *gorm.DB, _ int) error {
+			for _, p := range batch {
+				if links := p.Content.OutboundLinks(); len(links) > 0 {
+					out = append(out, models.PostLinks{PostID: p.ID, Links: links})
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect post links: %w", err)
+	}
+	return out, nil
+}
+
+func (r *PostgresLinkCheckRepository) ReplaceAll(checks []models.LinkCheck) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.LinkCheck{}).Error; err != nil {
+			return fmt.Errorf("failed to clear link checks: %w", err)
+		}
+		if len(checks) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(checks, 500).Error; err != nil {
+			return fmt.Errorf("failed to save link checks: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *PostgresLinkCheckRepository) FindBroken() ([]models.PostBrokenLinks, error) {
+	var rows []struct {
+		models.LinkCheck
+		Title string
+	}
+	err := r.db.Table("link_checks").
+		Select("link_checks.*, posts.title").
+		Joins("JOIN posts ON posts.id = link_checks.post_id").
+		Where("link_checks.broken = ?", true).
+		Order("posts.date DESC, posts.id, link_checks.url").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch broken links: %w", err)
+	}
+
+	var out []models.PostBrokenLinks
+	for _, row := range rows {
+		if n := len(out); n == 0 || out[n-1].PostID != row.PostID {
+			out = append(out, models.PostBrokenLinks{PostID: row.PostID, Title: row.Title})
+		}
+		out[len(out)-1].Links = append(out[len(out)-1].Links, row.LinkCheck)
+	}
+	return out, nil
+}
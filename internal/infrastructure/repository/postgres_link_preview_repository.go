@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresLinkPreviewRepository implements LinkPreviewRepository using PostgreSQL
+type PostgresLinkPreviewRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresLinkPreviewRepository creates a new PostgreSQL link preview repository
+func NewPostgresLinkPreviewRepository(db *gorm.DB) repositories.LinkPreviewRepository {
+	return &PostgresLinkPreviewRepository{db: db}
+}
+
+func (r *PostgresLinkPreviewRepository) FindFresh(url string, now time.Time) (*models.LinkPreview, error) {
+	var preview models.LinkPreview
+	err := r.db.Where("url = ? AND expires_at > ?", url, now).First(&preview).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch link preview: %w", err)
+	}
+	return &preview, nil
+}
+
+func (r *PostgresLinkPreviewRepository) Save(preview *models.LinkPreview) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "url"}},
+		DoUpdates: clause.AssignmentColumns([]string{"metadata", "fetched_at", "expires_at"}),
+	}).Create(preview).Error
+	if err != nil {
+		return fmt.Errorf("failed to save link preview: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresLinkPreviewRepository) DeleteExpired(before time.Time) (int64, error) {
+	res := r.db.Where("expires_at <= ?", before).Delete(&models.LinkPreview{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired link previews: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
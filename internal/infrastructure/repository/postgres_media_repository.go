@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresMediaRepository implements MediaRepository using PostgreSQL
+type PostgresMediaRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresMediaRepository creates a new PostgreSQL media repository
+func NewPostgresMediaRepository(db *gorm.DB) repositories.MediaRepository {
+	return &PostgresMediaRepository{db: db}
+}
+
+func (r *PostgresMediaRepository) Create(media *models.Media) error {
+	if err := r.db.Create(media).Error; err != nil {
+		return fmt.Errorf("failed to create media: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresMediaRepository) FindByID(id string) (*models.Media, error) {
+	var media models.Media
+	err := r.db.Where("id = ?", id).First(&media).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch media: %w", err)
+	}
+	return &media, nil
+}
+
+func (r *PostgresMediaRepository) FindByKey(key string) (*models.Media, error) {
+	var media models.Media
+	err := r.db.Where("key = ?", key).First(&media).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch media by key: %w", err)
+	}
+	return &media, nil
+}
+
+func (r *PostgresMediaRepository) IncrementUploadCount(id string) error {
+	res := r.db.Model(&models.Media{}).
+		Where("id = ?", id).
+		UpdateColumn("upload_count", gorm.Expr("upload_count + 1"))
+	if res.Error != nil {
+		return fmt.Errorf("failed to increment media upload count: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresMediaRepository) Stats() (*models.MediaStats, error) {
+	var stats models.MediaStats
+	err := r.db.Model(&models.Media{}).
+		Select(`COUNT(*) AS objects,
+			COALESCE(SUM(size), 0) AS total_bytes,
+			COALESCE(SUM(upload_count), 0) AS uploads,
+			COALESCE(SUM(upload_count - 1), 0) AS dedup_hits,
+			COALESCE(SUM((upload_count - 1) * size), 0) AS bytes_saved`).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute media stats: %w", err)
+	}
+	return &stats, nil
+}
+
+func (r *PostgresMediaRepository) FindAll(filters models.MediaFilters) ([]*models.Media, *models.PaginationMeta, error) {
+	var rows []*models.Media
+	var total int64
+
+	query := r.db.Model(&models.Media{})
+
+	if kind := strings.ToLower(strings.TrimSpace(filters.Kind)); kind != "" {
+		query = query.Where("mime_type LIKE ?", kind+"/%")
+	}
+	if filters.MimeType != "" {
+		query = query.Where("LOWER(mime_type) = ?", strings.ToLower(filters.MimeType))
+	}
+	if filters.Uploader != "" {
+		query = query.Where("uploader = ?", filters.Uploader)
+	}
+	if search := strings.TrimSpace(filters.Search); search != "" {
+		query = query.Where("LOWER(key) LIKE ?", "%"+strings.ToLower(search)+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count media: %w", err)
+	}
+
+	sortOrder := "desc"
+	if strings.ToLower(filters.SortOrder) == "asc" {
+		sortOrder = "asc"
+	}
+	// id breaks ties between rows created in the same instant so pages don't
+	// overlap.
+	query = query.Order(fmt.Sprintf("created_at %s, id %s", sortOrder, sortOrder))
+
+	page := filters.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := filters.Limit
+	if limit < 1 {
+		limit = 24
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	if err := query.Offset((page - 1) * limit).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch media: %w", err)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	meta := &models.PaginationMeta{
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+		HasMore:    page < totalPages,
+	}
+	return rows, meta, nil
+}
+
+func (r *PostgresMediaRepository) Delete(id string) error {
+	res := r.db.Delete(&models.Media{}, "id = ?", id)
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete media: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresMediaRepository) DeleteByKey(key string) error {
+	if err := r.db.Where("key = ?", key).Delete(&models.Media{}).Error; err != nil {
+		return fmt.Errorf("failed to delete media by key: %w", err)
+	}
+	return nil
+}
+
+// FindReferences looks the URL up in the places the frontend can put an
+// uploaded file: a post's cover image, an Editor.js image or video block (or
+// a Link Tool block's re-hosted preview image) inside a post's content, a
+// character portrait and a Whitenest arc cover.
+//
+// Image blocks are matched with JSONB containment so the GIN-friendly `@>`
+// operator does the work instead of a text search over the whole document.
+// Two shapes are checked because the official Image Tool nests the URL under
+// data.file.url while the Simple Image tool stores it as data.url.
+func (r *PostgresMediaRepository) FindReferences(url string) (*models.MediaReferences, error) {
+	refs := &models.MediaReferences{
+		PostImageIDs:   []string{},
+		PostContentIDs: []string{},
+		CharacterIDs:   []string{},
+		ArcIDs:         []string{},
+	}
+
+	if err := r.db.Model(&models.Post{}).
+		Where("image = ?", url).
+		Order("date DESC").
+		Pluck("id", &refs.PostImageIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to check post image references: %w", err)
+	}
+
+	// Video blocks use the same two shapes as image blocks.
+	var blocks []interface{}
+	for _, blockType := range []string{"image", "video"} {
+		fileBlock, err := blockContainment(blockType, map[string]interface{}{
+			"file": map[string]string{"url": url},
+		})
+		if err != nil {
+			return nil, err
+		}
+		urlBlock, err := blockContainment(blockType, map[string]interface{}{"url": url})
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, fileBlock, urlBlock)
+	}
+	// Re-hosted link preview images live in data.meta.image.url.
+	linkToolBlock, err := blockContainment("linkTool", map[string]interface{}{
+		"meta": map[string]interface{}{"image": map[string]string{"url": url}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	blocks = append(blocks, linkToolBlock)
+	if err := r.db.Model(&models.Post{}).
+		Where(strings.TrimSuffix(strings.Repeat("content @> ?::jsonb OR ", len(blocks)), " OR "), blocks...).
+		Order("date DESC").
+		Pluck("id", &refs.PostContentIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to check post content references: %w", err)
+	}
+
+	if err := r.db.Model(&models.Character{}).
+		Where("portrait = ?", url).
+		Order("short_name ASC").
+		Pluck("id", &refs.CharacterIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to check character portrait references: %w", err)
+	}
+
+	if err := r.db.Model(&models.WhitenestArc{}).
+		Where("image = ?", url).
+		Order("start_chapter ASC").
+		Pluck("id", &refs.ArcIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to check arc image references: %w", err)
+	}
+
+	return refs, nil
+}
+
+// blockContainment builds the JSONB document used with `content @> ?` to
+// match an Editor.js block of the given type whose data contains the given
+// fields.
+func blockContainment(blockType string, data map[string]interface{}) (string, error) {
+	doc := map[string]interface{}{
+		"blocks": []map[string]interface{}{
+			{"type": blockType, "data": data},
+		},
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to build block filter: %w", err)
+	}
+	return string(raw), nil
+}
+
+// FindReferencedURLs walks every post in batches (content can be large, so we
+// don't load the whole table at once), every character portrait and every
+// arc cover.
+func (r *PostgresMediaRepository) FindReferencedURLs() ([]string, error) {
+	var urls []string
+
+	var batch []*models.Post
+	err := r.db.Model(&models.Post{}).
+		Select("id", "image", "content").
+		FindInBatches(&batch, 100, func(tx *gorm.DB, _ int) error {
+			for _, p := range batch {
+				if p.Image != "" {
+					urls = append(urls, p.Image)
+				}
+				urls = append(urls, p.Content.MediaURLs()...)
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect post media references: %w", err)
+	}
+
+	var portraits []string
+	if err := r.db.Model(&models.Character{}).
+		Where("portrait <> ''").
+		Pluck("portrait", &portraits).Error; err != nil {
+		return nil, fmt.Errorf("failed to collect character portraits: %w", err)
+	}
+
+	var arcImages []string
+	if err := r.db.Model(&models.WhitenestArc{}).
+		Where("image IS NOT NULL AND image <> ''").
+		Pluck("image", &arcImages).Error; err != nil {
+		return nil, fmt.Errorf("failed to collect arc images: %w", err)
+	}
+
+	urls = append(urls, portraits...)
+	return append(urls, arcImages...), nil
+}
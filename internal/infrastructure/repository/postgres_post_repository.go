@@ -1,13 +1,25 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
-	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/davidrdsilva/blog-api/internal/domain/models"
 	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Matched as substrings by the post and whitenest handlers.
+const (
+	errChapterVersionMismatch = "chapter version mismatch"
+	errInvalidChapterPosition = "invalid chapter position"
+	// Shares the WHITENEST_INVARIANT_VIOLATION prefix.
+	errChapterNotQueued = "whitenest invariant: chapter already released, release_at only applies to queued chapters"
 )
 
 // PostgresPostRepository implements PostRepository using PostgreSQL
@@ -64,9 +76,93 @@ func (r *PostgresPostRepository) loadCast(post *models.Post) error {
 // FindAll retrieves posts with filtering, pagination, and sorting
 func (r *PostgresPostRepository) FindAll(filters models.PostFilters) ([]*models.Post, *models.PaginationMeta, error) {
 	var posts []*models.Post
-	var total int64
+	total := int64(-1)
+
+	query := r.filteredPosts(filters, "")
+
+	// Count total records
+	if !filters.SkipCount {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, nil, fmt.Errorf("failed to count posts: %w", err)
+		}
+	}
+
+	// Apply sorting
+	sortBy := filters.SortBy
+	if sortBy == "" {
+		sortBy = "date"
+	}
+	sortOrder := filters.SortOrder
+	if sortOrder == "" {
+		sortOrder = "desc"
+	}
+
+	// Validate and sanitize sort fields to prevent SQL injection
+	column, ok := postSortColumns[sortBy]
+	if !ok {
+		sortBy = "date"
+		column = postSortColumns[sortBy]
+	}
+
+	if strings.ToLower(sortOrder) != "asc" {
+		sortOrder = "desc"
+	}
+
+	// Apply pagination
+	page := filters.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := filters.Limit
+	if limit < 1 {
+		limit = 6
+	}
+	if limit > 50 {
+		limit = 50
+	}
 
-	// Build query
+	// A cursor replaces the offset: the page starts next to the cursor's row,
+	// so posts published while a reader scrolls don't shift what comes next.
+	var cursor *keysetCursor
+	var cursorValue interface{}
+	offset := 0
+	if filters.Cursor != "" {
+		var err error
+		if cursor, err = decodeCursor(filters.Cursor, sortBy); err != nil {
+			return nil, nil, err
+		}
+		if cursorValue, err = postCursorValue(sortBy, cursor.Value); err != nil {
+			return nil, nil, err
+		}
+		page = 0
+	} else {
+		offset = (page - 1) * limit
+		query = query.Offset(offset)
+	}
+
+	// Columns are qualified because the category join brings its own id.
+	query = applyKeyset(query, column, "posts.id", sortOrder, cursor, cursorValue)
+
+	// Execute query (preload category + tags so the list view can show them).
+	// The extra row only tells us whether another page exists.
+	if err := query.Limit(limit + 1).Preload("Category").Preload("Tags").Find(&posts).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch posts: %w", err)
+	}
+
+	posts, extra := trimKeysetPage(posts, limit, cursor)
+	var first, last *keysetCursor
+	if len(posts) > 0 {
+		first = postCursor(sortBy, posts[0])
+		last = postCursor(sortBy, posts[len(posts)-1])
+	}
+	return posts, keysetMeta(total, page, limit, offset, cursor, extra, first, last), nil
+}
+
+// filteredPosts builds the filtered (but unsorted and unpaginated) post query
+// shared by FindAll and Facets. except names a facet whose own filter is left
+// out, so its buckets count the alternatives to the current selection; pass
+// "" to apply every filter.
+func (r *PostgresPostRepository) filteredPosts(filters models.PostFilters, except string) *gorm.DB {
 	query := r.db.Model(&models.Post{})
 
 	// Apply search filter using full-text search
@@ -79,13 +175,23 @@ func (r *PostgresPostRepository) FindAll(filters models.PostFilters) ([]*models.
 	}
 
 	// Apply author filter
-	if filters.Author != "" {
-		query = query.Where("author = ?", filters.Author)
+	if filters.Author != "" && except != models.FacetAuthor {
+		query = query.Where("posts.author = ?", filters.Author)
+	}
+
+	// Apply category filter (OR semantics across the selected categories)
+	if len(filters.CategoryIDs) > 0 && except != models.FacetCategory {
+		query = query.Where("posts.category_id IN ?", filters.CategoryIDs)
 	}
 
-	// Apply category filter
-	if filters.CategoryID != nil {
-		query = query.Where("category_id = ?", *filters.CategoryID)
+	// Apply date range; DateTo is exclusive.
+	if except != models.FacetYear {
+		if filters.DateFrom != nil {
+			query = query.Where("posts.date >= ?", *filters.DateFrom)
+		}
+		if filters.DateTo != nil {
+			query = query.Where("posts.date < ?", *filters.DateTo)
+		}
 	}
 
 	if filters.IsWhitenestChapter != nil {
@@ -96,6 +202,9 @@ func (r *PostgresPostRepository) FindAll(filters models.PostFilters) ([]*models.
 		}
 	}
 
+	// Queued Whitenest chapters stay out of every listing until released.
+	query = query.Where("posts.release_at IS NULL")
+
 	// Internal-category visibility. By default, posts whose category has
 	// is_internal=true (Drafts) are hidden from listings. The drafts endpoint
 	// inverts this with OnlyInternalCategories=true.
@@ -107,89 +216,127 @@ func (r *PostgresPostRepository) FindAll(filters models.PostFilters) ([]*models.
 			Where("categories.is_internal = ?", false)
 	}
 
-	// Apply tag-name filter (OR semantics: posts that have ANY of the named tags).
-	// We use a subquery (rather than JOIN) so the row count from the main query
-	// stays correct even when a post matches multiple tags.
-	if names := normalizeTagFilterNames(filters.TagNames); len(names) > 0 {
-		query = query.Where(
-			"posts.id IN (?)",
-			r.db.Table("posts_tags AS pt").
-				Select("pt.post_id").
-				Joins("JOIN tags AS t ON t.id = pt.tag_id").
-				Where("LOWER(t.name) IN ?", names),
-		)
-	}
-
-	// Count total records
-	if err := query.Count(&total).Error; err != nil {
-		return nil, nil, fmt.Errorf("failed to count posts: %w", err)
-	}
-
-	// Apply sorting
-	sortBy := filters.SortBy
-	if sortBy == "" {
-		sortBy = "date"
-	}
-	sortOrder := filters.SortOrder
-	if sortOrder == "" {
-		sortOrder = "desc"
-	}
-
-	// Validate and sanitize sort fields to prevent SQL injection
-	allowedSortFields := map[string]bool{
-		"date":                     true,
-		"title":                    true,
-		"createdAt":                true,
-		"updatedAt":                true,
-		"whitenest_chapter_number": true,
-	}
-	if !allowedSortFields[sortBy] {
-		sortBy = "date"
+	// Apply tag-name filter: posts that have ANY of the named tags, or ALL of
+	// them with TagMatchAll. We use a subquery (rather than JOIN) so the row
+	// count from the main query stays correct even when a post matches
+	// multiple tags. The tag facet only drops an ANY filter: with ALL, its
+	// counts show how far adding one more tag narrows the results.
+	names := normalizeTagFilterNames(filters.TagNames)
+	if len(names) > 0 && (except != models.FacetTag || filters.TagMatchAll) {
+		tagged := r.db.Table("posts_tags AS pt").
+			Select("pt.post_id").
+			Joins("JOIN tags AS t ON t.id = pt.tag_id").
+			Where("LOWER(t.name) IN ?", names)
+		if filters.TagMatchAll {
+			tagged = tagged.Group("pt.post_id").Having("COUNT(DISTINCT t.id) = ?", len(names))
+		}
+		query = query.Where("posts.id IN (?)", tagged)
 	}
 
-	// Convert camelCase to snake_case for database column names
-	dbSortBy := camelToSnake(sortBy)
-
-	if strings.ToLower(sortOrder) != "asc" {
-		sortOrder = "desc"
-	}
+	return query
+}
 
-	query = query.Order(fmt.Sprintf("%s %s", dbSortBy, sortOrder))
+// facetBucketLimit caps the open-ended facets (tags and authors); category
+// and year have few enough values to list in full.
+const facetBucketLimit = 50
+
+// Facets counts the posts matching filters per value of each named facet.
+// Each facet leaves out its own filter (see filteredPosts) so a multi-select
+// sidebar can show how many posts every alternative would add.
+func (r *PostgresPostRepository) Facets(filters models.PostFilters, names []string) (models.PostFacets, error) {
+	facets := models.PostFacets{}
+	for _, name := range names {
+		query := r.filteredPosts(filters, name)
+		switch name {
+		case models.FacetCategory:
+			// Aliased so it can't clash with the visibility join.
+			query = query.Joins("JOIN categories AS fc ON fc.id = posts.category_id").
+				Select("fc.id::text AS value, fc.name AS label, COUNT(*) AS count").
+				Group("fc.id, fc.name").
+				Order("count DESC, fc.name ASC")
+		case models.FacetTag:
+			query = query.Joins("JOIN posts_tags AS fpt ON fpt.post_id = posts.id").
+				Joins("JOIN tags AS ft ON ft.id = fpt.tag_id").
+				Select("ft.name AS value, COUNT(*) AS count").
+				Group("ft.name").
+				Order("count DESC, ft.name ASC").
+				Limit(facetBucketLimit)
+		case models.FacetAuthor:
+			query = query.Select("posts.author AS value, COUNT(*) AS count").
+				Group("posts.author").
+				Order("count DESC, posts.author ASC").
+				Limit(facetBucketLimit)
+		case models.FacetYear:
+			// Years follow the session time zone, like the dates in responses.
+			query = query.Select("EXTRACT(YEAR FROM posts.date)::int::text AS value, COUNT(*) AS count").
+				Group("1").
+				Order("value DESC")
+		default:
+			return nil, fmt.Errorf("unknown facet %q", name)
+		}
 
-	// Apply pagination
-	page := filters.Page
-	if page < 1 {
-		page = 1
-	}
-	limit := filters.Limit
-	if limit < 1 {
-		limit = 6
-	}
-	if limit > 50 {
-		limit = 50
+		var buckets []models.FacetBucket
+		if err := query.Scan(&buckets).Error; err != nil {
+			return nil, fmt.Errorf("failed to compute %s facet: %w", name, err)
+		}
+		if buckets == nil {
+			buckets = []models.FacetBucket{}
+		}
+		facets[name] = buckets
 	}
+	return facets, nil
+}
 
-	offset := (page - 1) * limit
-	query = query.Offset(offset).Limit(limit)
+// postSortColumns maps the sortBy values clients may send to the expression
+// posts are ordered by. Chapter numbers are coalesced because NULL never
+// compares in a keyset condition; non-chapters sort as chapter 0.
+var postSortColumns = map[string]string{
+	"date":                     "posts.date",
+	"title":                    "posts.title",
+	"createdAt":                "posts.created_at",
+	"updatedAt":                "posts.updated_at",
+	"whitenest_chapter_number": "COALESCE(posts.whitenest_chapter_number, 0)",
+}
 
-	// Execute query (preload category + tags so the list view can show them).
-	if err := query.Preload("Category").Preload("Tags").Find(&posts).Error; err != nil {
-		return nil, nil, fmt.Errorf("failed to fetch posts: %w", err)
+// postCursor is the keyset position of p.
+func postCursor(sortBy string, p *models.Post) *keysetCursor {
+	var value string
+	switch sortBy {
+	case "title":
+		value = p.Title
+	case "createdAt":
+		value = p.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case "updatedAt":
+		value = p.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case "whitenest_chapter_number":
+		value = "0"
+		if p.WhitenestChapterNumber != nil {
+			value = strconv.Itoa(*p.WhitenestChapterNumber)
+		}
+	default:
+		value = p.Date.UTC().Format(time.RFC3339Nano)
 	}
+	return &keysetCursor{Sort: sortBy, Value: value, ID: p.ID}
+}
 
-	// Calculate pagination metadata
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-	hasMore := page < totalPages
-
-	meta := &models.PaginationMeta{
-		Total:      total,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: totalPages,
-		HasMore:    hasMore,
+// postCursorValue parses the sort value back out of a cursor.
+func postCursorValue(sortBy, value string) (interface{}, error) {
+	switch sortBy {
+	case "title":
+		return value, nil
+	case "whitenest_chapter_number":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New(errInvalidCursor)
+		}
+		return n, nil
+	default:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.New(errInvalidCursor)
+		}
+		return t, nil
 	}
-
-	return posts, meta, nil
 }
 
 // Update modifies an existing post
@@ -223,6 +370,38 @@ func (r *PostgresPostRepository) Exists(id string) (bool, error) {
 	return count > 0, err
 }
 
+func (r *PostgresPostRepository) ReplaceLinkImageURLs(id string, urls map[string]string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var post models.Post
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "content").
+			Where("id = ?", id).
+			First(&post).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if post.Content == nil {
+			return nil
+		}
+
+		changed := false
+		for i := range post.Content.Blocks {
+			block := &post.Content.Blocks[i]
+			if stored, ok := urls[block.LinkImageURL()]; ok {
+				block.SetLinkImageURL(stored)
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return tx.Model(&models.Post{}).Where("id = ?", id).UpdateColumn("content", post.Content).Error
+	})
+}
+
 // IncrementViews atomically bumps total_views by 1 for the given post.
 // Returns nil silently for unknown IDs; callers (the worker) shouldn't fail
 // just because a post was deleted between the read request and the job run.
@@ -281,7 +460,7 @@ func (r *PostgresPostRepository) FindSimilar(postID string, limit int) ([]*model
 		Select("p.id AS id, COUNT(pt.tag_id) AS shared_tags").
 		Joins("JOIN posts_tags pt ON pt.post_id = p.id").
 		Joins("JOIN categories c ON c.id = p.category_id").
-		Where("pt.tag_id IN (?) AND p.id != ? AND c.is_internal = ?", sourceTagIDs, postID, false).
+		Where("pt.tag_id IN (?) AND p.id != ? AND c.is_internal = ? AND p.release_at IS NULL", sourceTagIDs, postID, false).
 		Group("p.id, p.date").
 		Order("shared_tags DESC, p.date DESC").
 		Limit(limit).
@@ -342,12 +521,20 @@ func (r *PostgresPostRepository) FindWhitenestChapterByNumber(number int) (*mode
 
 // Tags/Category are intentionally omitted — the prev/next link cards only
 // need id, title, and chapter number.
-func (r *PostgresPostRepository) FindAdjacentWhitenestChapters(number int) (*models.Post, *models.Post, error) {
+func (r *PostgresPostRepository) FindAdjacentWhitenestChapters(number int, includeQueued bool) (*models.Post, *models.Post, error) {
 	var previous, next *models.Post
 
+	chapters := func() *gorm.DB {
+		query := r.db.Where("whitenest_chapter_number IS NOT NULL")
+		if !includeQueued {
+			query = query.Where("release_at IS NULL")
+		}
+		return query
+	}
+
 	var prev models.Post
-	err := r.db.
-		Where("whitenest_chapter_number IS NOT NULL AND whitenest_chapter_number < ?", number).
+	err := chapters().
+		Where("whitenest_chapter_number < ?", number).
 		Order("whitenest_chapter_number DESC").
 		First(&prev).Error
 	if err == nil {
@@ -357,8 +544,8 @@ func (r *PostgresPostRepository) FindAdjacentWhitenestChapters(number int) (*mod
 	}
 
 	var nxt models.Post
-	err = r.db.
-		Where("whitenest_chapter_number IS NOT NULL AND whitenest_chapter_number > ?", number).
+	err = chapters().
+		Where("whitenest_chapter_number > ?", number).
 		Order("whitenest_chapter_number ASC").
 		First(&nxt).Error
 	if err == nil {
@@ -383,6 +570,32 @@ func (r *PostgresPostRepository) ListWhitenestChapters() ([]*models.Post, error)
 	return posts, nil
 }
 
+// ReleaseDueWhitenestChapters clears release_at on the due chapters numbered
+// before the first chapter that isn't due yet, in one statement.
+func (r *PostgresPostRepository) ReleaseDueWhitenestChapters(now time.Time) ([]*models.Post, error) {
+	held := r.db.Model(&models.Post{}).
+		Select("MIN(whitenest_chapter_number)").
+		Where("release_at > ?", now)
+
+	var released []*models.Post
+	err := r.db.Model(&released).
+		Clauses(clause.Returning{}).
+		Where("whitenest_chapter_number IS NOT NULL AND release_at <= ?", now).
+		Where("whitenest_chapter_number < COALESCE((?), 2147483647)", held).
+		Updates(map[string]interface{}{
+			"release_at": nil,
+			"date":       now,
+			"updated_at": now,
+		}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to release due chapters: %w", err)
+	}
+	sort.Slice(released, func(i, j int) bool {
+		return *released[i].WhitenestChapterNumber < *released[j].WhitenestChapterNumber
+	})
+	return released, nil
+}
+
 func (r *PostgresPostRepository) MaxWhitenestChapterNumber() (int, error) {
 	var max *int
 	err := r.db.Model(&models.Post{}).
@@ -399,14 +612,18 @@ func (r *PostgresPostRepository) MaxWhitenestChapterNumber() (int, error) {
 
 // DemoteWhitenestChapter applies the regular post update, clears the chapter
 // number to NULL, and closes the gap by shifting all later chapters down by
-// one — all in a single transaction. The deferrable unique constraint on
+// one — all in a single transaction. Arcs starting after the demoted chapter
+// shift with them so every remaining chapter stays in its arc; an arc that
+// started at the demoted chapter keeps its start and now begins with the
+// chapter that slid into that number (or is left empty). The deferrable unique constraint on
 // whitenest_chapter_number is what makes the gap-close shift safe: the
 // statement-level UPDATE may transiently create rows with the same number
 // before each row is decremented; the constraint check fires once at COMMIT,
 // so intermediate states don't trip it.
 //
-// GORM's Updates(struct) skips nil pointer fields, so a separate UpdateColumn
-// call is needed to actually write NULL into whitenest_chapter_number. The
+// GORM's Updates(struct) skips nil pointer fields, so a separate UpdateColumns
+// call is needed to actually write NULL into whitenest_chapter_number (and
+// release_at, as a queued chapter leaving Whitenest is no longer queued). The
 // caller has set post.WhitenestChapterNumber to nil before this runs, but that
 // nil never reaches the DB without the explicit clear.
 func (r *PostgresPostRepository) DemoteWhitenestChapter(id string, post *models.Post, oldNumber int) error {
@@ -419,7 +636,10 @@ func (r *PostgresPostRepository) DemoteWhitenestChapter(id string, post *models.
 			return gorm.ErrRecordNotFound
 		}
 		if err := tx.Model(&models.Post{}).Where("id = ?", id).
-			UpdateColumn("whitenest_chapter_number", nil).Error; err != nil {
+			UpdateColumns(map[string]interface{}{
+				"whitenest_chapter_number": nil,
+				"release_at":               nil,
+			}).Error; err != nil {
 			return fmt.Errorf("failed to clear whitenest_chapter_number: %w", err)
 		}
 		if err := tx.Exec(
@@ -428,6 +648,12 @@ func (r *PostgresPostRepository) DemoteWhitenestChapter(id string, post *models.
 		).Error; err != nil {
 			return fmt.Errorf("failed to close chapter-number gap: %w", err)
 		}
+		if err := tx.Exec(
+			`UPDATE whitenest_arcs SET start_chapter = start_chapter - 1 WHERE start_chapter > ?`,
+			oldNumber,
+		).Error; err != nil {
+			return fmt.Errorf("failed to shift arc boundaries: %w", err)
+		}
 		return nil
 	})
 }
@@ -438,8 +664,23 @@ func (r *PostgresPostRepository) DemoteWhitenestChapter(id string, post *models.
 // are small. Mid-transaction collisions are absorbed by the deferrable unique
 // constraint, so the order in which rows are written doesn't matter — the
 // constraint is only checked at COMMIT.
-func (r *PostgresPostRepository) ReorderWhitenestChapters(order []models.ChapterOrderItem) error {
+//
+// Arc boundaries are positional, so by default each arc keeps covering the
+// same chapter numbers and chapters moved across a boundary change arcs.
+// arcs moves boundaries in the same transaction.
+func (r *PostgresPostRepository) ReorderWhitenestChapters(order []models.ChapterOrderItem, arcs []models.ArcBoundary) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, b := range arcs {
+			result := tx.Model(&models.WhitenestArc{}).
+				Where("id = ?", b.ArcID).
+				UpdateColumn("start_chapter", b.StartChapter)
+			if result.Error != nil {
+				return fmt.Errorf("failed to move arc %s: %w", b.ArcID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("unknown arc %s", b.ArcID)
+			}
+		}
 		for _, item := range order {
 			result := tx.Model(&models.Post{}).
 				Where("id = ? AND whitenest_chapter_number IS NOT NULL", item.PostID).
@@ -455,6 +696,184 @@ func (r *PostgresPostRepository) ReorderWhitenestChapters(order []models.Chapter
 	})
 }
 
+// lockChapterSequence locks every chapter row for the rest of tx and checks
+// the sequence against the version the client saw. Returns the current
+// highest chapter number.
+func lockChapterSequence(tx *gorm.DB, version string) (int, error) {
+	var items []models.ChapterOrderItem
+	err := tx.Model(&models.Post{}).
+		Select("id AS post_id, whitenest_chapter_number AS number").
+		Where("whitenest_chapter_number IS NOT NULL").
+		Order("whitenest_chapter_number ASC").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scan(&items).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock chapter sequence: %w", err)
+	}
+	if current := models.ChapterSequenceVersion(items); current != version {
+		return 0, fmt.Errorf("%s: expected %s, current %s", errChapterVersionMismatch, version, current)
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+	return items[len(items)-1].Number, nil
+}
+
+// openChapterSlot shifts chapters numbered at or after `at` up by one, and the
+// arcs starting after it with them, so a chapter can take number `at`. An arc
+// starting exactly at `at` keeps its start: the new chapter becomes its first.
+func openChapterSlot(tx *gorm.DB, at int) error {
+	if err := tx.Exec(
+		`UPDATE posts SET whitenest_chapter_number = whitenest_chapter_number + 1 WHERE whitenest_chapter_number >= ?`,
+		at,
+	).Error; err != nil {
+		return fmt.Errorf("failed to shift chapters: %w", err)
+	}
+	if err := tx.Exec(
+		`UPDATE whitenest_arcs SET start_chapter = start_chapter + 1 WHERE start_chapter > ?`,
+		at,
+	).Error; err != nil {
+		return fmt.Errorf("failed to shift arc boundaries: %w", err)
+	}
+	return nil
+}
+
+// InsertWhitenestChapter creates post as a chapter at the number it carries,
+// shifting later chapters up in the same transaction. The deferrable unique
+// constraint absorbs the transient duplicates of the shift.
+func (r *PostgresPostRepository) InsertWhitenestChapter(post *models.Post, version string) error {
+	at := *post.WhitenestChapterNumber
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		max, err := lockChapterSequence(tx, version)
+		if err != nil {
+			return err
+		}
+		if at < 1 || at > max+1 {
+			return fmt.Errorf("%s: insert_at must be between 1 and %d, got %d", errInvalidChapterPosition, max+1, at)
+		}
+		if err := openChapterSlot(tx, at); err != nil {
+			return err
+		}
+		return tx.Create(post).Error
+	})
+}
+
+// PromoteWhitenestChapter applies the regular post update to a post joining
+// Whitenest at the number it carries, shifting later chapters up in the same
+// transaction.
+func (r *PostgresPostRepository) PromoteWhitenestChapter(id string, post *models.Post, version string) error {
+	at := *post.WhitenestChapterNumber
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		max, err := lockChapterSequence(tx, version)
+		if err != nil {
+			return err
+		}
+		if at < 1 || at > max+1 {
+			return fmt.Errorf("%s: insert_at must be between 1 and %d, got %d", errInvalidChapterPosition, max+1, at)
+		}
+		if err := openChapterSlot(tx, at); err != nil {
+			return err
+		}
+		result := tx.Model(&models.Post{}).Where("id = ?", id).Updates(post)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// MoveWhitenestChapter moves the chapter numbered from to number to, shifting
+// the chapters in between by one. It behaves like removing the chapter and
+// inserting it again: arc boundaries follow the other chapters, so only the
+// moved chapter can change arc.
+func (r *PostgresPostRepository) MoveWhitenestChapter(from, to int, version string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		max, err := lockChapterSequence(tx, version)
+		if err != nil {
+			return err
+		}
+		return moveChapter(tx, from, to, max)
+	})
+}
+
+// RescheduleWhitenestChapter locks the chapter set before writing, which
+// also serialises it with ReleaseDueWhitenestChapters.
+func (r *PostgresPostRepository) RescheduleWhitenestChapter(id string, post *models.Post, from, to int, version string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		max, err := lockChapterSequence(tx, version)
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&models.Post{}).
+			Where("id = ? AND release_at IS NOT NULL", id).
+			Updates(post)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%s", errChapterNotQueued)
+		}
+		return moveChapter(tx, from, to, max)
+	})
+}
+
+// moveChapter does the work of MoveWhitenestChapter inside tx, once the
+// chapter sequence is locked. max is the highest chapter number.
+func moveChapter(tx *gorm.DB, from, to, max int) error {
+	if from < 1 || from > max {
+		return gorm.ErrRecordNotFound
+	}
+	if to < 1 || to > max {
+		return fmt.Errorf("%s: to must be between 1 and %d, got %d", errInvalidChapterPosition, max, to)
+	}
+	if from == to {
+		return nil
+	}
+
+	var id string
+	if err := tx.Model(&models.Post{}).
+		Where("whitenest_chapter_number = ?", from).
+		Pluck("id", &id).Error; err != nil {
+		return fmt.Errorf("failed to find chapter %d: %w", from, err)
+	}
+	if id == "" {
+		// A gap left by a deleted chapter.
+		return gorm.ErrRecordNotFound
+	}
+
+	shift := `UPDATE posts SET whitenest_chapter_number = whitenest_chapter_number - 1
+		WHERE whitenest_chapter_number > ? AND whitenest_chapter_number <= ?`
+	lo, hi := from, to
+	if to < from {
+		shift = `UPDATE posts SET whitenest_chapter_number = whitenest_chapter_number + 1
+			WHERE whitenest_chapter_number >= ? AND whitenest_chapter_number < ?`
+		lo, hi = to, from
+	}
+	if err := tx.Exec(shift, lo, hi).Error; err != nil {
+		return fmt.Errorf("failed to shift chapters: %w", err)
+	}
+	if err := tx.Model(&models.Post{}).Where("id = ?", id).
+		UpdateColumn("whitenest_chapter_number", to).Error; err != nil {
+		return fmt.Errorf("failed to move chapter: %w", err)
+	}
+
+	// Remove at from, then insert at to.
+	if err := tx.Exec(
+		`UPDATE whitenest_arcs SET start_chapter = start_chapter - 1 WHERE start_chapter > ?`, from,
+	).Error; err != nil {
+		return fmt.Errorf("failed to shift arc boundaries: %w", err)
+	}
+	if err := tx.Exec(
+		`UPDATE whitenest_arcs SET start_chapter = start_chapter + 1 WHERE start_chapter > ?`, to,
+	).Error; err != nil {
+		return fmt.Errorf("failed to shift arc boundaries: %w", err)
+	}
+	return nil
+}
+
 // ReplaceTags resets the tag set associated with a post. Used by Update so the
 // caller can supply a full replacement list of tags.
 func (r *PostgresPostRepository) ReplaceTags(postID string, tags []*models.Tag) error {
@@ -475,6 +894,16 @@ func (r *PostgresPostRepository) ReplaceTags(postID string, tags []*models.Tag)
 // so we do it by hand inside a single transaction.
 func (r *PostgresPostRepository) ReplaceCharacters(postID string, characterIDs []string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Characters that stay in the cast keep their state override.
+		var existing []models.PostsCharacter
+		if err := tx.Where("post_id = ?", postID).Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to load existing cast: %w", err)
+		}
+		overrides := make(map[string]models.CharacterStateOverride, len(existing))
+		for _, row := range existing {
+			overrides[row.CharacterID] = row.CharacterStateOverride
+		}
+
 		if err := tx.Where("post_id = ?", postID).Delete(&models.PostsCharacter{}).Error; err != nil {
 			return fmt.Errorf("failed to clear existing cast: %w", err)
 		}
@@ -484,9 +913,10 @@ func (r *PostgresPostRepository) ReplaceCharacters(postID string, characterIDs [
 		rows := make([]models.PostsCharacter, len(characterIDs))
 		for i, charID := range characterIDs {
 			rows[i] = models.PostsCharacter{
-				PostID:      postID,
-				CharacterID: charID,
-				Position:    i,
+				PostID:                 postID,
+				CharacterID:            charID,
+				Position:               i,
+				CharacterStateOverride: overrides[charID],
 			}
 		}
 		if err := tx.Create(&rows).Error; err != nil {
@@ -496,18 +926,6 @@ func (r *PostgresPostRepository) ReplaceCharacters(postID string, characterIDs [
 	})
 }
 
-// camelToSnake converts camelCase to snake_case
-func camelToSnake(s string) string {
-	var result strings.Builder
-	for i, char := range s {
-		if i > 0 && char >= 'A' && char <= 'Z' {
-			result.WriteRune('_')
-		}
-		result.WriteRune(char)
-	}
-	return strings.ToLower(result.String())
-}
-
 // normalizeTagFilterNames lowercases and dedupes filter values, dropping empties.
 func normalizeTagFilterNames(names []string) []string {
 	seen := make(map[string]struct{})
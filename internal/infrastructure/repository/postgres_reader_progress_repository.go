@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresReaderProgressRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresReaderProgressRepository(db *gorm.DB) repositories.ReaderProgressRepository {
+	return &PostgresReaderProgressRepository{db: db}
+}
+
+var readerProgressKey = []clause.Column{{Name: "reader_id"}, {Name: "post_id"}}
+
+func (r *PostgresReaderProgressRepository) Touch(readerID, postID string) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   readerProgressKey,
+		DoUpdates: clause.Assignments(map[string]interface{}{"updated_at": gorm.Expr("CURRENT_TIMESTAMP")}),
+	}).Create(&models.ReaderProgress{ReaderID: readerID, PostID: postID}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record chapter open: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresReaderProgressRepository) Save(progress *models.ReaderProgress, columns []string) error {
+	updates := make(map[string]interface{}, len(columns)+1)
+	for _, col := range columns {
+		updates[col] = gorm.Expr("excluded." + col)
+	}
+	updates["updated_at"] = gorm.Expr("CURRENT_TIMESTAMP")
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   readerProgressKey,
+		DoUpdates: clause.Assignments(updates),
+	}).Create(progress).Error
+	if err != nil {
+		return fmt.Errorf("failed to save reader progress: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresReaderProgressRepository) FindByReader(readerID string) ([]models.ChapterProgress, error) {
+	var rows []models.ChapterProgress
+	err := r.db.Table("reader_progress").
+		Select("reader_progress.post_id, posts.whitenest_chapter_number AS chapter_number, reader_progress.block_id, reader_progress.read_at, reader_progress.updated_at").
+		Joins("JOIN posts ON posts.id = reader_progress.post_id").
+		Where("reader_progress.reader_id = ? AND posts.whitenest_chapter_number IS NOT NULL", readerID).
+		Order("posts.whitenest_chapter_number").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reader progress: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type PostgresWhitenestArcRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresWhitenestArcRepository(db *gorm.DB) repositories.WhitenestArcRepository {
+	return &PostgresWhitenestArcRepository{db: db}
+}
+
+func (r *PostgresWhitenestArcRepository) Create(arc *models.WhitenestArc) error {
+	if err := r.db.Create(arc).Error; err != nil {
+		return fmt.Errorf("failed to create arc: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWhitenestArcRepository) Update(id string, arc *models.WhitenestArc) error {
+	res := r.db.Model(&models.WhitenestArc{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"title":         arc.Title,
+		"synopsis":      arc.Synopsis,
+		"image":         arc.Image,
+		"start_chapter": arc.StartChapter,
+	})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update arc: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresWhitenestArcRepository) Delete(id string) error {
+	res := r.db.Delete(&models.WhitenestArc{}, "id = ?", id)
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete arc: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresWhitenestArcRepository) FindByID(id string) (*models.WhitenestArc, error) {
+	var arc models.WhitenestArc
+	err := r.db.Where("id = ?", id).First(&arc).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch arc: %w", err)
+	}
+	return &arc, nil
+}
+
+func (r *PostgresWhitenestArcRepository) FindAll() ([]*models.WhitenestArc, error) {
+	var arcs []*models.WhitenestArc
+	if err := r.db.Order("start_chapter ASC, created_at ASC, id ASC").Find(&arcs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list arcs: %w", err)
+	}
+	return arcs, nil
+}
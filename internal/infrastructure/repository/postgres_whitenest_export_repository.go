@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/domain/models"
+	"github.com/davidrdsilva/blog-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type PostgresWhitenestExportRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresWhitenestExportRepository(db *gorm.DB) repositories.WhitenestExportRepository {
+	return &PostgresWhitenestExportRepository{db: db}
+}
+
+func (r *PostgresWhitenestExportRepository) Create(export *models.WhitenestExport) error {
+	if err := r.db.Create(export).Error; err != nil {
+		return fmt.Errorf("failed to create export: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWhitenestExportRepository) Save(export *models.WhitenestExport) error {
+	if err := r.db.Save(export).Error; err != nil {
+		return fmt.Errorf("failed to update export: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWhitenestExportRepository) FindByID(id string) (*models.WhitenestExport, error) {
+	var export models.WhitenestExport
+	err := r.db.Where("id = ?", id).First(&export).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch export: %w", err)
+	}
+	return &export, nil
+}
+
+func (r *PostgresWhitenestExportRepository) FindLiveByFingerprint(fingerprint string) (*models.WhitenestExport, error) {
+	var export models.WhitenestExport
+	err := r.db.
+		Where("fingerprint = ? AND status IN ?", fingerprint,
+			[]string{models.ExportStatusPending, models.ExportStatusRunning, models.ExportStatusReady}).
+		Order("created_at DESC").
+		First(&export).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch export: %w", err)
+	}
+	return &export, nil
+}
+
+func (r *PostgresWhitenestExportRepository) FindReadyBySelection(arcID *string, from, to int) ([]*models.WhitenestExport, error) {
+	query := r.db.Where("status = ? AND from_chapter = ? AND to_chapter = ?", models.ExportStatusReady, from, to)
+	if arcID != nil {
+		query = query.Where("arc_id = ?", *arcID)
+	} else {
+		query = query.Where("arc_id IS NULL")
+	}
+	var exports []*models.WhitenestExport
+	if err := query.Order("created_at").Find(&exports).Error; err != nil {
+		return nil, fmt.Errorf("failed to list exports: %w", err)
+	}
+	return exports, nil
+}
+
+func (r *PostgresWhitenestExportRepository) Delete(id string) error {
+	res := r.db.Delete(&models.WhitenestExport{}, "id = ?", id)
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete export: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresWhitenestExportRepository) FailUnfinished(reason string) (int64, error) {
+	res := r.db.Model(&models.WhitenestExport{}).
+		Where("status IN ?", []string{models.ExportStatusPending, models.ExportStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":       models.ExportStatusFailed,
+			"error":        reason,
+			"completed_at": time.Now(),
+		})
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to fail unfinished exports: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+)
+
+// EXIF tags we read. Everything else in the block (GPS, maker notes, serial
+// numbers) is ignored here and dropped when the image is re-encoded.
+const (
+	exifTagOrientation        = 0x0112
+	exifTagDateTime           = 0x0132
+	exifTagExifIFDPointer     = 0x8769
+	exifTagDateTimeOriginal   = 0x9003
+	exifTagOffsetTimeOriginal = 0x9011
+)
+
+// exifDateLayout is the fixed "YYYY:MM:DD HH:MM:SS" format EXIF uses for dates.
+const exifDateLayout = "2006:01:02 15:04:05"
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// exifInfo is the subset of EXIF we act on: the orientation to bake into the
+// pixels and the capture date to keep as stored metadata.
+type exifInfo struct {
+	Orientation int
+	CapturedAt  *time.Time
+}
+
+// extractEXIF locates the raw TIFF-structured EXIF block inside a JPEG, PNG or
+// WebP payload. Returns nil when the container carries no EXIF.
+func extractEXIF(data []byte, contentType string) []byte {
+	switch strings.ToLower(contentType) {
+	case "image/jpeg":
+		return jpegEXIF(data)
+	case "image/png":
+		return pngEXIF(data)
+	case "image/webp":
+		return webpEXIF(data)
+	default:
+		return nil
+	}
+}
+
+// jpegEXIF walks the marker segments up to the start of scan and returns the
+// payload of the first APP1 segment tagged "Exif".
+func jpegEXIF(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil
+		}
+		marker := data[pos+1]
+		// Standalone markers carry no length field.
+		if marker == 0xD8 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			pos += 2
+			continue
+		}
+		// Start of scan: entropy-coded data follows, no more metadata segments.
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if length < 2 || pos+2+length > len(data) {
+			return nil
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, exifHeader) {
+			return segment[len(exifHeader):]
+		}
+		pos += 2 + length
+	}
+	return nil
+}
+
+// pngEXIF returns the body of the eXIf chunk, if any.
+func pngEXIF(data []byte) []byte {
+	const sigLen = 8
+	if len(data) < sigLen {
+		return nil
+	}
+	pos := sigLen
+	for pos+8 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		kind := string(data[pos+4 : pos+8])
+		end := pos + 8 + length
+		if end+4 > len(data) {
+			return nil
+		}
+		if kind == "eXIf" {
+			return data[pos+8 : end]
+		}
+		if kind == "IDAT" || kind == "IEND" {
+			// eXIf must precede IDAT per the PNG 1.5 extension spec.
+			return nil
+		}
+		pos = end + 4 // skip CRC
+	}
+	return nil
+}
+
+// webpEXIF returns the body of the EXIF chunk of an extended (VP8X) WebP.
+// Some encoders prefix the chunk with the JPEG-style "Exif\0\0" header, so we
+// strip it when present.
+func webpEXIF(data []byte) []byte {
+	for _, c := range webpChunks(data) {
+		if c.fourCC == "EXIF" {
+			return bytes.TrimPrefix(c.body, exifHeader)
+		}
+	}
+	return nil
+}
+
+// parseEXIF reads orientation and capture date from a TIFF-structured EXIF
+// block. Malformed or truncated blocks yield an error; callers treat that as
+// "no usable EXIF" rather than rejecting the upload.
+func parseEXIF(tiff []byte) (*exifInfo, error) {
+	if len(tiff) < 8 {
+		return nil, errors.New("exif block too short")
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("invalid exif byte order")
+	}
+	if order.Uint16(tiff[2:4]) != 42 {
+		return nil, errors.New("invalid exif magic")
+	}
+
+	info := &exifInfo{Orientation: 1}
+
+	ifd0, err := readIFD(tiff, order, order.Uint32(tiff[4:8]))
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := ifd0[exifTagOrientation]; ok {
+		if o := int(v.uint(order)); o >= 1 && o <= 8 {
+			info.Orientation = o
+		}
+	}
+
+	dateTime := ""
+	if v, ok := ifd0[exifTagDateTime]; ok {
+		dateTime = v.ascii()
+	}
+
+	offset := ""
+	if v, ok := ifd0[exifTagExifIFDPointer]; ok {
+		if sub, err := readIFD(tiff, order, v.uint(order)); err == nil {
+			if d, ok := sub[exifTagDateTimeOriginal]; ok && d.ascii() != "" {
+				dateTime = d.ascii()
+			}
+			if o, ok := sub[exifTagOffsetTimeOriginal]; ok {
+				offset = o.ascii()
+			}
+		}
+	}
+
+	if t, ok := parseEXIFDate(dateTime, offset); ok {
+		info.CapturedAt = &t
+	}
+
+	return info, nil
+}
+
+// exifEntry is a single IFD entry with its value bytes already resolved,
+// whether they were stored inline or at an offset.
+type exifEntry struct {
+	kind  uint16
+	value []byte
+}
+
+// uint reads a SHORT or LONG entry as an unsigned integer.
+func (e exifEntry) uint(order binary.ByteOrder) uint32 {
+	switch {
+	case e.kind == 3 && len(e.value) >= 2:
+		return uint32(order.Uint16(e.value))
+	case e.kind == 4 && len(e.value) >= 4:
+		return order.Uint32(e.value)
+	default:
+		return 0
+	}
+}
+
+// ascii reads an ASCII entry, trimming the NUL terminator and padding.
+func (e exifEntry) ascii() string {
+	if e.kind != 2 {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(e.value), "\x00"))
+}
+
+// exifTypeSizes maps TIFF field types to their per-component byte size.
+var exifTypeSizes = map[uint16]uint32{
+	1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8,
+}
+
+// readIFD decodes the entries of the IFD at the given offset into a tag map.
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32) (map[uint16]exifEntry, error) {
+	if uint64(offset)+2 > uint64(len(tiff)) {
+		return nil, errors.New("exif ifd offset out of range")
+	}
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	start := int(offset) + 2
+	if start+count*12 > len(tiff) {
+		return nil, errors.New("exif ifd truncated")
+	}
+
+	entries := make(map[uint16]exifEntry, count)
+	for i := 0; i < count; i++ {
+		raw := tiff[start+i*12 : start+(i+1)*12]
+		tag := order.Uint16(raw[0:2])
+		kind := order.Uint16(raw[2:4])
+		n := order.Uint32(raw[4:8])
+
+		size, known := exifTypeSizes[kind]
+		if !known {
+			continue
+		}
+		total := uint64(size) * uint64(n)
+		var value []byte
+		if total <= 4 {
+			value = raw[8 : 8+total]
+		} else {
+			at := uint64(order.Uint32(raw[8:12]))
+			if at+total > uint64(len(tiff)) {
+				continue
+			}
+			value = tiff[at : at+total]
+		}
+		entries[tag] = exifEntry{kind: kind, value: value}
+	}
+	return entries, nil
+}
+
+// parseEXIFDate parses an EXIF date with an optional "+HH:MM" offset. EXIF
+// dates without an offset are camera-local with no zone; we record them as UTC
+// so they round-trip unchanged.
+func parseEXIFDate(value, offset string) (time.Time, bool) {
+	if value == "" || strings.HasPrefix(value, "0000") {
+		return time.Time{}, false
+	}
+	if offset != "" {
+		if t, err := time.Parse(exifDateLayout+"-07:00", value+offset); err == nil {
+			return t, true
+		}
+	}
+	t, err := time.Parse(exifDateLayout, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
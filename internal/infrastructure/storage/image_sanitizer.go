@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"time"
+
+	_ "golang.org/x/image/webp"
+)
+
+// jpegQuality is used when re-encoding JPEG uploads. High enough that a
+// single generation loss is not visible on photos.
+const jpegQuality = 92
+
+// ImageMetadata holds the camera fields we keep from an upload. They are
+// stored next to the object rather than embedded in the public file.
+type ImageMetadata struct {
+	Width      int
+	Height     int
+	CapturedAt *time.Time
+}
+
+// sanitizedImage is an upload payload with its metadata stripped and its
+// EXIF orientation applied to the pixels. ContentType may differ from the
+// input's when a format had to be converted (see sanitizeImage).
+type sanitizedImage struct {
+	Data        []byte
+	ContentType string
+	Metadata    ImageMetadata
+}
+
+// sanitizeImage removes EXIF/XMP/text metadata (GPS coordinates included)
+// from JPEG, PNG and WebP uploads and bakes the EXIF orientation into the
+// pixels so browsers that ignore the tag still render the photo upright.
+//
+// JPEG and PNG are decoded and re-encoded; the standard library encoders
+// never write metadata. Go has no WebP encoder, so WebP metadata chunks are
+// dropped from the RIFF container without touching the bitstream. A WebP that
+// needs rotating is the one case we can't keep in its own format — it is
+// re-encoded as (lossless) PNG instead. GIF carries no EXIF and passes through.
+func sanitizeImage(data []byte, contentType string) (*sanitizedImage, error) {
+	contentType = strings.ToLower(contentType)
+
+	info := &exifInfo{Orientation: 1}
+	if raw := extractEXIF(data, contentType); raw != nil {
+		if parsed, err := parseEXIF(raw); err == nil {
+			info = parsed
+		}
+	}
+
+	switch contentType {
+	case "image/jpeg":
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode image: %w", err)
+		}
+		img = applyOrientation(img, info.Orientation)
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, fmt.Errorf("failed to re-encode image: %w", err)
+		}
+		return newSanitizedImage(buf.Bytes(), contentType, img.Bounds(), info), nil
+
+	case "image/png":
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode image: %w", err)
+		}
+		img = applyOrientation(img, info.Orientation)
+		encoded, err := encodePNG(img)
+		if err != nil {
+			return nil, err
+		}
+		return newSanitizedImage(encoded, contentType, img.Bounds(), info), nil
+
+	case "image/webp":
+		if info.Orientation == 1 {
+			stripped, err := stripWebPMetadata(data)
+			if err != nil {
+				return nil, err
+			}
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(stripped))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode image: %w", err)
+			}
+			return newSanitizedImage(stripped, contentType, image.Rect(0, 0, cfg.Width, cfg.Height), info), nil
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode image: %w", err)
+		}
+		img = applyOrientation(img, info.Orientation)
+		encoded, err := encodePNG(img)
+		if err != nil {
+			return nil, err
+		}
+		return newSanitizedImage(encoded, "image/png", img.Bounds(), info), nil
+
+	default:
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode image: %w", err)
+		}
+		return newSanitizedImage(data, contentType, image.Rect(0, 0, cfg.Width, cfg.Height), info), nil
+	}
+}
+
+func newSanitizedImage(data []byte, contentType string, bounds image.Rectangle, info *exifInfo) *sanitizedImage {
+	return &sanitizedImage{
+		Data:        data,
+		ContentType: contentType,
+		Metadata: ImageMetadata{
+			Width:      bounds.Dx(),
+			Height:     bounds.Dy(),
+			CapturedAt: info.CapturedAt,
+		},
+	}
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to re-encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// applyOrientation returns img transformed so that it displays upright
+// without the EXIF orientation tag. Orientation values follow the EXIF spec:
+// 2/4 mirror, 3 rotates 180°, 6/8 rotate 90° CW/CCW, 5/7 transpose/transverse.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	src := toNRGBA(img)
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			si := y*src.Stride + x*4
+			di := dy*dst.Stride + dx*4
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
+
+// toNRGBA copies img into a zero-origin NRGBA so pixels can be moved by index.
+func toNRGBA(img image.Image) *image.NRGBA {
+	if n, ok := img.(*image.NRGBA); ok && n.Rect.Min == (image.Point{}) {
+		return n
+	}
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return dst
+}
+
+// webpChunk is one top-level chunk inside a WebP RIFF container.
+type webpChunk struct {
+	fourCC string
+	body   []byte
+}
+
+// webpChunks splits a WebP file into its top-level chunks. Returns nil for
+// anything that isn't a well-formed RIFF/WEBP container.
+func webpChunks(data []byte) []webpChunk {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil
+	}
+	var chunks []webpChunk
+	pos := 12
+	for pos+8 <= len(data) {
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + size
+		if end > len(data) {
+			return nil
+		}
+		chunks = append(chunks, webpChunk{fourCC: string(data[pos : pos+4]), body: data[pos+8 : end]})
+		// Chunks are padded to an even length.
+		pos = end + size%2
+	}
+	return chunks
+}
+
+// VP8X feature flags for the metadata chunks we drop.
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// stripWebPMetadata rebuilds the RIFF container without EXIF and XMP chunks
+// and clears the matching VP8X feature flags. The image bitstream itself is
+// copied verbatim.
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	chunks := webpChunks(data)
+	if chunks == nil {
+		return nil, fmt.Errorf("failed to decode image: malformed WebP container")
+	}
+
+	var body bytes.Buffer
+	body.WriteString("WEBP")
+	for _, c := range chunks {
+		if c.fourCC == "EXIF" || c.fourCC == "XMP " {
+			continue
+		}
+		chunkBody := c.body
+		if c.fourCC == "VP8X" && len(chunkBody) > 0 {
+			chunkBody = append([]byte(nil), chunkBody...)
+			chunkBody[0] &^= webpFlagEXIF | webpFlagXMP
+		}
+		var header [8]byte
+		copy(header[0:4], c.fourCC)
+		binary.LittleEndian.PutUint32(header[4:8], uint32(len(chunkBody)))
+		body.Write(header[:])
+		body.Write(chunkBody)
+		if len(chunkBody)%2 == 1 {
+			body.WriteByte(0)
+		}
+	}
+
+	out := make([]byte, 8, 8+body.Len())
+	copy(out[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(out[4:8], uint32(body.Len()))
+	return append(out, body.Bytes()...), nil
+}
@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+)
+
+// LocalStorage is the ObjectStorage backend for a directory on disk, for
+// running the API without MinIO. Files are served by the API itself: the
+// router mounts Root() as a static route at URLPath(). Content types are
+// derived from the file extension and Put metadata is not kept.
+type LocalStorage struct {
+	root      string
+	publicURL string
+	logger    *logging.Logger
+}
+
+// NewLocalStorage creates the root directory if needed. publicURL is the
+// externally visible base the files are served from, e.g.
+// http://localhost:8080/media-files.
+func NewLocalStorage(root, publicURL string, logger *logging.Logger) (*LocalStorage, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local storage directory is not configured")
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local storage directory: %w", err)
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory: %w", err)
+	}
+	if _, err := url.Parse(publicURL); err != nil {
+		return nil, fmt.Errorf("invalid storage public URL: %w", err)
+	}
+
+	logger.Info("Local file storage initialized",
+		logging.F("root", abs),
+		logging.F("public_url", publicURL),
+	)
+	return &LocalStorage{
+		root:      abs,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+		logger:    logger,
+	}, nil
+}
+
+// Root is the directory objects are stored in.
+func (s *LocalStorage) Root() string {
+	return s.root
+}
+
+// URLPath is the path component of the public URL, where the router should
+// mount the static file route.
+func (s *LocalStorage) URLPath() string {
+	u, _ := url.Parse(s.publicURL)
+	if u.Path == "" {
+		return "/"
+	}
+	return u.Path
+}
+
+// pathFor maps a key to a file under root. Keys must be clean and relative,
+// and the joined path must still lie below root, so ".." segments can't
+// reach files outside it.
+func (s *LocalStorage) pathFor(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || filepath.IsAbs(key) || path.Clean(key) != key {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	p := filepath.Join(s.root, filepath.FromSlash(key))
+	rel, err := filepath.Rel(s.root, p)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return p, nil
+}
+
+// Put writes to a temp file in the destination directory and renames it into
+// place, so readers never see a partially written object.
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, opts PutOptions) (int64, error) {
+	dst, err := s.pathFor(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".put-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file for %s: %w", key, err)
+	}
+	written, err := io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && size >= 0 && written != size {
+		err = fmt.Errorf("wrote %d bytes, expected %d", written, size)
+	}
+	if err == nil {
+		// CreateTemp makes the file 0600; objects are public.
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), dst)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return 0, fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+	return written, nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.pathFor(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	return f, nil
+}
+
+func (s *LocalStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	p, err := s.pathFor(key)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && fi.IsDir()) {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat object %s: %w", key, err)
+	}
+	return s.objectInfo(key, fi), nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	p, err := s.pathFor(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	s.logger.Info("Object deleted", logging.F("key", key))
+	return nil
+}
+
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var out []ObjectInfo
+	err := filepath.WalkDir(s.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if d.IsDir() {
+			// Skip directories that can't contain matching keys.
+			if key != "." && !strings.HasPrefix(key+"/", prefix) && !strings.HasPrefix(prefix, key+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) || strings.HasPrefix(d.Name(), ".put-") {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		out = append(out, *s.objectInfo(key, fi))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+	}
+	return out, nil
+}
+
+func (s *LocalStorage) Move(ctx context.Context, srcKey, dstKey string) error {
+	src, err := s.pathFor(srcKey)
+	if err != nil {
+		return err
+	}
+	dst, err := s.pathFor(dstKey)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", dstKey, err)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", srcKey, dstKey, err)
+	}
+	// Stamp the move time, matching S3 where the copy is a new object; the
+	// media GC measures quarantine from it.
+	now := timeNow()
+	_ = os.Chtimes(dst, now, now)
+	return nil
+}
+
+func (s *LocalStorage) Touch(ctx context.Context, key string) error {
+	p, err := s.pathFor(key)
+	if err != nil {
+		return err
+	}
+	now := timeNow()
+	err = os.Chtimes(p, now, now)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to touch object %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) PublicURL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+func (s *LocalStorage) KeyFromURL(url string) (string, bool) {
+	return keyFromURL(s.publicURL+"/", url)
+}
+
+func (s *LocalStorage) IsTrustedURL(url string) bool {
+	_, ok := s.KeyFromURL(url)
+	return ok
+}
+
+func (s *LocalStorage) objectInfo(key string, fi fs.FileInfo) *ObjectInfo {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &ObjectInfo{
+		Key:          key,
+		Size:         fi.Size(),
+		ContentType:  contentType,
+		LastModified: fi.ModTime(),
+	}
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStorage is an in-process ObjectStorage backend for tests and local
+// experiments. Nothing is persisted and nothing serves the public URLs.
+type MemoryStorage struct {
+	mu        sync.RWMutex
+	objects   map[string]*memoryObject
+	publicURL string
+}
+
+type memoryObject struct {
+	data []byte
+	info ObjectInfo
+}
+
+// NewMemoryStorage creates an empty store whose objects claim to be served
+// under publicURL.
+func NewMemoryStorage(publicURL string) *MemoryStorage {
+	return &MemoryStorage{
+		objects:   make(map[string]*memoryObject),
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+func (s *MemoryStorage) Put(ctx context.Context, key string, r io.Reader, size int64, opts PutOptions) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+	if size >= 0 && int64(len(data)) != size {
+		return 0, fmt.Errorf("failed to put object %s: read %d bytes, expected %d", key, len(data), size)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = &memoryObject{
+		data: data,
+		info: ObjectInfo{
+			Key:          key,
+			Size:         int64(len(data)),
+			ContentType:  opts.ContentType,
+			LastModified: timeNow(),
+		},
+	}
+	return int64(len(data)), nil
+}
+
+func (s *MemoryStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	obj, ok := s.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	return memoryReader{bytes.NewReader(obj.data)}, nil
+}
+
+// memoryReader keeps bytes.Reader's Seek visible, which io.NopCloser would
+// hide.
+type memoryReader struct {
+	*bytes.Reader
+}
+
+func (memoryReader) Close() error { return nil }
+
+func (s *MemoryStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	obj, ok := s.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	info := obj.info
+	return &info, nil
+}
+
+func (s *MemoryStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+// List returns matching objects sorted by key, like an S3 listing.
+func (s *MemoryStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []ObjectInfo
+	for key, obj := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			out = append(out, obj.info)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+func (s *MemoryStorage) Move(ctx context.Context, srcKey, dstKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[srcKey]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrObjectNotFound, srcKey)
+	}
+	delete(s.objects, srcKey)
+	obj.info.Key = dstKey
+	obj.info.LastModified = timeNow()
+	s.objects[dstKey] = obj
+	return nil
+}
+
+func (s *MemoryStorage) Touch(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	obj.info.LastModified = timeNow()
+	return nil
+}
+
+func (s *MemoryStorage) PublicURL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+func (s *MemoryStorage) KeyFromURL(url string) (string, bool) {
+	return keyFromURL(s.publicURL+"/", url)
+}
+
+func (s *MemoryStorage) IsTrustedURL(url string) bool {
+	_, ok := s.KeyFromURL(url)
+	return ok
+}
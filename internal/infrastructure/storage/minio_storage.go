@@ -1,30 +1,27 @@
 package storage
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"image"
-	_ "image/gif"
-	_ "image/jpeg"
-	_ "image/png"
-	"path/filepath"
+	"io"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/davidrdsilva/blog-api/config"
 	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
-	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
-// MinIOStorage handles file uploads to MinIO object storage
+// MinIOStorage is the ObjectStorage backend for MinIO (or any S3-compatible
+// service). Objects under PublicPrefixes are public-read through the bucket
+// policy.
 type MinIOStorage struct {
 	client    *minio.Client
 	bucket    string
 	publicURL string
-	config    *config.UploadConfig
 	logger    *logging.Logger
 }
 
@@ -47,7 +44,6 @@ func NewMinIOStorage(cfg *config.Config, log *logging.Logger) (*MinIOStorage, er
 		client:    client,
 		bucket:    cfg.MinIO.Bucket,
 		publicURL: cfg.MinIO.PublicURL,
-		config:    &cfg.Upload,
 		logger:    log,
 	}
 
@@ -60,7 +56,8 @@ func NewMinIOStorage(cfg *config.Config, log *logging.Logger) (*MinIOStorage, er
 	return storage, nil
 }
 
-// ensureBucket creates the bucket if it doesn't exist
+// ensureBucket creates the bucket if it doesn't exist and (re)applies its
+// policy and lifecycle on every start, so existing buckets pick up changes.
 func (s *MinIOStorage) ensureBucket() error {
 	ctx := context.Background()
 
@@ -76,129 +73,231 @@ func (s *MinIOStorage) ensureBucket() error {
 		if err != nil {
 			return fmt.Errorf("failed to create bucket: %w", err)
 		}
+	}
 
-		// Set bucket policy to public-read for uploaded images
-		policy := fmt.Sprintf(`{
-			"Version": "2012-10-17",
-			"Statement": [{
-				"Effect": "Allow",
-				"Principal": {"AWS": ["*"]},
-				"Action": ["s3:GetObject"],
-				"Resource": ["arn:aws:s3:::%s/*"]
-			}]
-		}`, s.bucket)
-
-		err = s.client.SetBucketPolicy(ctx, s.bucket, policy)
-		if err != nil {
-			return fmt.Errorf("failed to set bucket policy: %w", err)
-		}
+	// Only the public prefixes are readable anonymously. Direct uploads sit
+	// under TempPrefix until CompletePresigned has checked them, and must not
+	// be servable before that.
+	resources := make([]string, len(PublicPrefixes))
+	for i, prefix := range PublicPrefixes {
+		resources[i] = fmt.Sprintf(`"arn:aws:s3:::%s/%s*"`, s.bucket, prefix)
+	}
+	policy := fmt.Sprintf(`{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Principal": {"AWS": ["*"]},
+			"Action": ["s3:GetObject"],
+			"Resource": [%s]
+		}]
+	}`, strings.Join(resources, ", "))
+
+	err = s.client.SetBucketPolicy(ctx, s.bucket, policy)
+	if err != nil {
+		return fmt.Errorf("failed to set bucket policy: %w", err)
+	}
 
-		s.logger.Info("Bucket created with public-read policy")
+	// Expire temp objects in the bucket itself, so a direct upload that is
+	// never completed goes away even when the media GC is disabled.
+	if err := s.ensureTempExpiry(ctx); err != nil {
+		s.logger.Warn("Failed to set bucket lifecycle; stale temp uploads are left to the media GC",
+			logging.F("error", err.Error()),
+		)
 	}
 
+	s.logger.Info("Bucket policy applied", logging.F("public_prefixes", strings.Join(PublicPrefixes, ",")))
 	return nil
 }
 
-func (s *MinIOStorage) isVideoMimeType(mimeType string) bool {
-	return strings.HasPrefix(strings.ToLower(mimeType), "video/")
-}
+// tempExpiryRuleID identifies the lifecycle rule ensureTempExpiry manages.
+const tempExpiryRuleID = "expire-temp-uploads"
 
-func (s *MinIOStorage) UploadImage(fileData []byte, originalFilename string, contentType string) (string, error) {
-	if !s.isAllowedMimeType(contentType) {
-		return "", fmt.Errorf("invalid file type: %s (allowed: %v)", contentType, s.config.AllowedMimeTypes)
-	}
-
-	maxSizeMB := float64(s.config.MaxFileSizeMB)
-	if s.isVideoMimeType(contentType) {
-		maxSizeMB = float64(s.config.MaxVideoFileSizeMB)
+// ensureTempExpiry adds the rule expiring TempPrefix to the bucket's
+// lifecycle, or replaces an earlier version of it. Other rules are kept as
+// they are, since operators may have configured their own.
+func (s *MinIOStorage) ensureTempExpiry(ctx context.Context) error {
+	lc, err := s.client.GetBucketLifecycle(ctx, s.bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchLifecycleConfiguration" {
+			return fmt.Errorf("failed to get bucket lifecycle: %w", err)
+		}
+		lc = lifecycle.NewConfiguration()
 	}
 
-	fileSizeMB := float64(len(fileData)) / (1024 * 1024)
-	if fileSizeMB > maxSizeMB {
-		return "", fmt.Errorf("file size %.2fMB exceeds maximum allowed size of %.0fMB", fileSizeMB, maxSizeMB)
+	rule := lifecycle.Rule{
+		ID:         tempExpiryRuleID,
+		Status:     "Enabled",
+		RuleFilter: lifecycle.Filter{Prefix: TempPrefix},
+		Expiration: lifecycle.Expiration{Days: tempObjectExpiryDays},
 	}
-
-	if !s.isVideoMimeType(contentType) {
-		if err := s.validateImageDimensions(fileData); err != nil {
-			return "", err
+	rules := make([]lifecycle.Rule, 0, len(lc.Rules)+1)
+	for _, r := range lc.Rules {
+		if r.ID != tempExpiryRuleID {
+			rules = append(rules, r)
 		}
 	}
+	lc.Rules = append(rules, rule)
 
-	// Generate unique filename with UUID to avoid collisions and spaces
-	ext := filepath.Ext(originalFilename)
-	if ext == "" {
-		ext = s.getExtensionFromMimeType(contentType)
+	if err := s.client.SetBucketLifecycle(ctx, s.bucket, lc); err != nil {
+		return fmt.Errorf("failed to set bucket lifecycle: %w", err)
 	}
-	filename := fmt.Sprintf("uploads/%s%s", uuid.New().String(), ext)
-
-	// Upload to MinIO
-	ctx := context.Background()
-	reader := bytes.NewReader(fileData)
+	return nil
+}
 
-	_, err := s.client.PutObject(ctx, s.bucket, filename, reader, int64(len(fileData)), minio.PutObjectOptions{
-		ContentType: contentType,
+func (s *MinIOStorage) Put(ctx context.Context, key string, r io.Reader, size int64, opts PutOptions) (int64, error) {
+	info, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType:  opts.ContentType,
+		UserMetadata: opts.Metadata,
+		// Only used when size is -1: caps the part buffer minio-go allocates.
+		PartSize: uploadPartSize,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to upload file: %w", err)
+		return 0, fmt.Errorf("failed to put object %s: %w", key, err)
 	}
+	return info.Size, nil
+}
 
-	// Generate public URL
-	url := fmt.Sprintf("%s/%s/%s", s.publicURL, s.bucket, filename)
+func (s *MinIOStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	// GetObject is lazy; stat first so a missing key fails here rather than
+	// on the first Read.
+	if _, err := s.Stat(ctx, key); err != nil {
+		return nil, err
+	}
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	return obj, nil
+}
 
-	s.logger.Info("Image uploaded successfully",
-		logging.F("filename", filename),
-		logging.F("size_mb", fmt.Sprintf("%.2f", fileSizeMB)),
-	)
+func (s *MinIOStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to stat object %s: %w", key, err)
+	}
+	return &ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}, nil
+}
 
-	return url, nil
+func (s *MinIOStorage) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	s.logger.Info("Object deleted", logging.F("key", key))
+	return nil
 }
 
-func (s *MinIOStorage) isAllowedMimeType(mimeType string) bool {
-	for _, allowed := range s.config.AllowedMimeTypes {
-		if strings.EqualFold(mimeType, allowed) {
-			return true
+func (s *MinIOStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var out []ObjectInfo
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, obj.Err)
 		}
+		out = append(out, ObjectInfo{
+			Key:          obj.Key,
+			Size:         obj.Size,
+			ContentType:  obj.ContentType,
+			LastModified: obj.LastModified,
+		})
 	}
-	return false
+	return out, nil
 }
 
-func (s *MinIOStorage) validateImageDimensions(fileData []byte) error {
-	reader := bytes.NewReader(fileData)
-	img, _, err := image.DecodeConfig(reader)
+// Move copies srcKey to dstKey server-side, then removes srcKey. S3 has no
+// rename, so a failure between the two steps leaves both copies; the next
+// move of the same key simply overwrites the destination.
+func (s *MinIOStorage) Move(ctx context.Context, srcKey, dstKey string) error {
+	_, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.bucket, Object: dstKey},
+		minio.CopySrcOptions{Bucket: s.bucket, Object: srcKey},
+	)
 	if err != nil {
-		return fmt.Errorf("failed to decode image: %w", err)
+		return fmt.Errorf("failed to copy %s to %s: %w", srcKey, dstKey, err)
 	}
-
-	maxDim := s.config.MaxImageDimension
-	if img.Width > maxDim || img.Height > maxDim {
-		return fmt.Errorf("image dimensions %dx%d exceed maximum allowed %dx%d",
-			img.Width, img.Height, maxDim, maxDim)
+	if err := s.client.RemoveObject(ctx, s.bucket, srcKey, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to remove %s after copy: %w", srcKey, err)
 	}
+	return nil
+}
 
+// Touch copies the object onto itself. S3 only allows that when the metadata
+// is replaced, so the existing metadata is read first and written back.
+func (s *MinIOStorage) Touch(ctx context.Context, key string) error {
+	info, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+		}
+		return fmt.Errorf("failed to stat object %s: %w", key, err)
+	}
+	_, err = s.client.CopyObject(ctx,
+		minio.CopyDestOptions{
+			Bucket:          s.bucket,
+			Object:          key,
+			UserMetadata:    info.UserMetadata,
+			ReplaceMetadata: true,
+			ContentType:     info.ContentType,
+		},
+		minio.CopySrcOptions{Bucket: s.bucket, Object: key},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to touch object %s: %w", key, err)
+	}
 	return nil
 }
 
-func (s *MinIOStorage) getExtensionFromMimeType(mimeType string) string {
-	switch mimeType {
-	case "image/jpeg":
-		return ".jpg"
-	case "image/png":
-		return ".png"
-	case "image/gif":
-		return ".gif"
-	case "image/webp":
-		return ".webp"
-	case "video/mp4":
-		return ".mp4"
-	case "video/webm":
-		return ".webm"
-	case "video/ogg":
-		return ".ogv"
-	case "video/quicktime":
-		return ".mov"
-	default:
-		return ".bin"
+// PublicURL returns the public-read URL for an object key.
+func (s *MinIOStorage) PublicURL(key string) string {
+	return fmt.Sprintf("%s/%s/%s", s.publicURL, s.bucket, key)
+}
+
+func (s *MinIOStorage) KeyFromURL(url string) (string, bool) {
+	return keyFromURL(fmt.Sprintf("%s/%s/", s.publicURL, s.bucket), url)
+}
+
+func (s *MinIOStorage) IsTrustedURL(url string) bool {
+	_, ok := s.KeyFromURL(url)
+	return ok
+}
+
+// PresignPost builds a POST policy rather than a presigned PUT because only a
+// policy can enforce a content-length range. The policy signature doesn't
+// cover the host, so the URL is rewritten from the internal endpoint (e.g.
+// minio:9000 inside Docker) to the public one browsers can reach.
+func (s *MinIOStorage) PresignPost(ctx context.Context, key, contentType string, maxSize int64, expiry time.Duration) (string, map[string]string, error) {
+	policy := minio.NewPostPolicy()
+	for _, err := range []error{
+		policy.SetBucket(s.bucket),
+		policy.SetKey(key),
+		policy.SetContentType(contentType),
+		policy.SetContentLengthRange(1, maxSize),
+		policy.SetExpires(time.Now().UTC().Add(expiry)),
+	} {
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to build upload policy: %w", err)
+		}
+	}
+
+	u, fields, err := s.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to presign upload: %w", err)
+	}
+	if public, err := url.Parse(s.publicURL); err == nil && public.Host != "" {
+		u.Scheme = public.Scheme
+		u.Host = public.Host
+		u.Path = strings.TrimSuffix(public.Path, "/") + u.Path
 	}
+	return u.String(), fields, nil
 }
 
 func (s *MinIOStorage) HealthCheck() error {
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/config"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+)
+
+// Storage backends selectable with STORAGE_BACKEND.
+const (
+	BackendMinIO  = "minio"
+	BackendLocal  = "local"
+	BackendMemory = "memory"
+)
+
+// timeNow is the clock the local and in-memory backends stamp objects with.
+var timeNow = time.Now
+
+// ErrObjectNotFound is returned (wrapped) by Get and Stat for a missing key.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// PutOptions carries what is stored alongside an object's bytes. Backends
+// that can't keep arbitrary metadata (the local filesystem) ignore Metadata.
+type PutOptions struct {
+	ContentType string
+	Metadata    map[string]string
+}
+
+// ObjectStorage is a flat, bucket-like key/value store for uploaded files.
+// Objects under PublicPrefixes are publicly readable at PublicURL(key).
+type ObjectStorage interface {
+	// Put stores r under key and returns the number of bytes written. size
+	// may be -1 when the length isn't known up front; the backend then
+	// streams without buffering the whole object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, opts PutOptions) (int64, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	// Delete removes key. Deleting a missing key is not an error, so callers
+	// can safely retry.
+	Delete(ctx context.Context, key string) error
+	// List returns every object whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// Move renames srcKey to dstKey, overwriting dstKey if it exists.
+	Move(ctx context.Context, srcKey, dstKey string) error
+	// Touch stamps key with a fresh LastModified, keeping its bytes and
+	// metadata. A missing key returns ErrObjectNotFound (wrapped).
+	Touch(ctx context.Context, key string) error
+
+	PublicURL(key string) string
+	// KeyFromURL is the inverse of PublicURL. It reports false for URLs that
+	// don't point into this store (external images, other hosts).
+	KeyFromURL(url string) (string, bool)
+	// IsTrustedURL reports whether url is served from this store, i.e.
+	// whether it is safe to accept as a post or character image.
+	IsTrustedURL(url string) bool
+}
+
+// Presigner is implemented by backends that can hand clients a signed,
+// time-limited form for uploading one object directly, bypassing the API.
+type Presigner interface {
+	// PresignPost returns the URL to POST a multipart form to and the form
+	// fields that must accompany the file. The signed policy pins the key and
+	// content type and only accepts bodies of 1..maxSize bytes.
+	PresignPost(ctx context.Context, key, contentType string, maxSize int64, expiry time.Duration) (string, map[string]string, error)
+}
+
+// New creates the backend selected by cfg.Storage.Backend.
+func New(cfg *config.Config, logger *logging.Logger) (ObjectStorage, error) {
+	switch cfg.Storage.Backend {
+	case BackendMinIO, "":
+		return NewMinIOStorage(cfg, logger)
+	case BackendLocal:
+		return NewLocalStorage(cfg.Storage.LocalDir, cfg.Storage.PublicURL, logger)
+	case BackendMemory:
+		return NewMemoryStorage(cfg.Storage.PublicURL), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q (want %s, %s or %s)",
+			cfg.Storage.Backend, BackendMinIO, BackendLocal, BackendMemory)
+	}
+}
+
+// keyFromURL strips base (which must end in "/") from url, dropping any
+// query string or fragment. Shared by the backends' KeyFromURL.
+func keyFromURL(base, url string) (string, bool) {
+	if !strings.HasPrefix(url, base) {
+		return "", false
+	}
+	key := strings.TrimPrefix(url, base)
+	if i := strings.IndexAny(key, "?#"); i >= 0 {
+		key = key[:i]
+	}
+	return key, key != ""
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/google/uuid"
+)
+
+const (
+	// presignExpiry is how long a presigned upload form stays valid.
+	presignExpiry = 15 * time.Minute
+
+	// presignedPrefix is where direct uploads land until they are completed.
+	// It sits under TempPrefix so the media GC removes abandoned ones.
+	presignedPrefix = TempPrefix + "presigned/"
+
+	// tempObjectExpiryDays is when the bucket's lifecycle rule deletes
+	// objects under TempPrefix, well after any presigned form has expired.
+	tempObjectExpiryDays = 1
+)
+
+// PresignedUpload is a signed form for uploading one file straight to the
+// bucket. The client POSTs Fields plus the file (as the last field, named
+// "file") to URL as multipart/form-data, then calls CompletePresigned with Key.
+type PresignedUpload struct {
+	Key       string
+	URL       string
+	Fields    map[string]string
+	MaxSize   int64
+	ExpiresAt time.Time
+}
+
+// Presign validates the declared type and size and returns a signed upload
+// form for a fresh key. Only videos can be uploaded this way: images must go
+// through UploadImage so their metadata is stripped before they are stored.
+func (u *Uploader) Presign(ctx context.Context, filename, contentType string, size int64) (*PresignedUpload, error) {
+	presigner, ok := u.objects.(Presigner)
+	if !ok {
+		return nil, fmt.Errorf("presigned uploads are not supported by this storage backend")
+	}
+
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if !u.isAllowedMimeType(contentType) || !u.isVideoMimeType(contentType) {
+		return nil, fmt.Errorf("invalid file type: %s (presigned uploads accept videos only)", contentType)
+	}
+	maxSize := int64(u.config.MaxVideoFileSizeMB) << 20
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid file size: must be positive")
+	}
+	if size > maxSize {
+		return nil, fmt.Errorf("file size %.2fMB exceeds maximum allowed size of %dMB",
+			float64(size)/(1024*1024), u.config.MaxVideoFileSizeMB)
+	}
+
+	key := presignedPrefix + uuid.New().String() + u.extensionFor(filename, contentType)
+	// The declared size is only a hint for the early check above; the
+	// policy allows anything up to the limit and CompletePresigned checks
+	// what actually arrived.
+	formURL, fields, err := presigner.PresignPost(ctx, key, contentType, maxSize, presignExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PresignedUpload{
+		Key:       key,
+		URL:       formURL,
+		Fields:    fields,
+		MaxSize:   maxSize,
+		ExpiresAt: time.Now().Add(presignExpiry),
+	}, nil
+}
+
+// CompletePresigned verifies a direct upload and moves it to its
+// content-addressed key. The object's size is re-checked and its type sniffed
+// from the stored bytes, since the client controlled what was sent. Hashing
+// streams the object, so memory use stays constant whatever its size. An
+// object that fails verification is deleted.
+func (u *Uploader) CompletePresigned(ctx context.Context, key string) (*UploadResult, error) {
+	if !strings.HasPrefix(key, presignedPrefix) || path.Clean(key) != key ||
+		strings.Contains(strings.TrimPrefix(key, presignedPrefix), "/") {
+		return nil, fmt.Errorf("invalid upload key")
+	}
+
+	info, err := u.objects.Stat(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, fmt.Errorf("upload not found: %s", key)
+		}
+		return nil, err
+	}
+
+	result, err := u.verifyPresigned(ctx, key, info)
+	if err == nil {
+		err = u.probeVideo(ctx, key, result)
+	}
+	if err != nil {
+		if derr := u.objects.Delete(ctx, key); derr != nil {
+			u.logger.Warn("Failed to remove rejected direct upload",
+				logging.F("key", key),
+				logging.F("error", derr.Error()),
+			)
+		}
+		return nil, err
+	}
+	return u.promoteTemp(ctx, key, path.Ext(key), result)
+}
+
+func (u *Uploader) verifyPresigned(ctx context.Context, key string, info *ObjectInfo) (*UploadResult, error) {
+	maxSizeMB := u.config.MaxVideoFileSizeMB
+	if info.Size > int64(maxSizeMB)<<20 {
+		return nil, fmt.Errorf("file size exceeds maximum allowed size of %dMB", maxSizeMB)
+	}
+
+	body, err := u.objects.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read file data: %w", err)
+	}
+	head = head[:n]
+
+	contentType := sniffContentType(head)
+	if !u.isAllowedMimeType(contentType) || !u.isVideoMimeType(contentType) {
+		return nil, fmt.Errorf("invalid file type: %s (presigned uploads accept videos only)", contentType)
+	}
+	// The object is served with the type declared at presign time, so the
+	// bytes have to actually be that type.
+	if info.ContentType != "" && !strings.EqualFold(info.ContentType, contentType) {
+		return nil, fmt.Errorf("invalid file type: declared %s but file is %s", info.ContentType, contentType)
+	}
+
+	hasher := sha256.New()
+	hasher.Write(head)
+	rest, err := io.Copy(hasher, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file data: %w", err)
+	}
+
+	return &UploadResult{
+		ContentType: contentType,
+		Size:        int64(n) + rest,
+		SHA256:      hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
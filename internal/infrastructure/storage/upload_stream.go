@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// sniffLen is how much of the upload is inspected to determine its real
+	// content type; it matches what http.DetectContentType looks at.
+	sniffLen = 512
+
+	// imageHeaderChunk is the first prefix read to find an image's
+	// dimensions. It covers the frame header of nearly every image, so a huge
+	// one is rejected before the rest of it is read; files with larger
+	// metadata segments are read further.
+	imageHeaderChunk = 256 << 10
+
+	// uploadPartSize is the buffer minio-go allocates per streaming upload
+	// when the length is unknown. Left unset it would size parts for a 5 TiB
+	// object (~550 MiB each); 5 MiB is the S3 minimum.
+	uploadPartSize = 5 << 20
+)
+
+// errFileTooLarge is returned by sizeLimitedReader once more than the limit
+// has been read.
+var errFileTooLarge = errors.New("file exceeds maximum allowed size")
+
+// sizeLimitedReader fails with errFileTooLarge instead of silently truncating
+// like io.LimitReader, so an oversized upload is an error rather than a
+// corrupt object.
+type sizeLimitedReader struct {
+	r        io.Reader
+	limit    int64
+	read     int64
+	exceeded bool
+}
+
+func newSizeLimitedReader(r io.Reader, limit int64) *sizeLimitedReader {
+	return &sizeLimitedReader{r: r, limit: limit}
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.exceeded {
+		return 0, errFileTooLarge
+	}
+	// Read at most one byte past the limit: that's enough to tell a file of
+	// exactly limit bytes from one that is larger.
+	if remaining := l.limit - l.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		l.exceeded = true
+		return n, errFileTooLarge
+	}
+	return n, err
+}
+
+// sniffContentType determines the upload's MIME type from its first bytes.
+// http.DetectContentType covers the image formats and WebM; ISO-BMFF (MP4 and
+// QuickTime) and Ogg are refined here because the standard sniffer either
+// doesn't tell them apart or reports a non-video type.
+func sniffContentType(head []byte) string {
+	if len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")) {
+		if bytes.Equal(head[8:12], []byte("qt  ")) {
+			return "video/quicktime"
+		}
+		return "video/mp4"
+	}
+
+	detected := http.DetectContentType(head)
+	if i := strings.IndexByte(detected, ';'); i >= 0 {
+		detected = detected[:i]
+	}
+	if detected == "application/ogg" {
+		return "video/ogg"
+	}
+	return detected
+}
@@ -0,0 +1,402 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidrdsilva/blog-api/config"
+	"github.com/davidrdsilva/blog-api/internal/infrastructure/logging"
+	"github.com/google/uuid"
+)
+
+// Key prefixes inside the bucket. New uploads land under UploadsPrefix; the
+// media garbage collector moves unreferenced objects under QuarantinePrefix
+// (keeping the rest of the key) before deleting them for good.
+const (
+	UploadsPrefix    = "uploads/"
+	QuarantinePrefix = "quarantine/"
+	// TempPrefix holds streamed uploads until their content hash, and so
+	// their final key, is known.
+	TempPrefix = "tmp/"
+	// ExportsPrefix holds generated downloads (Whitenest EPUBs). The media
+	// garbage collector leaves it alone; exports are replaced by newer ones.
+	ExportsPrefix = "exports/"
+)
+
+// PublicPrefixes are the key prefixes served to readers. Temp and
+// quarantined objects stay private.
+var PublicPrefixes = []string{UploadsPrefix, ExportsPrefix}
+
+// Uploader validates, sanitizes and stores uploaded files on whichever
+// ObjectStorage backend is configured. It owns the upload rules (allowed
+// types, size and dimension limits, metadata stripping, content-addressed
+// keys); the backend only moves bytes.
+type Uploader struct {
+	objects ObjectStorage
+	config  *config.UploadConfig
+	logger  *logging.Logger
+}
+
+// NewUploader creates an uploader writing to objects.
+func NewUploader(objects ObjectStorage, cfg *config.UploadConfig, logger *logging.Logger) *Uploader {
+	return &Uploader{
+		objects: objects,
+		config:  cfg,
+		logger:  logger,
+	}
+}
+
+// reuseExisting reports whether key is already stored. An existing object is
+// touched so the media GC's grace period restarts from this upload: it may
+// have been unreferenced long enough to be quarantined on the next sweep,
+// right after being handed out again.
+func (u *Uploader) reuseExisting(ctx context.Context, key string) (bool, error) {
+	err := u.objects.Touch(ctx, key)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, ErrObjectNotFound) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check for existing object: %w", err)
+}
+
+func (u *Uploader) isVideoMimeType(mimeType string) bool {
+	return strings.HasPrefix(strings.ToLower(mimeType), "video/")
+}
+
+// UploadResult describes a stored object. CapturedAt is only populated for
+// images. Deduplicated is set when an object with the same
+// bytes already existed and was reused instead of written again.
+type UploadResult struct {
+	URL          string
+	Key          string
+	ContentType  string
+	Size         int64
+	SHA256       string
+	Width        int
+	Height       int
+	CapturedAt   *time.Time
+	Deduplicated bool
+	// Video only, read from the container; Width/Height above hold the
+	// video track's resolution.
+	Duration   *float64
+	VideoCodec string
+	AudioCodec string
+}
+
+// UploadImage validates and stores an upload read from r. The content type is
+// sniffed from the first bytes; the client-declared type is only logged when
+// it disagrees. Nothing is read past the configured size limit.
+//
+// Images are buffered (up to the image size limit) because they have to be
+// decoded and re-encoded to strip metadata. Videos are streamed straight into
+// an unknown-length multipart upload, so memory use stays at one part buffer
+// regardless of file size.
+func (u *Uploader) UploadImage(r io.Reader, originalFilename string, clientContentType string) (*UploadResult, error) {
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read file data: %w", err)
+	}
+	if len(head) == 0 {
+		return nil, fmt.Errorf("failed to read file data: file is empty")
+	}
+
+	contentType := sniffContentType(head)
+	if !u.isAllowedMimeType(contentType) {
+		return nil, fmt.Errorf("invalid file type: %s (allowed: %v)", contentType, u.config.AllowedMimeTypes)
+	}
+	if clientContentType != "" && !strings.EqualFold(clientContentType, contentType) {
+		u.logger.Warn("Declared content type does not match file contents",
+			logging.F("declared", clientContentType),
+			logging.F("detected", contentType),
+		)
+	}
+
+	if u.isVideoMimeType(contentType) {
+		return u.uploadVideo(br, originalFilename, contentType)
+	}
+	return u.uploadStillImage(br, originalFilename, contentType)
+}
+
+// MaxUploadBytes is the largest file any upload may be.
+func (u *Uploader) MaxUploadBytes() int64 {
+	return u.config.MaxUploadBytes()
+}
+
+func (u *Uploader) uploadStillImage(r io.Reader, originalFilename string, contentType string) (*UploadResult, error) {
+	limited := newSizeLimitedReader(r, int64(u.config.MaxFileSizeMB)<<20)
+
+	// Check dimensions from a prefix before reading the rest, so an oversized
+	// (or decompression-bomb) upload is rejected cheaply.
+	var buf bytes.Buffer
+	if err := u.validateImageDimensions(&buf, limited); err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(&buf, limited); err != nil {
+		return nil, u.readError(err, u.config.MaxFileSizeMB)
+	}
+
+	// Photos straight off a phone carry GPS coordinates in EXIF, and the
+	// bucket is public-read. Strip it before anything is written.
+	sanitized, err := sanitizeImage(buf.Bytes(), contentType)
+	if err != nil {
+		return nil, err
+	}
+	fileData := sanitized.Data
+	result := &UploadResult{
+		ContentType: sanitized.ContentType,
+		Width:       sanitized.Metadata.Width,
+		Height:      sanitized.Metadata.Height,
+		CapturedAt:  sanitized.Metadata.CapturedAt,
+	}
+
+	userMetadata := map[string]string{
+		"Width":  strconv.Itoa(result.Width),
+		"Height": strconv.Itoa(result.Height),
+	}
+	if result.CapturedAt != nil {
+		userMetadata["Captured-At"] = result.CapturedAt.Format(time.RFC3339)
+	}
+
+	// Objects are content-addressed: the key is the SHA-256 of the stored
+	// (sanitized) bytes, so re-uploading the same file maps to the same key
+	// and an existing object can be reused instead of written again. The
+	// extension comes from the stored content type rather than the client's
+	// filename so "photo.jpeg" and "photo.JPG" land on the same key.
+	sum := sha256.Sum256(fileData)
+	result.SHA256 = hex.EncodeToString(sum[:])
+	filename := UploadsPrefix + result.SHA256 + u.extensionFor(originalFilename, result.ContentType)
+
+	result.URL = u.objects.PublicURL(filename)
+	result.Key = filename
+	result.Size = int64(len(fileData))
+
+	ctx := context.Background()
+
+	exists, err := u.reuseExisting(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		result.Deduplicated = true
+		u.logReused(result)
+		return result, nil
+	}
+
+	reader := bytes.NewReader(fileData)
+	_, err = u.objects.Put(ctx, filename, reader, int64(len(fileData)), PutOptions{
+		ContentType: result.ContentType,
+		Metadata:    userMetadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	u.logStored(result)
+	return result, nil
+}
+
+// uploadVideo streams r into the bucket. The content-addressed key isn't
+// known until the last byte has been hashed, so the object is first written
+// under TempPrefix and then either moved to its final key or, if those bytes
+// are already stored, discarded in favour of the existing object.
+func (u *Uploader) uploadVideo(r io.Reader, originalFilename string, contentType string) (*UploadResult, error) {
+	limited := newSizeLimitedReader(r, int64(u.config.MaxVideoFileSizeMB)<<20)
+	hasher := sha256.New()
+	ext := u.extensionFor(originalFilename, contentType)
+	tempKey := TempPrefix + uuid.New().String() + ext
+
+	ctx := context.Background()
+
+	size, err := u.objects.Put(ctx, tempKey, io.TeeReader(limited, hasher), -1, PutOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		if limited.exceeded {
+			return nil, u.readError(errFileTooLarge, u.config.MaxVideoFileSizeMB)
+		}
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	result := &UploadResult{
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      hex.EncodeToString(hasher.Sum(nil)),
+	}
+	if err := u.probeVideo(ctx, tempKey, result); err != nil {
+		_ = u.objects.Delete(ctx, tempKey)
+		return nil, err
+	}
+	return u.promoteTemp(ctx, tempKey, ext, result)
+}
+
+// probeVideo reads the stored object's container metadata into result. The
+// backends' readers are seekable, so only the metadata is fetched, not the
+// media payload.
+func (u *Uploader) probeVideo(ctx context.Context, key string, result *UploadResult) error {
+	body, err := u.objects.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	meta, err := parseVideoMetadata(body, result.ContentType)
+	if err != nil {
+		return err
+	}
+	result.Duration = meta.Duration
+	result.Width = meta.Width
+	result.Height = meta.Height
+	result.VideoCodec = meta.VideoCodec
+	result.AudioCodec = meta.AudioCodec
+	return nil
+}
+
+// promoteTemp moves a fully written temp object to its content-addressed key,
+// or discards it in favour of an existing object with the same bytes. result
+// must carry the content type, size and hash; Key and URL are filled in.
+func (u *Uploader) promoteTemp(ctx context.Context, tempKey, ext string, result *UploadResult) (*UploadResult, error) {
+	result.Key = UploadsPrefix + result.SHA256 + ext
+	result.URL = u.objects.PublicURL(result.Key)
+
+	exists, err := u.reuseExisting(ctx, result.Key)
+	if err == nil && exists {
+		result.Deduplicated = true
+		if err := u.objects.Delete(ctx, tempKey); err != nil {
+			u.logger.Warn("Failed to remove temporary upload",
+				logging.F("key", tempKey),
+				logging.F("error", err.Error()),
+			)
+		}
+		u.logReused(result)
+		return result, nil
+	}
+	if err == nil {
+		err = u.objects.Move(ctx, tempKey, result.Key)
+	}
+	if err != nil {
+		// Leave nothing behind; the media GC also clears stale temp objects
+		// in case this cleanup fails too.
+		_ = u.objects.Delete(ctx, tempKey)
+		return nil, err
+	}
+
+	u.logStored(result)
+	return result, nil
+}
+
+// readError turns a failure reading the upload into the error the service
+// maps to a response code.
+func (u *Uploader) readError(err error, maxSizeMB int) error {
+	if errors.Is(err, errFileTooLarge) {
+		return fmt.Errorf("file size exceeds maximum allowed size of %dMB", maxSizeMB)
+	}
+	return fmt.Errorf("failed to read file data: %w", err)
+}
+
+func (u *Uploader) logStored(result *UploadResult) {
+	u.logger.Info("Image uploaded successfully",
+		logging.F("filename", result.Key),
+		logging.F("size_mb", fmt.Sprintf("%.2f", float64(result.Size)/(1024*1024))),
+	)
+}
+
+func (u *Uploader) logReused(result *UploadResult) {
+	u.logger.Info("Upload matched an existing object, reusing it",
+		logging.F("filename", result.Key),
+		logging.F("size_mb", fmt.Sprintf("%.2f", float64(result.Size)/(1024*1024))),
+	)
+}
+
+// extensionFor picks the stored object's extension. Known content types map
+// to a fixed extension; anything else falls back to the client's filename.
+func (u *Uploader) extensionFor(originalFilename, contentType string) string {
+	if ext := u.getExtensionFromMimeType(contentType); ext != ".bin" {
+		return ext
+	}
+	if ext := strings.ToLower(filepath.Ext(originalFilename)); ext != "" {
+		return ext
+	}
+	return ".bin"
+}
+
+func (u *Uploader) isAllowedMimeType(mimeType string) bool {
+	for _, allowed := range u.config.AllowedMimeTypes {
+		if strings.EqualFold(mimeType, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateImageDimensions reads r into buf until the image's dimensions can
+// be decoded and checks them. The frame header is normally near the start,
+// but a JPEG may put any number of EXIF/ICC segments (up to 64 KB each) ahead
+// of it, so the prefix doubles until the header decodes or the file ends.
+func (u *Uploader) validateImageDimensions(buf *bytes.Buffer, r io.Reader) error {
+	chunk := int64(imageHeaderChunk)
+	var img image.Config
+	for {
+		_, err := io.CopyN(buf, r, chunk)
+		if err != nil && err != io.EOF {
+			return u.readError(err, u.config.MaxFileSizeMB)
+		}
+		var decodeErr error
+		img, _, decodeErr = image.DecodeConfig(bytes.NewReader(buf.Bytes()))
+		if decodeErr == nil {
+			break
+		}
+		// An unrecognised format won't decode with more data either.
+		if err == io.EOF || errors.Is(decodeErr, image.ErrFormat) {
+			return fmt.Errorf("failed to decode image: %w", decodeErr)
+		}
+		chunk = int64(buf.Len())
+	}
+
+	maxDim := u.config.MaxImageDimension
+	if img.Width > maxDim || img.Height > maxDim {
+		return fmt.Errorf("image dimensions %dx%d exceed maximum allowed %dx%d",
+			img.Width, img.Height, maxDim, maxDim)
+	}
+
+	return nil
+}
+
+func (u *Uploader) getExtensionFromMimeType(mimeType string) string {
+	switch mimeType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "video/mp4":
+		return ".mp4"
+	case "video/webm":
+		return ".webm"
+	case "video/ogg":
+		return ".ogv"
+	case "video/quicktime":
+		return ".mov"
+	default:
+		return ".bin"
+	}
+}
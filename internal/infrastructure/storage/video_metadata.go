@@ -0,0 +1,624 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// VideoMetadata is what the container says about a video. Codec identifiers
+// are the container's own: MP4/MOV sample entry types (avc1, hvc1, mp4a) or
+// Matroska codec IDs (V_VP9, A_OPUS). Duration is nil when the container
+// doesn't record one (e.g. a live-recorded WebM).
+type VideoMetadata struct {
+	Duration   *float64
+	Width      int
+	Height     int
+	VideoCodec string
+	AudioCodec string
+}
+
+// errInvalidVideo wraps every parse failure so callers can report them as one
+// class of error.
+var errInvalidVideo = errors.New("invalid video")
+
+func invalidVideo(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", errInvalidVideo, fmt.Sprintf(format, args...))
+}
+
+const (
+	// maxMoovSize bounds the MP4 metadata box read into memory. Real moov
+	// boxes are a few hundred KB even for long videos.
+	maxMoovSize = 32 << 20
+	// maxEBMLHeaderSize bounds the Matroska header/Info/Tracks elements read
+	// into memory.
+	maxEBMLHeaderSize = 1 << 20
+)
+
+// parseVideoMetadata reads container metadata from r, which should be
+// seekable so the (large) media payload can be skipped rather than read.
+// Formats without a parser (Ogg) return empty metadata, not an error.
+func parseVideoMetadata(r io.Reader, contentType string) (*VideoMetadata, error) {
+	switch contentType {
+	case "video/mp4", "video/quicktime":
+		return parseMP4(r)
+	case "video/webm":
+		return parseWebM(r)
+	default:
+		return &VideoMetadata{}, nil
+	}
+}
+
+// skip advances r by n bytes, seeking when possible.
+func skip(r io.Reader, n int64) error {
+	if s, ok := r.(io.Seeker); ok {
+		_, err := s.Seek(n, io.SeekCurrent)
+		return err
+	}
+	copied, err := io.CopyN(io.Discard, r, n)
+	if err == io.EOF && copied < n {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// --- MP4 / QuickTime (ISO base media file format) ---
+
+type mp4Box struct {
+	typ  string
+	body []byte
+}
+
+// readBoxHeader reads a box header from r and returns the type and body size.
+// A size of -1 means the box runs to the end of the file.
+func readBoxHeader(r io.Reader) (string, int64, error) {
+	var hdr [8]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return "", 0, err
+	}
+	size := int64(binary.BigEndian.Uint32(hdr[:4]))
+	typ := string(hdr[4:8])
+	headerLen := int64(8)
+	switch size {
+	case 0:
+		return typ, -1, nil
+	case 1:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return "", 0, invalidVideo("truncated %s box header", typ)
+		}
+		large := binary.BigEndian.Uint64(ext[:])
+		if large > math.MaxInt64 {
+			return "", 0, invalidVideo("%s box too large", typ)
+		}
+		size = int64(large)
+		headerLen = 16
+	}
+	if size < headerLen {
+		return "", 0, invalidVideo("%s box has invalid size %d", typ, size)
+	}
+	return typ, size - headerLen, nil
+}
+
+// childBoxes splits a container box's body into its children.
+func childBoxes(body []byte) ([]mp4Box, error) {
+	var boxes []mp4Box
+	for len(body) > 0 {
+		if len(body) < 8 {
+			return nil, invalidVideo("truncated box header")
+		}
+		size := uint64(binary.BigEndian.Uint32(body[:4]))
+		typ := string(body[4:8])
+		headerLen := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(body))
+		case 1:
+			if len(body) < 16 {
+				return nil, invalidVideo("truncated %s box header", typ)
+			}
+			size = binary.BigEndian.Uint64(body[8:16])
+			headerLen = 16
+		}
+		if size < headerLen || size > uint64(len(body)) {
+			return nil, invalidVideo("%s box overruns its parent", typ)
+		}
+		boxes = append(boxes, mp4Box{typ: typ, body: body[headerLen:size]})
+		body = body[size:]
+	}
+	return boxes, nil
+}
+
+func findBox(boxes []mp4Box, typ string) *mp4Box {
+	for i := range boxes {
+		if boxes[i].typ == typ {
+			return &boxes[i]
+		}
+	}
+	return nil
+}
+
+// findPath descends through nested container boxes, e.g. "mdia/minf/stbl".
+func findPath(body []byte, path string) ([]byte, error) {
+	for _, typ := range strings.Split(path, "/") {
+		children, err := childBoxes(body)
+		if err != nil {
+			return nil, err
+		}
+		box := findBox(children, typ)
+		if box == nil {
+			return nil, nil
+		}
+		body = box.body
+	}
+	return body, nil
+}
+
+// parseMP4 walks the top-level boxes until it has read moov, skipping mdat
+// and anything else. moov may come before or after the media data.
+func parseMP4(r io.Reader) (*VideoMetadata, error) {
+	sawFtyp := false
+	for {
+		typ, size, err := readBoxHeader(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if errors.Is(err, errInvalidVideo) {
+				return nil, err
+			}
+			return nil, invalidVideo("truncated file")
+		}
+
+		switch typ {
+		case "ftyp":
+			sawFtyp = true
+			if size < 0 || skip(r, size) != nil {
+				return nil, invalidVideo("truncated ftyp box")
+			}
+		case "moov":
+			if !sawFtyp {
+				return nil, invalidVideo("moov before ftyp")
+			}
+			if size < 0 || size > maxMoovSize {
+				return nil, invalidVideo("moov box too large")
+			}
+			body := make([]byte, size)
+			if _, err := io.ReadFull(r, body); err != nil {
+				return nil, invalidVideo("truncated moov box")
+			}
+			return parseMoov(body)
+		default:
+			if size < 0 {
+				// Runs to EOF without a moov.
+				return nil, invalidVideo("no moov box")
+			}
+			if err := skip(r, size); err != nil {
+				return nil, invalidVideo("truncated %s box", typ)
+			}
+		}
+	}
+	if !sawFtyp {
+		return nil, invalidVideo("no ftyp box")
+	}
+	return nil, invalidVideo("no moov box")
+}
+
+func parseMoov(moov []byte) (*VideoMetadata, error) {
+	children, err := childBoxes(moov)
+	if err != nil {
+		return nil, err
+	}
+
+	mvhd := findBox(children, "mvhd")
+	if mvhd == nil {
+		return nil, invalidVideo("no mvhd box")
+	}
+	timescale, duration, err := parseMvhd(mvhd.body)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &VideoMetadata{}
+	if duration > 0 {
+		seconds := float64(duration) / float64(timescale)
+		meta.Duration = &seconds
+	}
+
+	tracks := 0
+	for _, box := range children {
+		if box.typ != "trak" {
+			continue
+		}
+		tracks++
+		if err := parseTrak(box.body, meta); err != nil {
+			return nil, err
+		}
+	}
+	if tracks == 0 {
+		return nil, invalidVideo("no tracks")
+	}
+	return meta, nil
+}
+
+// parseMvhd returns the movie timescale and duration.
+func parseMvhd(b []byte) (uint32, uint64, error) {
+	if len(b) < 4 {
+		return 0, 0, invalidVideo("truncated mvhd box")
+	}
+	var timescale uint32
+	var duration uint64
+	switch b[0] {
+	case 0:
+		if len(b) < 20 {
+			return 0, 0, invalidVideo("truncated mvhd box")
+		}
+		timescale = binary.BigEndian.Uint32(b[12:16])
+		duration = uint64(binary.BigEndian.Uint32(b[16:20]))
+	case 1:
+		if len(b) < 32 {
+			return 0, 0, invalidVideo("truncated mvhd box")
+		}
+		timescale = binary.BigEndian.Uint32(b[20:24])
+		duration = binary.BigEndian.Uint64(b[24:32])
+	default:
+		return 0, 0, invalidVideo("unknown mvhd version %d", b[0])
+	}
+	if timescale == 0 {
+		return 0, 0, invalidVideo("mvhd timescale is zero")
+	}
+	// All-ones means "unknown" (fragmented files).
+	if duration == math.MaxUint32 || duration == math.MaxUint64 {
+		duration = 0
+	}
+	return timescale, duration, nil
+}
+
+// parseTrak fills meta from the first video and first audio track.
+func parseTrak(trak []byte, meta *VideoMetadata) error {
+	hdlr, err := findPath(trak, "mdia/hdlr")
+	if err != nil {
+		return err
+	}
+	if len(hdlr) < 12 {
+		return invalidVideo("missing or truncated hdlr box")
+	}
+	handler := string(hdlr[8:12])
+
+	codec := ""
+	stsd, err := findPath(trak, "mdia/minf/stbl/stsd")
+	if err != nil {
+		return err
+	}
+	// stsd: version/flags(4) entry_count(4), then sample entries.
+	if len(stsd) >= 16 {
+		codec = strings.TrimSpace(string(stsd[12:16]))
+	}
+
+	switch handler {
+	case "vide":
+		if meta.VideoCodec != "" {
+			return nil
+		}
+		meta.VideoCodec = codec
+		tkhd, err := findPath(trak, "tkhd")
+		if err != nil {
+			return err
+		}
+		w, h, err := parseTkhdDimensions(tkhd)
+		if err != nil {
+			return err
+		}
+		meta.Width, meta.Height = w, h
+	case "soun":
+		if meta.AudioCodec == "" {
+			meta.AudioCodec = codec
+		}
+	}
+	return nil
+}
+
+// parseTkhdDimensions reads the 16.16 fixed-point presentation size at the
+// end of the track header.
+func parseTkhdDimensions(b []byte) (int, int, error) {
+	if len(b) < 1 {
+		return 0, 0, invalidVideo("missing tkhd box")
+	}
+	// Fields before width/height: v0 = 76 bytes, v1 = 88 bytes.
+	offset := 76
+	if b[0] == 1 {
+		offset = 88
+	}
+	if len(b) < offset+8 {
+		return 0, 0, invalidVideo("truncated tkhd box")
+	}
+	w := int(binary.BigEndian.Uint32(b[offset:offset+4]) >> 16)
+	h := int(binary.BigEndian.Uint32(b[offset+4:offset+8]) >> 16)
+	return w, h, nil
+}
+
+// --- WebM (Matroska / EBML) ---
+
+const (
+	ebmlIDHeader      = 0x1A45DFA3
+	ebmlIDDocType     = 0x4282
+	ebmlIDSegment     = 0x18538067
+	ebmlIDInfo        = 0x1549A966
+	ebmlIDTimecode    = 0x2AD7B1
+	ebmlIDDuration    = 0x4489
+	ebmlIDTracks      = 0x1654AE6B
+	ebmlIDTrackEntry  = 0xAE
+	ebmlIDTrackType   = 0x83
+	ebmlIDCodecID     = 0x86
+	ebmlIDVideo       = 0xE0
+	ebmlIDPixelWidth  = 0xB0
+	ebmlIDPixelHeight = 0xBA
+	ebmlIDCluster     = 0x1F43B675
+
+	// ebmlUnknownSize marks an element whose size wasn't known when written.
+	ebmlUnknownSize = -1
+)
+
+// readVint reads an EBML variable-length integer. With keepMarker the length
+// marker bit stays in the value (element IDs); without it the value is a
+// size, and an all-ones size is reported as ebmlUnknownSize.
+func readVint(r io.Reader, keepMarker bool) (int64, error) {
+	var first [1]byte
+	if _, err := io.ReadFull(r, first[:]); err != nil {
+		return 0, err
+	}
+	length := 1
+	for mask := byte(0x80); length <= 8 && first[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 8 {
+		return 0, invalidVideo("invalid EBML varint")
+	}
+	if keepMarker && length > 4 {
+		return 0, invalidVideo("invalid EBML element ID")
+	}
+
+	value := uint64(first[0])
+	if !keepMarker {
+		value &= uint64(0xFF >> length)
+	}
+	allOnes := value == uint64(0xFF>>length)
+	if length > 1 {
+		rest := make([]byte, length-1)
+		if _, err := io.ReadFull(r, rest); err != nil {
+			return 0, invalidVideo("truncated EBML varint")
+		}
+		for _, b := range rest {
+			value = value<<8 | uint64(b)
+			allOnes = allOnes && b == 0xFF
+		}
+	}
+	if !keepMarker && allOnes {
+		return ebmlUnknownSize, nil
+	}
+	if value > math.MaxInt64 {
+		return 0, invalidVideo("EBML value too large")
+	}
+	return int64(value), nil
+}
+
+func readElementHeader(r io.Reader) (int64, int64, error) {
+	id, err := readVint(r, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	size, err := readVint(r, false)
+	if err != nil {
+		if err == io.EOF {
+			err = invalidVideo("truncated EBML element")
+		}
+		return 0, 0, err
+	}
+	return id, size, nil
+}
+
+// readElementBody reads a bounded element body into memory.
+func readElementBody(r io.Reader, id, size int64) ([]byte, error) {
+	if size < 0 || size > maxEBMLHeaderSize {
+		return nil, invalidVideo("EBML element 0x%X has unsupported size", id)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, invalidVideo("truncated EBML element 0x%X", id)
+	}
+	return body, nil
+}
+
+// ebmlChildren iterates the elements inside an in-memory master element.
+func ebmlChildren(body []byte, fn func(id int64, data []byte) error) error {
+	r := bytes.NewReader(body)
+	for r.Len() > 0 {
+		id, size, err := readElementHeader(r)
+		if err != nil {
+			if err == io.EOF {
+				return invalidVideo("truncated EBML element")
+			}
+			return err
+		}
+		if size < 0 || size > int64(r.Len()) {
+			return invalidVideo("EBML element 0x%X overruns its parent", id)
+		}
+		data := body[len(body)-r.Len() : len(body)-r.Len()+int(size)]
+		if err := fn(id, data); err != nil {
+			return err
+		}
+		r.Seek(size, io.SeekCurrent)
+	}
+	return nil
+}
+
+func ebmlUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+func ebmlFloat(b []byte) (float64, error) {
+	switch len(b) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	case 0:
+		return 0, nil
+	default:
+		return 0, invalidVideo("invalid EBML float length %d", len(b))
+	}
+}
+
+// parseWebM reads the EBML header, then the Segment's children until both
+// Info and Tracks have been seen or the first Cluster (media data) starts.
+func parseWebM(r io.Reader) (*VideoMetadata, error) {
+	id, size, err := readElementHeader(r)
+	if err != nil || id != ebmlIDHeader {
+		return nil, invalidVideo("missing EBML header")
+	}
+	header, err := readElementBody(r, id, size)
+	if err != nil {
+		return nil, err
+	}
+	docType := ""
+	if err := ebmlChildren(header, func(id int64, data []byte) error {
+		if id == ebmlIDDocType {
+			docType = string(bytes.TrimRight(data, "\x00"))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if docType != "webm" && docType != "matroska" {
+		return nil, invalidVideo("unsupported EBML doctype %q", docType)
+	}
+
+	id, _, err = readElementHeader(r)
+	if err != nil || id != ebmlIDSegment {
+		return nil, invalidVideo("missing Segment")
+	}
+
+	meta := &VideoMetadata{}
+	timecodeScale := uint64(1000000)
+	var rawDuration float64
+	sawInfo, sawTracks := false, false
+
+	for !(sawInfo && sawTracks) {
+		id, size, err := readElementHeader(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if id == ebmlIDCluster {
+			break
+		}
+
+		switch id {
+		case ebmlIDInfo:
+			body, err := readElementBody(r, id, size)
+			if err != nil {
+				return nil, err
+			}
+			sawInfo = true
+			err = ebmlChildren(body, func(id int64, data []byte) error {
+				switch id {
+				case ebmlIDTimecode:
+					if v := ebmlUint(data); v > 0 {
+						timecodeScale = v
+					}
+				case ebmlIDDuration:
+					f, err := ebmlFloat(data)
+					if err != nil {
+						return err
+					}
+					rawDuration = f
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		case ebmlIDTracks:
+			body, err := readElementBody(r, id, size)
+			if err != nil {
+				return nil, err
+			}
+			sawTracks = true
+			if err := ebmlChildren(body, func(id int64, data []byte) error {
+				if id == ebmlIDTrackEntry {
+					return parseTrackEntry(data, meta)
+				}
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+		default:
+			if size == ebmlUnknownSize {
+				return nil, invalidVideo("EBML element 0x%X has unknown size", id)
+			}
+			if err := skip(r, size); err != nil {
+				return nil, invalidVideo("truncated EBML element 0x%X", id)
+			}
+		}
+	}
+
+	if !sawTracks {
+		return nil, invalidVideo("no Tracks element")
+	}
+	if rawDuration > 0 {
+		seconds := rawDuration * float64(timecodeScale) / 1e9
+		meta.Duration = &seconds
+	}
+	return meta, nil
+}
+
+func parseTrackEntry(entry []byte, meta *VideoMetadata) error {
+	var trackType uint64
+	var codecID string
+	var width, height int
+	err := ebmlChildren(entry, func(id int64, data []byte) error {
+		switch id {
+		case ebmlIDTrackType:
+			trackType = ebmlUint(data)
+		case ebmlIDCodecID:
+			codecID = string(bytes.TrimRight(data, "\x00"))
+		case ebmlIDVideo:
+			return ebmlChildren(data, func(id int64, data []byte) error {
+				switch id {
+				case ebmlIDPixelWidth:
+					width = int(ebmlUint(data))
+				case ebmlIDPixelHeight:
+					height = int(ebmlUint(data))
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	switch trackType {
+	case 1:
+		if meta.VideoCodec == "" {
+			meta.VideoCodec = codecID
+			meta.Width, meta.Height = width, height
+		}
+	case 2:
+		if meta.AudioCodec == "" {
+			meta.AudioCodec = codecID
+		}
+	}
+	return nil
+}
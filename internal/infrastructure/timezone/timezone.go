@@ -0,0 +1,16 @@
+// Package timezone holds the zone the API renders and reads dates in.
+package timezone
+
+import "time"
+
+// BRT is Brasilia Time (UTC-3), loaded once at package init. pgx returns
+// timestamptz values in UTC; API responses and logs convert to BRT, and
+// date-only query values are read in it.
+var BRT = func() *time.Location {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		// Fall back to a fixed UTC-3 offset if the timezone database is unavailable.
+		loc = time.FixedZone("BRT", -3*60*60)
+	}
+	return loc
+}()